@@ -1,6 +1,7 @@
 package crapsgame
 
 import (
+	"fmt"
 	"sort"
 )
 
@@ -46,7 +47,8 @@ type CanonicalBetDefinition struct {
 	RequiresPoint     bool
 	RequiresComeOut   bool
 	HouseEdge         float64
-	Commission        float64 // Commission rate (0.05 for 5%)
+	Commission        float64         // Commission rate (0.05 for 5%)
+	SpecialPayouts    map[int]float64 // Payout multipliers for specific roll totals (field 2 and 12)
 }
 
 // CanonicalBetDefinitions contains ALL bet definitions in a single source of truth
@@ -192,6 +194,7 @@ var CanonicalBetDefinitions = map[string]CanonicalBetDefinition{
 		RequiresComeOut:   false,
 		HouseEdge:         2.78,
 		Commission:        0.0,
+		SpecialPayouts:    map[int]float64{2: 2, 12: 3},
 	},
 
 	// Place Bets
@@ -715,6 +718,21 @@ var CanonicalBetDefinitions = map[string]CanonicalBetDefinition{
 		HouseEdge:         13.89,
 		Commission:        0.0,
 	},
+	"COME_OUT_HARDWAYS": {
+		Name:              "Come-Out Hardways",
+		Category:          PropositionBets,
+		Description:       "Bet that the come-out roll will be any hardway (2-2, 3-3, 4-4, 5-5)",
+		Payout:            "7:1",
+		WorkingBehavior:   "ONE_ROLL",
+		OneRoll:           true,
+		PayoutNumerator:   7,
+		PayoutDenominator: 1,
+		ValidNumbers:      []int{4, 6, 8, 10},
+		RequiresPoint:     false,
+		RequiresComeOut:   true, // Only offered on the come-out roll
+		HouseEdge:         11.11,
+		Commission:        0.0,
+	},
 
 	// Horn Bets
 	"HORN_HIGH_2": {
@@ -1139,6 +1157,23 @@ var CanonicalBetDefinitions = map[string]CanonicalBetDefinition{
 		Commission:        0.0,
 	},
 
+	// Hop Hard 4 (2-2)
+	"HOP_HARD_4": {
+		Name:              "Hop Hard 4",
+		Category:          HopBets,
+		Description:       "Hop bet on hard 4 (2-2)",
+		Payout:            "30:1",
+		WorkingBehavior:   "ONE_ROLL",
+		OneRoll:           true,
+		PayoutNumerator:   30,
+		PayoutDenominator: 1,
+		ValidNumbers:      []int{4},
+		RequiresPoint:     false,
+		RequiresComeOut:   false,
+		HouseEdge:         13.89,
+		Commission:        0.0,
+	},
+
 	// Hop Hard 6 (3-3)
 	"HOP_HARD_6": {
 		Name:              "Hop Hard 6",
@@ -1156,6 +1191,40 @@ var CanonicalBetDefinitions = map[string]CanonicalBetDefinition{
 		Commission:        0.0,
 	},
 
+	// Hop Hard 8 (4-4)
+	"HOP_HARD_8": {
+		Name:              "Hop Hard 8",
+		Category:          HopBets,
+		Description:       "Hop bet on hard 8 (4-4)",
+		Payout:            "30:1",
+		WorkingBehavior:   "ONE_ROLL",
+		OneRoll:           true,
+		PayoutNumerator:   30,
+		PayoutDenominator: 1,
+		ValidNumbers:      []int{8},
+		RequiresPoint:     false,
+		RequiresComeOut:   false,
+		HouseEdge:         13.89,
+		Commission:        0.0,
+	},
+
+	// Hop Hard 10 (5-5)
+	"HOP_HARD_10": {
+		Name:              "Hop Hard 10",
+		Category:          HopBets,
+		Description:       "Hop bet on hard 10 (5-5)",
+		Payout:            "30:1",
+		WorkingBehavior:   "ONE_ROLL",
+		OneRoll:           true,
+		PayoutNumerator:   30,
+		PayoutDenominator: 1,
+		ValidNumbers:      []int{10},
+		RequiresPoint:     false,
+		RequiresComeOut:   false,
+		HouseEdge:         13.89,
+		Commission:        0.0,
+	},
+
 	// Hop Easy 8 (any combination except 4-4)
 	"HOP_EASY_8": {
 		Name:              "Hop Easy 8",
@@ -1266,6 +1335,8 @@ func GetBetsByHouseEdge() []string {
 // --- RESOLVER FUNCTIONS FOR ALL CANONICAL BET TYPES ---
 
 // Generic resolver for Place bets (handles Place 4, 5, 6, 8, 9, 10)
+// A place bet on the point number is its own wager, decided on its number
+// regardless of the line bet; making the point pays both and leaves it up.
 func resolvePlaceBet(bet *Bet, roll *Roll, state GameState) (bool, float64, bool) {
 	if len(bet.Numbers) == 0 {
 		return false, 0, false
@@ -1273,7 +1344,7 @@ func resolvePlaceBet(bet *Bet, roll *Roll, state GameState) (bool, float64, bool
 	num := bet.Numbers[0]
 	if roll.Total == num {
 		def, _ := CanonicalBetDefinitions[bet.Type]
-		payout := bet.Amount * float64(def.PayoutNumerator) / float64(def.PayoutDenominator)
+		payout := payoutAt(bet.Amount, def.PayoutNumerator, def.PayoutDenominator)
 		return true, payout, false // Win and continue
 	} else if roll.Total == 7 && state == StatePoint {
 		// Place bets only lose to 7 during point phase, not come-out
@@ -1290,9 +1361,12 @@ func resolveBuyBet(bet *Bet, roll *Roll, state GameState) (bool, float64, bool)
 	num := bet.Numbers[0]
 	if roll.Total == num {
 		def, _ := CanonicalBetDefinitions[bet.Type]
-		gross := bet.Amount * float64(def.PayoutNumerator) / float64(def.PayoutDenominator)
-		commission := bet.Amount * def.Commission
-		return true, gross - commission, false // Win and continue
+		gross := payoutAt(bet.Amount, def.PayoutNumerator, def.PayoutDenominator)
+		if bet.CommissionPaid > 0 {
+			return true, gross, false // Vig already paid at placement
+		}
+		commission := ToMoney(bet.Amount * def.Commission).Dollars()
+		return true, subDollars(gross, commission), false // Win and continue
 	} else if roll.Total == 7 && state == StatePoint {
 		// Buy bets only lose to 7 during point phase, not come-out
 		return false, 0, true // Lose and remove
@@ -1308,9 +1382,12 @@ func resolveLayBet(bet *Bet, roll *Roll, state GameState) (bool, float64, bool)
 	num := bet.Numbers[0]
 	if roll.Total == 7 {
 		def, _ := CanonicalBetDefinitions[bet.Type]
-		gross := bet.Amount * float64(def.PayoutNumerator) / float64(def.PayoutDenominator)
-		commission := bet.Amount * def.Commission
-		return true, gross - commission, false // Win and continue
+		gross := payoutAt(bet.Amount, def.PayoutNumerator, def.PayoutDenominator)
+		if bet.CommissionPaid > 0 {
+			return true, gross, false // Vig already paid at placement
+		}
+		commission := ToMoney(bet.Amount * def.Commission).Dollars()
+		return true, subDollars(gross, commission), false // Win and continue
 	} else if roll.Total == num {
 		return false, 0, true // Lose and remove
 	}
@@ -1325,7 +1402,7 @@ func resolvePlaceToLoseBet(bet *Bet, roll *Roll, state GameState) (bool, float64
 	num := bet.Numbers[0]
 	if roll.Total == 7 {
 		def, _ := CanonicalBetDefinitions[bet.Type]
-		payout := bet.Amount * float64(def.PayoutNumerator) / float64(def.PayoutDenominator)
+		payout := payoutAt(bet.Amount, def.PayoutNumerator, def.PayoutDenominator)
 		return true, payout, false // Win and continue
 	} else if roll.Total == num {
 		return false, 0, true // Lose and remove
@@ -1339,11 +1416,12 @@ func resolveHardwayBet(bet *Bet, roll *Roll, state GameState) (bool, float64, bo
 		return false, 0, false
 	}
 	num := bet.Numbers[0]
-	if roll.Total == num && roll.IsHard {
+	hard := roll.Die1 == roll.Die2
+	if roll.Total == num && hard {
 		def, _ := CanonicalBetDefinitions[bet.Type]
-		payout := bet.Amount * float64(def.PayoutNumerator) / float64(def.PayoutDenominator)
+		payout := payoutAt(bet.Amount, def.PayoutNumerator, def.PayoutDenominator)
 		return true, payout, false // Win and continue
-	} else if roll.Total == num && !roll.IsHard {
+	} else if roll.Total == num && !hard {
 		return false, 0, true // Lose and remove
 	} else if roll.Total == 7 && state == StatePoint {
 		// Hardway bets only lose to 7 during point phase, not come-out
@@ -1357,7 +1435,7 @@ func resolvePassLine(bet *Bet, roll *Roll, state GameState) (bool, float64, bool
 	def, _ := CanonicalBetDefinitions[bet.Type]
 	if state == StateComeOut {
 		if roll.Total == 7 || roll.Total == 11 {
-			return true, bet.Amount * float64(def.PayoutNumerator) / float64(def.PayoutDenominator), true
+			return true, payoutAt(bet.Amount, def.PayoutNumerator, def.PayoutDenominator), true
 		} else if roll.Total == 2 || roll.Total == 3 || roll.Total == 12 {
 			return false, 0, true
 		}
@@ -1372,7 +1450,7 @@ func resolvePassLine(bet *Bet, roll *Roll, state GameState) (bool, float64, bool
 		}
 		point := bet.Numbers[0]
 		if roll.Total == point {
-			return true, bet.Amount * float64(def.PayoutNumerator) / float64(def.PayoutDenominator), true
+			return true, payoutAt(bet.Amount, def.PayoutNumerator, def.PayoutDenominator), true
 		} else if roll.Total == 7 {
 			return false, 0, true
 		}
@@ -1387,7 +1465,7 @@ func resolveDontPass(bet *Bet, roll *Roll, state GameState) (bool, float64, bool
 	def, _ := CanonicalBetDefinitions[bet.Type]
 	if state == StateComeOut {
 		if roll.Total == 2 || roll.Total == 3 {
-			return true, bet.Amount * float64(def.PayoutNumerator) / float64(def.PayoutDenominator), true
+			return true, payoutAt(bet.Amount, def.PayoutNumerator, def.PayoutDenominator), true
 		} else if roll.Total == 12 {
 			return true, 0, true // push - return bet amount (payout=0 means no extra winnings)
 		} else if roll.Total == 7 || roll.Total == 11 {
@@ -1400,7 +1478,7 @@ func resolveDontPass(bet *Bet, roll *Roll, state GameState) (bool, float64, bool
 		}
 		point := bet.Numbers[0]
 		if roll.Total == 7 {
-			return true, bet.Amount * float64(def.PayoutNumerator) / float64(def.PayoutDenominator), true
+			return true, payoutAt(bet.Amount, def.PayoutNumerator, def.PayoutDenominator), true
 		} else if roll.Total == point {
 			return false, 0, true
 		}
@@ -1408,14 +1486,103 @@ func resolveDontPass(bet *Bet, roll *Roll, state GameState) (bool, float64, bool
 	return false, 0, false
 }
 
+// Come bet resolver. A come bet has its own come-out on the first roll after
+// it is placed: 7/11 win, 2/3/12 lose, and any other number becomes its come
+// point (stored in bet.Numbers). It then wins on that number and loses on 7,
+// independent of the table point.
+func resolveComeBet(bet *Bet, roll *Roll, state GameState) (bool, float64, bool) {
+	def, _ := CanonicalBetDefinitions[bet.Type]
+	if len(bet.Numbers) == 0 {
+		switch roll.Total {
+		case 7, 11:
+			return true, payoutAt(bet.Amount, def.PayoutNumerator, def.PayoutDenominator), true
+		case 2, 3, 12:
+			return false, 0, true
+		}
+		bet.Numbers = []int{roll.Total} // Travel to the come point
+		return false, 0, false
+	}
+
+	if roll.Total == bet.Numbers[0] {
+		return true, payoutAt(bet.Amount, def.PayoutNumerator, def.PayoutDenominator), true
+	} else if roll.Total == 7 {
+		return false, 0, true
+	}
+	return false, 0, false
+}
+
+// Don't come bet resolver, the mirror of resolveComeBet: 2/3 win, 12 pushes,
+// 7/11 lose, then it wins on 7 and loses on its come point
+func resolveDontComeBet(bet *Bet, roll *Roll, state GameState) (bool, float64, bool) {
+	def, _ := CanonicalBetDefinitions[bet.Type]
+	if len(bet.Numbers) == 0 {
+		switch roll.Total {
+		case 2, 3:
+			return true, payoutAt(bet.Amount, def.PayoutNumerator, def.PayoutDenominator), true
+		case 12:
+			return true, 0, true // push - return bet amount
+		case 7, 11:
+			return false, 0, true
+		}
+		bet.Numbers = []int{roll.Total} // Travel behind the come point
+		return false, 0, false
+	}
+
+	if roll.Total == 7 {
+		return true, payoutAt(bet.Amount, def.PayoutNumerator, def.PayoutDenominator), true
+	} else if roll.Total == bet.Numbers[0] {
+		return false, 0, true
+	}
+	return false, 0, false
+}
+
+// Come odds resolver. The odds share the come point of the come bet they back
+// (copied into bet.Numbers at placement) and pay true odds for that number.
+func resolveComeOdds(bet *Bet, roll *Roll, state GameState) (bool, float64, bool) {
+	if len(bet.Numbers) == 0 {
+		return false, 0, false
+	}
+	point := bet.Numbers[0]
+
+	if roll.Total == point {
+		oddsNum, oddsDen, err := PointOdds(point)
+		if err != nil {
+			return false, 0, true // Invalid point
+		}
+		return true, payoutAt(bet.Amount, oddsNum, oddsDen), true
+	} else if roll.Total == 7 {
+		return false, 0, true
+	}
+	return false, 0, false
+}
+
+// Don't come odds resolver, laying true odds against the don't come point
+func resolveDontComeOdds(bet *Bet, roll *Roll, state GameState) (bool, float64, bool) {
+	if len(bet.Numbers) == 0 {
+		return false, 0, false
+	}
+	point := bet.Numbers[0]
+
+	if roll.Total == 7 {
+		// Laying the odds pays the inverse of taking them
+		oddsNum, oddsDen, err := PointOdds(point)
+		if err != nil {
+			return false, 0, true // Invalid point
+		}
+		return true, payoutAt(bet.Amount, oddsDen, oddsNum), true
+	} else if roll.Total == point {
+		return false, 0, true
+	}
+	return false, 0, false
+}
+
 // Field bet resolver
 func resolveFieldBet(bet *Bet, roll *Roll, state GameState) (bool, float64, bool) {
-	if roll.Total == 2 {
-		return true, bet.Amount * 2, true // 2:1 odds = bet + 2*bet winnings
-	} else if roll.Total == 12 {
-		return true, bet.Amount * 3, true // 3:1 odds = bet + 3*bet winnings
+	def, _ := CanonicalBetDefinitions[bet.Type]
+	if multiplier, ok := def.SpecialPayouts[roll.Total]; ok {
+		return true, payoutTimes(bet.Amount, multiplier), true // 2 pays 2:1, 12 pays 3:1
 	} else if roll.Total == 3 || roll.Total == 4 || roll.Total == 9 || roll.Total == 10 || roll.Total == 11 {
-		return true, bet.Amount * 1, true // 1:1 odds = bet + 1*bet winnings
+		return true, bet.Amount, true // 1:1 odds = bet + 1*bet winnings
 	}
 	return false, 0, true
 }
@@ -1424,7 +1591,7 @@ func resolveFieldBet(bet *Bet, roll *Roll, state GameState) (bool, float64, bool
 func resolveAnySeven(bet *Bet, roll *Roll, state GameState) (bool, float64, bool) {
 	def, _ := CanonicalBetDefinitions[bet.Type]
 	if roll.Total == 7 {
-		return true, bet.Amount * float64(def.PayoutNumerator) / float64(def.PayoutDenominator), true
+		return true, payoutAt(bet.Amount, def.PayoutNumerator, def.PayoutDenominator), true
 	}
 	return false, 0, true
 }
@@ -1433,7 +1600,7 @@ func resolveAnySeven(bet *Bet, roll *Roll, state GameState) (bool, float64, bool
 func resolveAnyCraps(bet *Bet, roll *Roll, state GameState) (bool, float64, bool) {
 	def, _ := CanonicalBetDefinitions[bet.Type]
 	if roll.Total == 2 || roll.Total == 3 || roll.Total == 12 {
-		return true, bet.Amount * float64(def.PayoutNumerator) / float64(def.PayoutDenominator), true
+		return true, payoutAt(bet.Amount, def.PayoutNumerator, def.PayoutDenominator), true
 	}
 	return false, 0, true
 }
@@ -1442,7 +1609,7 @@ func resolveAnyCraps(bet *Bet, roll *Roll, state GameState) (bool, float64, bool
 func resolveEleven(bet *Bet, roll *Roll, state GameState) (bool, float64, bool) {
 	def, _ := CanonicalBetDefinitions[bet.Type]
 	if roll.Total == 11 {
-		return true, bet.Amount * float64(def.PayoutNumerator) / float64(def.PayoutDenominator), true
+		return true, payoutAt(bet.Amount, def.PayoutNumerator, def.PayoutDenominator), true
 	}
 	return false, 0, true
 }
@@ -1451,7 +1618,7 @@ func resolveEleven(bet *Bet, roll *Roll, state GameState) (bool, float64, bool)
 func resolveAceDeuce(bet *Bet, roll *Roll, state GameState) (bool, float64, bool) {
 	def, _ := CanonicalBetDefinitions[bet.Type]
 	if roll.Total == 3 {
-		return true, bet.Amount * float64(def.PayoutNumerator) / float64(def.PayoutDenominator), true
+		return true, payoutAt(bet.Amount, def.PayoutNumerator, def.PayoutDenominator), true
 	}
 	return false, 0, true
 }
@@ -1460,7 +1627,7 @@ func resolveAceDeuce(bet *Bet, roll *Roll, state GameState) (bool, float64, bool
 func resolveAces(bet *Bet, roll *Roll, state GameState) (bool, float64, bool) {
 	def, _ := CanonicalBetDefinitions[bet.Type]
 	if roll.Total == 2 {
-		return true, bet.Amount * float64(def.PayoutNumerator) / float64(def.PayoutDenominator), true
+		return true, payoutAt(bet.Amount, def.PayoutNumerator, def.PayoutDenominator), true
 	}
 	return false, 0, true
 }
@@ -1469,7 +1636,16 @@ func resolveAces(bet *Bet, roll *Roll, state GameState) (bool, float64, bool) {
 func resolveBoxcars(bet *Bet, roll *Roll, state GameState) (bool, float64, bool) {
 	def, _ := CanonicalBetDefinitions[bet.Type]
 	if roll.Total == 12 {
-		return true, bet.Amount * float64(def.PayoutNumerator) / float64(def.PayoutDenominator), true
+		return true, payoutAt(bet.Amount, def.PayoutNumerator, def.PayoutDenominator), true
+	}
+	return false, 0, true
+}
+
+// Come-out hardways resolver
+func resolveComeOutHardways(bet *Bet, roll *Roll, state GameState) (bool, float64, bool) {
+	def, _ := CanonicalBetDefinitions[bet.Type]
+	if roll.Die1 == roll.Die2 && roll.Total >= 4 && roll.Total <= 10 {
+		return true, payoutAt(bet.Amount, def.PayoutNumerator, def.PayoutDenominator), true
 	}
 	return false, 0, true
 }
@@ -1485,50 +1661,50 @@ func resolveHornBet(bet *Bet, roll *Roll, state GameState) (bool, float64, bool)
 		if roll.Total == 2 || roll.Total == 3 || roll.Total == 11 || roll.Total == 12 {
 			// Standard horn payout: 3:1 for 3, 11, 12; 27:4 for 2
 			if roll.Total == 2 || roll.Total == 12 {
-				payout = bet.Amount * 27.0 / 4.0
+				payout = payoutAt(bet.Amount, 27, 4)
 			} else {
-				payout = bet.Amount * 3.0
+				payout = payoutAt(bet.Amount, 3, 1)
 			}
 			win = true
 		}
 	case "HORN_HIGH_2":
 		if roll.Total == 2 {
-			payout = bet.Amount * 27.0 / 4.0
+			payout = payoutAt(bet.Amount, 27, 4)
 			win = true
 		} else if roll.Total == 3 || roll.Total == 11 || roll.Total == 12 {
-			payout = bet.Amount * 3.0
+			payout = payoutAt(bet.Amount, 3, 1)
 			win = true
 		}
 	case "HORN_HIGH_3":
 		if roll.Total == 3 {
-			payout = bet.Amount * 15.0
+			payout = payoutAt(bet.Amount, 15, 1)
 			win = true
 		} else if roll.Total == 2 || roll.Total == 11 || roll.Total == 12 {
-			payout = bet.Amount * 3.0
+			payout = payoutAt(bet.Amount, 3, 1)
 			win = true
 		}
 	case "HORN_HIGH_11":
 		if roll.Total == 11 {
-			payout = bet.Amount * 15.0
+			payout = payoutAt(bet.Amount, 15, 1)
 			win = true
 		} else if roll.Total == 2 || roll.Total == 3 || roll.Total == 12 {
-			payout = bet.Amount * 3.0
+			payout = payoutAt(bet.Amount, 3, 1)
 			win = true
 		}
 	case "HORN_HIGH_12":
 		if roll.Total == 12 {
-			payout = bet.Amount * 27.0 / 4.0
+			payout = payoutAt(bet.Amount, 27, 4)
 			win = true
 		} else if roll.Total == 2 || roll.Total == 3 || roll.Total == 11 {
-			payout = bet.Amount * 3.0
+			payout = payoutAt(bet.Amount, 3, 1)
 			win = true
 		}
 	case "HORN_HIGH_ACE_DEUCE":
 		if roll.Total == 3 {
-			payout = bet.Amount * 15.0
+			payout = payoutAt(bet.Amount, 15, 1)
 			win = true
 		} else if roll.Total == 2 || roll.Total == 11 || roll.Total == 12 {
-			payout = bet.Amount * 3.0
+			payout = payoutAt(bet.Amount, 3, 1)
 			win = true
 		}
 	}
@@ -1536,30 +1712,45 @@ func resolveHornBet(bet *Bet, roll *Roll, state GameState) (bool, float64, bool)
 }
 
 // --- HOP BETS RESOLVER ---
+// Hop bets are one-roll bets on the exact faces of the dice, in either order
 func resolveHopBet(bet *Bet, roll *Roll, state GameState) (bool, float64, bool) {
-	// Hop bets are one-roll bets on a specific dice combination
-	// Use ValidNumbers for the total, and bet.Type for hard/easy
 	def, _ := CanonicalBetDefinitions[bet.Type]
-	if len(def.ValidNumbers) == 0 {
-		return false, 0, true
+	win := false
+	if bet.Type == "HOP_EASY_8" {
+		// Any 8 but 4-4
+		win = roll.Total == 8 && roll.Die1 != roll.Die2
+	} else if a, b, ok := hopFaces(bet.Type); ok {
+		win = (roll.Die1 == a && roll.Die2 == b) || (roll.Die1 == b && roll.Die2 == a)
 	}
-	if roll.Total == def.ValidNumbers[0] {
-		// For hard hops, check if IsHard is required
-		if bet.Type == "HOP_HARD_6" && roll.Total == 6 && roll.IsHard {
-			payout := bet.Amount * float64(def.PayoutNumerator) / float64(def.PayoutDenominator)
-			return true, payout, true
-		}
-		if bet.Type == "HOP_EASY_8" && roll.Total == 8 && !roll.IsHard {
-			payout := bet.Amount * float64(def.PayoutNumerator) / float64(def.PayoutDenominator)
-			return true, payout, true
-		}
-		// For generic hops, just pay out
-		payout := bet.Amount * float64(def.PayoutNumerator) / float64(def.PayoutDenominator)
-		return true, payout, true
+	if win {
+		return true, payoutAt(bet.Amount, def.PayoutNumerator, def.PayoutDenominator), true
 	}
 	return false, 0, true
 }
 
+// hopFaces returns the two faces a hop bet is on, from HOP_<a>_<b> or
+// HOP_HARD_<total>
+func hopFaces(betType string) (int, int, bool) {
+	var a, b int
+	if n, _ := fmt.Sscanf(betType, "HOP_HARD_%d", &a); n == 1 {
+		return a / 2, a / 2, a%2 == 0
+	}
+	if n, _ := fmt.Sscanf(betType, "HOP_%d_%d", &a, &b); n == 2 {
+		return a, b, true
+	}
+	return 0, 0, false
+}
+
+// facesMatter reports whether a bet is decided by the faces of the dice
+// rather than just their total
+func facesMatter(betType string) bool {
+	def, exists := CanonicalBetDefinitions[betType]
+	if !exists {
+		return false
+	}
+	return def.Category == HardWayBets || def.Category == HopBets || betType == "COME_OUT_HARDWAYS"
+}
+
 // --- COMBINATION BETS RESOLVER ---
 func resolveCombinationBet(bet *Bet, roll *Roll, state GameState) (bool, float64, bool) {
 	switch bet.Type {
@@ -1627,7 +1818,7 @@ func resolveBigBet(bet *Bet, roll *Roll, state GameState) (bool, float64, bool)
 	num := bet.Numbers[0]
 	if roll.Total == num {
 		def, _ := CanonicalBetDefinitions[bet.Type]
-		payout := bet.Amount * float64(def.PayoutNumerator) / float64(def.PayoutDenominator)
+		payout := payoutAt(bet.Amount, def.PayoutNumerator, def.PayoutDenominator)
 		return true, payout, false // Win and continue
 	} else if roll.Total == 7 && state == StatePoint {
 		// Big 6/8 bets only lose to 7 during point phase, not come-out
@@ -1640,7 +1831,7 @@ func resolveBigBet(bet *Bet, roll *Roll, state GameState) (bool, float64, bool)
 func resolveWorldBet(bet *Bet, roll *Roll, state GameState) (bool, float64, bool) {
 	if roll.Total == 7 {
 		// Any 7 pays 4:1
-		return true, bet.Amount * 4, false // Win and continue
+		return true, payoutAt(bet.Amount, 4, 1), false // Win and continue
 	} else if roll.Total == 2 || roll.Total == 3 || roll.Total == 12 {
 		// Any craps pays 1:1
 		return true, bet.Amount, false // Win and continue
@@ -1652,10 +1843,10 @@ func resolveWorldBet(bet *Bet, roll *Roll, state GameState) (bool, float64, bool
 func resolveCAndEBet(bet *Bet, roll *Roll, state GameState) (bool, float64, bool) {
 	if roll.Total == 2 || roll.Total == 3 || roll.Total == 12 {
 		// Any craps pays 3:1
-		return true, bet.Amount * 3, false // Win and continue
+		return true, payoutAt(bet.Amount, 3, 1), false // Win and continue
 	} else if roll.Total == 11 {
 		// Eleven pays 7:1
-		return true, bet.Amount * 7, false // Win and continue
+		return true, payoutAt(bet.Amount, 7, 1), false // Win and continue
 	}
 	return false, 0, false // Continue
 }
@@ -1699,10 +1890,16 @@ var BetTypeResolvers = map[string]BetResolutionFunc{
 	"PASS_LINE": resolvePassLine,
 	// Don't Pass
 	"DONT_PASS": resolveDontPass,
+	// Come and Don't Come
+	"COME":      resolveComeBet,
+	"DONT_COME": resolveDontComeBet,
 	// Pass Odds
 	"PASS_ODDS": resolvePassOdds,
 	// Don't Pass Odds
 	"DONT_PASS_ODDS": resolveDontPassOdds,
+	// Come and Don't Come Odds
+	"COME_ODDS":      resolveComeOdds,
+	"DONT_COME_ODDS": resolveDontComeOdds,
 	// Field
 	"FIELD": resolveFieldBet,
 	// Any Seven
@@ -1717,6 +1914,8 @@ var BetTypeResolvers = map[string]BetResolutionFunc{
 	"ACES": resolveAces,
 	// Boxcars
 	"BOXCARS": resolveBoxcars,
+	// Come-out hardways
+	"COME_OUT_HARDWAYS": resolveComeOutHardways,
 	// Horn bets
 	"HORN":                resolveHornBet,
 	"HORN_HIGH_2":         resolveHornBet,
@@ -1725,24 +1924,27 @@ var BetTypeResolvers = map[string]BetResolutionFunc{
 	"HORN_HIGH_12":        resolveHornBet,
 	"HORN_HIGH_ACE_DEUCE": resolveHornBet,
 	// Hop bets
-	"HOP":        resolveHopBet,
-	"HOP_HARD_6": resolveHopBet,
-	"HOP_EASY_8": resolveHopBet,
-	"HOP_1_2":    resolveHopBet,
-	"HOP_1_3":    resolveHopBet,
-	"HOP_1_4":    resolveHopBet,
-	"HOP_1_5":    resolveHopBet,
-	"HOP_1_6":    resolveHopBet,
-	"HOP_2_3":    resolveHopBet,
-	"HOP_2_4":    resolveHopBet,
-	"HOP_2_5":    resolveHopBet,
-	"HOP_2_6":    resolveHopBet,
-	"HOP_3_4":    resolveHopBet,
-	"HOP_3_5":    resolveHopBet,
-	"HOP_3_6":    resolveHopBet,
-	"HOP_4_5":    resolveHopBet,
-	"HOP_4_6":    resolveHopBet,
-	"HOP_5_6":    resolveHopBet,
+	"HOP":         resolveHopBet,
+	"HOP_HARD_4":  resolveHopBet,
+	"HOP_HARD_6":  resolveHopBet,
+	"HOP_HARD_8":  resolveHopBet,
+	"HOP_HARD_10": resolveHopBet,
+	"HOP_EASY_8":  resolveHopBet,
+	"HOP_1_2":     resolveHopBet,
+	"HOP_1_3":     resolveHopBet,
+	"HOP_1_4":     resolveHopBet,
+	"HOP_1_5":     resolveHopBet,
+	"HOP_1_6":     resolveHopBet,
+	"HOP_2_3":     resolveHopBet,
+	"HOP_2_4":     resolveHopBet,
+	"HOP_2_5":     resolveHopBet,
+	"HOP_2_6":     resolveHopBet,
+	"HOP_3_4":     resolveHopBet,
+	"HOP_3_5":     resolveHopBet,
+	"HOP_3_6":     resolveHopBet,
+	"HOP_4_5":     resolveHopBet,
+	"HOP_4_6":     resolveHopBet,
+	"HOP_5_6":     resolveHopBet,
 	// Combination bets
 	"PLACE_NUMBERS": resolveCombinationBet,
 	"PLACE_INSIDE":  resolveCombinationBet,
@@ -1772,7 +1974,7 @@ func ResolveBet(bet *Bet, roll *Roll, state GameState, currentPoint int) (bool,
 		if state == StateComeOut {
 			// Come out roll logic
 			if roll.Total == 7 || roll.Total == 11 {
-				payout := bet.Amount * float64(def.PayoutNumerator) / float64(def.PayoutDenominator)
+				payout := payoutAt(bet.Amount, def.PayoutNumerator, def.PayoutDenominator)
 				return true, payout, true
 			} else if roll.Total == 2 || roll.Total == 3 || roll.Total == 12 {
 				return false, 0, true
@@ -1785,7 +1987,7 @@ func ResolveBet(bet *Bet, roll *Roll, state GameState, currentPoint int) (bool,
 				return false, 0, false
 			}
 			if roll.Total == currentPoint {
-				payout := bet.Amount * float64(def.PayoutNumerator) / float64(def.PayoutDenominator)
+				payout := payoutAt(bet.Amount, def.PayoutNumerator, def.PayoutDenominator)
 				return true, payout, true
 			} else if roll.Total == 7 {
 				return false, 0, true
@@ -1795,64 +1997,17 @@ func ResolveBet(bet *Bet, roll *Roll, state GameState, currentPoint int) (bool,
 		}
 	}
 
-	// For pass odds bets, we need special handling to use the current point
-	if bet.Type == "PASS_ODDS" {
-		if state != StatePoint || currentPoint == 0 {
-			return false, 0, false
-		}
-
-		if roll.Total == currentPoint {
-			// Point made - odds bet wins at true odds
-			var payoutMultiplier float64
-			switch currentPoint {
-			case 4, 10:
-				payoutMultiplier = 2.0 // 2:1 true odds
-			case 5, 9:
-				payoutMultiplier = 1.5 // 3:2 true odds
-			case 6, 8:
-				payoutMultiplier = 1.2 // 6:5 true odds
-			default:
-				return false, 0, true // Invalid point
-			}
-			payout := bet.Amount * payoutMultiplier
-			return true, payout, true
-		} else if roll.Total == 7 {
-			// Seven out - odds bet loses
-			return false, 0, true
-		}
-
-		// Any other roll - bet continues
-		return false, 0, false
-	}
-
-	// For don't pass odds bets, we need special handling to use the current point
-	if bet.Type == "DONT_PASS_ODDS" {
-		if state != StatePoint || currentPoint == 0 {
-			return false, 0, false
-		}
-
-		if roll.Total == 7 {
-			// Seven out - don't pass odds bet wins at true odds
-			var payoutMultiplier float64
-			switch currentPoint {
-			case 4, 10:
-				payoutMultiplier = 0.5 // 1:2 true odds
-			case 5, 9:
-				payoutMultiplier = 0.667 // 2:3 true odds
-			case 6, 8:
-				payoutMultiplier = 0.833 // 5:6 true odds
-			default:
-				return false, 0, true // Invalid point
+	// Pass odds, don't pass bets and their odds are decided on the table point.
+	// Before a point is set the odds have nothing to resolve against and stay up.
+	if bet.Type == "PASS_ODDS" || bet.Type == "DONT_PASS" || bet.Type == "DONT_PASS_ODDS" {
+		if state == StatePoint {
+			if currentPoint == 0 {
+				return false, 0, false
 			}
-			payout := bet.Amount * payoutMultiplier
-			return true, payout, true
-		} else if roll.Total == currentPoint {
-			// Point made - don't pass odds bet loses
-			return false, 0, true
+			onPoint := *bet
+			onPoint.Numbers = []int{currentPoint}
+			return resolver(&onPoint, roll, state)
 		}
-
-		// Any other roll - bet continues
-		return false, 0, false
 	}
 
 	// Use the standard resolver for all other bet types
@@ -1875,18 +2030,11 @@ func resolvePassOdds(bet *Bet, roll *Roll, state GameState) (bool, float64, bool
 
 	if roll.Total == point {
 		// Point made - odds bet wins at true odds
-		var payoutMultiplier float64
-		switch point {
-		case 4, 10:
-			payoutMultiplier = 2.0 // 2:1 true odds
-		case 5, 9:
-			payoutMultiplier = 1.5 // 3:2 true odds
-		case 6, 8:
-			payoutMultiplier = 1.2 // 6:5 true odds
-		default:
+		oddsNum, oddsDen, err := PointOdds(point)
+		if err != nil {
 			return false, 0, true // Invalid point
 		}
-		payout := bet.Amount * payoutMultiplier
+		payout := payoutAt(bet.Amount, oddsNum, oddsDen)
 		return true, payout, true
 	} else if roll.Total == 7 {
 		// Seven out - odds bet loses
@@ -1897,7 +2045,8 @@ func resolvePassOdds(bet *Bet, roll *Roll, state GameState) (bool, float64, bool
 	return false, 0, false
 }
 
-// Don't Pass Odds resolver
+// Don't Pass Odds resolver. ResolveBet hands it the table point in bet.Numbers;
+// it wins on a seven out and loses when the point is made.
 func resolveDontPassOdds(bet *Bet, roll *Roll, state GameState) (bool, float64, bool) {
 	// Don't pass odds bets only work in point phase
 	if state != StatePoint {
@@ -1911,19 +2060,12 @@ func resolveDontPassOdds(bet *Bet, roll *Roll, state GameState) (bool, float64,
 	point := bet.Numbers[0]
 
 	if roll.Total == 7 {
-		// Seven out - don't pass odds bet wins at true odds
-		var payoutMultiplier float64
-		switch point {
-		case 4, 10:
-			payoutMultiplier = 0.5 // 1:2 true odds
-		case 5, 9:
-			payoutMultiplier = 0.667 // 2:3 true odds
-		case 6, 8:
-			payoutMultiplier = 0.833 // 5:6 true odds
-		default:
-			return false, 0, true // Invalid point
+		// Seven out - don't pass odds bet wins at true odds, laid
+		oddsNum, oddsDen, err := PointOdds(point)
+		if err != nil {
+			return false, 0, false // Not a point, so nothing to decide
 		}
-		payout := bet.Amount * payoutMultiplier
+		payout := payoutAt(bet.Amount, oddsDen, oddsNum)
 		return true, payout, true
 	} else if roll.Total == point {
 		// Point made - don't pass odds bet loses
@@ -0,0 +1,193 @@
+package crapsgame
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// DiceSource produces the two dice for a roll
+type DiceSource interface {
+	Roll() (int, int)
+}
+
+// secureDiceSource is the default dice source, backed by crypto/rand
+type secureDiceSource struct{}
+
+func (secureDiceSource) Roll() (int, int) {
+	return rollDieSecure(), rollDieSecure()
+}
+
+// seededDiceSource is a reproducible dice source backed by math/rand
+type seededDiceSource struct {
+	rng *rand.Rand
+}
+
+// NewSeededDiceSource returns a dice source that produces the same sequence of
+// rolls for the same seed, for debugging and reproducible simulations
+func NewSeededDiceSource(seed int64) DiceSource {
+	return &seededDiceSource{rng: rand.New(rand.NewSource(seed))}
+}
+
+func (s *seededDiceSource) Roll() (int, int) {
+	return s.rng.Intn(6) + 1, s.rng.Intn(6) + 1
+}
+
+// SetDiceSource replaces the dice used for every roll at the table.
+// Passing nil restores the default crypto/rand source.
+func (t *Table) SetDiceSource(source DiceSource) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if source == nil {
+		source = secureDiceSource{}
+	}
+	t.dice = source
+}
+
+// newRoll rolls the table's dice and records the result as the current roll
+func (t *Table) newRoll() *Roll {
+	if t.dice == nil {
+		t.dice = secureDiceSource{}
+	}
+
+	die1, die2 := t.dice.Roll()
+	return t.recordRoll(die1, die2)
+}
+
+// describeDice names a roll by its faces, low die first, e.g. "Hard 8 (4-4)"
+// or "Easy 8 (3-5)". Totals with no hard way are just the total, "7 (3-4)".
+func describeDice(roll *Roll) string {
+	low, high := roll.Die1, roll.Die2
+	if low > high {
+		low, high = high, low
+	}
+	total := low + high
+	switch {
+	case total%2 != 0 || total < 4 || total > 10:
+		return fmt.Sprintf("%d (%d-%d)", total, low, high)
+	case low == high:
+		return fmt.Sprintf("Hard %d (%d-%d)", total, low, high)
+	default:
+		return fmt.Sprintf("Easy %d (%d-%d)", total, low, high)
+	}
+}
+
+// recordRoll records die1 and die2 as the current roll
+func (t *Table) recordRoll(die1, die2 int) *Roll {
+	roll := &Roll{
+		Die1: die1,
+		Die2: die2,
+		Time: time.Now(),
+	}
+	roll.Total = roll.Die1 + roll.Die2
+	roll.IsHard = roll.Die1 == roll.Die2
+
+	t.CurrentRoll = roll
+	t.LastRoll = roll.Time
+	t.recordRollHistory(roll)
+
+	return roll
+}
+
+// DefaultRollHistorySize is how many recent rolls a table remembers unless
+// RollHistorySize says otherwise
+const DefaultRollHistorySize = 1000
+
+// RollRecord is a roll in the table's history along with the state the game
+// was in when it was thrown
+type RollRecord struct {
+	Roll  *Roll
+	State GameState
+	Point Point
+}
+
+// rollHistory is a fixed-capacity ring buffer of recent rolls. It grows until
+// it reaches capacity, then each new roll overwrites the oldest.
+type rollHistory struct {
+	records  []RollRecord
+	start    int // index of the oldest record once the buffer is full
+	capacity int
+}
+
+// push adds a record, resizing first if the capacity has changed
+func (h *rollHistory) push(record RollRecord, capacity int) {
+	if capacity != h.capacity {
+		h.resize(capacity)
+	}
+	if h.capacity <= 0 {
+		return
+	}
+	if len(h.records) < h.capacity {
+		h.records = append(h.records, record)
+		return
+	}
+	h.records[h.start] = record
+	h.start = (h.start + 1) % h.capacity
+}
+
+// list returns the records oldest first
+func (h *rollHistory) list() []RollRecord {
+	records := make([]RollRecord, 0, len(h.records))
+	records = append(records, h.records[h.start:]...)
+	return append(records, h.records[:h.start]...)
+}
+
+// resize changes the capacity, keeping the most recent records that fit
+func (h *rollHistory) resize(capacity int) {
+	records := h.list()
+	if capacity < 0 {
+		capacity = 0
+	}
+	if len(records) > capacity {
+		records = records[len(records)-capacity:]
+	}
+	h.records = records
+	h.start = 0
+	h.capacity = capacity
+}
+
+// rollHistorySize returns the configured history capacity
+func (t *Table) rollHistorySize() int {
+	if t.RollHistorySize == 0 {
+		return DefaultRollHistorySize
+	}
+	return t.RollHistorySize
+}
+
+// recordRollHistory remembers a roll, dropping the oldest once the history is full
+func (t *Table) recordRollHistory(roll *Roll) {
+	t.rollHistory.push(RollRecord{Roll: roll, State: t.State, Point: t.Point}, t.rollHistorySize())
+}
+
+// GetRollHistory returns the table's most recent rolls, oldest first
+func (t *Table) GetRollHistory() []RollRecord {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.rollHistory.list()
+}
+
+// WaysToRoll returns how many of the 36 dice combinations make a total
+func WaysToRoll(total int) int {
+	return len(Combinations(total))
+}
+
+// RollProbability returns the probability of rolling a total on a single roll
+func RollProbability(total int) float64 {
+	return float64(WaysToRoll(total)) / 36.0
+}
+
+// Combinations returns every ordered die pair that sums to total, lowest first
+// die first (e.g. 8 -> [2,6] [3,5] [4,4] [5,3] [6,2]). Totals outside 2-12
+// return an empty slice.
+func Combinations(total int) [][2]int {
+	combos := [][2]int{}
+	for die1 := 1; die1 <= 6; die1++ {
+		die2 := total - die1
+		if die2 >= 1 && die2 <= 6 {
+			combos = append(combos, [2]int{die1, die2})
+		}
+	}
+	return combos
+}
@@ -0,0 +1,108 @@
+package crapsgame
+
+import (
+	"fmt"
+	"time"
+)
+
+// EventType identifies a notable table occurrence outside of bet resolution
+type EventType string
+
+const (
+	EventShooterChange EventType = "SHOOTER_CHANGE"
+	EventDiceSet       EventType = "DICE_SET"
+)
+
+// TableEvent records something that happened at the table, e.g. a forced
+// shooter change
+type TableEvent struct {
+	Time    time.Time
+	Type    EventType
+	Message string
+}
+
+// TableObserver is notified as the table plays, to drive a UI or logger
+// without parsing result messages. Observers are called while the table is
+// locked, so they must not call back into the table, and the bets they are
+// handed must not be changed.
+type TableObserver interface {
+	// OnRoll is called for each roll before any bet is resolved
+	OnRoll(roll *Roll)
+	// OnBetResolved is called for each bet the roll decides. outcome is
+	// OutcomeWin, OutcomeLose or OutcomePush; payout is the winnings, not
+	// counting the returned stake, and 0 for a loss or push.
+	OnBetResolved(playerID string, bet *Bet, outcome string, payout float64)
+	// OnStateChange is called for each game state transition, e.g. COME_OUT
+	// to POINT when the point is established
+	OnStateChange(from, to GameState)
+}
+
+// RegisterObserver adds an observer that is notified of every roll, bet
+// decision and state change from now on
+func (t *Table) RegisterObserver(observer TableObserver) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.observers = append(t.observers, observer)
+}
+
+// notifyBetResolved tells each observer that a roll decided the bet
+func (t *Table) notifyBetResolved(player *Player, bet *Bet, outcome string, payout float64) {
+	for _, observer := range t.observers {
+		observer.OnBetResolved(player.ID, bet, outcome, payout)
+	}
+}
+
+// recordEvent appends an event to the table's event log and prints it
+func (t *Table) recordEvent(eventType EventType, format string, args ...interface{}) TableEvent {
+	event := TableEvent{
+		Time:    time.Now(),
+		Type:    eventType,
+		Message: fmt.Sprintf(format, args...),
+	}
+	t.Events = append(t.Events, event)
+	fmt.Printf("Event %s: %s\n", event.Type, event.Message)
+	return event
+}
+
+// SetDiceSetting records how the current shooter sets the dice (e.g.
+// "hard_ways"). The label is cosmetic and does not affect the roll; it is
+// cleared when the dice pass to the next shooter.
+func (t *Table) SetDiceSetting(label string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.Shooter == "" {
+		return fmt.Errorf("no shooter to set the dice")
+	}
+	t.DiceSetting = label
+	t.recordEvent(EventDiceSet, "shooter %s sets the dice: %s", t.Shooter, label)
+	return nil
+}
+
+// GetDiceSetting returns the current shooter and how they set the dice, read
+// together so the label always belongs to the shooter returned
+func (t *Table) GetDiceSetting() (shooter, label string) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.Shooter, t.DiceSetting
+}
+
+// countShooterRoll tracks how many rolls the current shooter has thrown and
+// passes the dice when MaxRollsPerShooter is reached. It is called after the
+// game state has been updated for a roll, with the shooter who threw it.
+func (t *Table) countShooterRoll(shooter string) {
+	if t.Shooter != shooter {
+		// The hand already ended (seven out); the new shooter starts at zero
+		return
+	}
+
+	t.ShooterRolls++
+	if t.MaxRollsPerShooter <= 0 || t.ShooterRolls < t.MaxRollsPerShooter {
+		return
+	}
+
+	rolls := t.ShooterRolls
+	t.assignNewShooter()
+	t.recordEvent(EventShooterChange, "shooter %s reached the %d roll limit after %d rolls, new shooter: %s",
+		shooter, t.MaxRollsPerShooter, rolls, t.Shooter)
+}
@@ -0,0 +1,509 @@
+package crapsgame
+
+import (
+	"fmt"
+	"time"
+)
+
+// TransactionType classifies a bankroll movement recorded in the table ledger
+type TransactionType string
+
+const (
+	TransactionBet    TransactionType = "BET"    // wager moved from bankroll to the layout
+	TransactionPress  TransactionType = "PRESS"  // additional wager added to an existing bet
+	TransactionWin    TransactionType = "WIN"    // money credited to the bankroll for a winning bet
+	TransactionLoss   TransactionType = "LOSS"   // wager taken by the house
+	TransactionRefund TransactionType = "REFUND" // wager returned without a decision
+	TransactionPush   TransactionType = "PUSH"   // wager returned to the bankroll on a push
+	TransactionRail   TransactionType = "RAIL"   // pushed wager set aside on the player's rail (see Table.PushToRail)
+
+	TransactionCommission TransactionType = "COMMISSION" // vig paid up front on a buy or lay bet
+)
+
+// Transaction is a single ledger entry. Amount is always positive; the type
+// determines whether it moved money off of or back onto the player's bankroll.
+type Transaction struct {
+	Time     time.Time
+	PlayerID string
+	BetID    string
+	BetType  string
+	Type     TransactionType
+	Amount   float64
+	Bankroll float64 // player's bankroll after the transaction
+}
+
+// SessionStats accumulates a player's betting activity for the session
+type SessionStats struct {
+	BetsPlaced     int
+	TotalWagered   float64 // sum of all wagers, including presses
+	TotalWon       float64 // winnings paid, excluding returned wagers (updated at resolution)
+	TotalLost      float64 // wagers taken by the house
+	CommissionPaid float64 // vig paid up front, separate from wagers
+	BiggestWin     float64 // largest single payout, excluding the returned wager
+	RollsSurvived  int     // rolls played with at least one working bet
+	RollsPlayed    int     // rolls thrown while the player was at the table
+
+	CurrentStreak     int // decisions in a row: positive for wins, negative for losses
+	LongestWinStreak  int
+	LongestLossStreak int
+}
+
+// recordWin adds a bet's winnings to the session totals
+func (s *SessionStats) recordWin(payout float64) {
+	s.TotalWon = addDollars(s.TotalWon, payout)
+	s.BiggestWin = max(s.BiggestWin, payout)
+}
+
+// recordDecision extends the streak of wins or losses. Pushes are not
+// decisions and leave the streak as it is.
+func (s *SessionStats) recordDecision(won bool) {
+	if won {
+		if s.CurrentStreak < 0 {
+			s.CurrentStreak = 0
+		}
+		s.CurrentStreak++
+		s.LongestWinStreak = max(s.LongestWinStreak, s.CurrentStreak)
+		return
+	}
+	if s.CurrentStreak > 0 {
+		s.CurrentStreak = 0
+	}
+	s.CurrentStreak--
+	s.LongestLossStreak = max(s.LongestLossStreak, -s.CurrentStreak)
+}
+
+// Net returns the player's net result from decided bets after commission
+func (s SessionStats) Net() float64 {
+	return s.TotalWon - s.TotalLost - s.CommissionPaid
+}
+
+// recordTransaction appends a ledger entry and updates the player's stats
+func (t *Table) recordTransaction(player *Player, bet *Bet, txType TransactionType, amount float64) {
+	t.Transactions = append(t.Transactions, Transaction{
+		Time:     time.Now(),
+		PlayerID: player.ID,
+		BetID:    bet.ID,
+		BetType:  bet.Type,
+		Type:     txType,
+		Amount:   amount,
+		Bankroll: player.Bankroll,
+	})
+
+	switch txType {
+	case TransactionBet:
+		player.Stats.BetsPlaced++
+		player.Stats.TotalWagered = addDollars(player.Stats.TotalWagered, amount)
+	case TransactionPress:
+		player.Stats.TotalWagered = addDollars(player.Stats.TotalWagered, amount)
+	case TransactionLoss:
+		player.Stats.TotalLost = addDollars(player.Stats.TotalLost, amount)
+	case TransactionCommission:
+		player.Stats.CommissionPaid = addDollars(player.Stats.CommissionPaid, amount)
+	}
+}
+
+// AverageBetSize returns the player's total wagered divided by the number of
+// bets placed this session, or 0 if the player has not bet
+func (t *Table) AverageBetSize(playerID string) float64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.averageBetSize(playerID)
+}
+
+func (t *Table) averageBetSize(playerID string) float64 {
+	player, exists := t.Players[playerID]
+	if !exists || player.Stats.BetsPlaced == 0 {
+		return 0
+	}
+	return player.Stats.TotalWagered / float64(player.Stats.BetsPlaced)
+}
+
+// DefaultRollsPerHour is a typical pace for a busy table, used to estimate
+// hourly cost unless Table.RollsPerHour says otherwise
+const DefaultRollsPerHour = 100
+
+// HourlyPace returns the rolls per hour used to estimate a player's cost
+func (t *Table) HourlyPace() float64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.hourlyPace()
+}
+
+func (t *Table) hourlyPace() float64 {
+	if t.RollsPerHour <= 0 {
+		return DefaultRollsPerHour
+	}
+	return t.RollsPerHour
+}
+
+// AverageHouseEdge returns the house edge percent across everything the
+// player has wagered this session, weighted by the amounts bet and pressed,
+// or 0 if the player has not bet
+func (t *Table) AverageHouseEdge(playerID string) float64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.averageHouseEdge(playerID)
+}
+
+func (t *Table) averageHouseEdge(playerID string) float64 {
+	var wagered, weighted float64
+	for _, tx := range t.Transactions {
+		if tx.PlayerID != playerID || (tx.Type != TransactionBet && tx.Type != TransactionPress) {
+			continue
+		}
+		wagered += tx.Amount
+		weighted += tx.Amount * CanonicalBetDefinitions[tx.BetType].HouseEdge
+	}
+	if wagered == 0 {
+		return 0
+	}
+	return weighted / wagered
+}
+
+// CompRating returns the player's theoretical loss for the session, the
+// figure casinos base comps on: average bet times house edge times rolls. The
+// player's time at the table (the rolls they have been there for, at the
+// table's HourlyPace) is rated at rollsPerHour, the pace the casino credits;
+// 0 or less rates the rolls as played. It returns 0 for an unknown player or
+// one who hasn't bet.
+func (t *Table) CompRating(playerID string, rollsPerHour float64) float64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	rolls := t.rollsPlayed(playerID)
+	if rollsPerHour > 0 {
+		rolls = rolls / t.hourlyPace() * rollsPerHour
+	}
+	return t.averageBetSize(playerID) * t.averageHouseEdge(playerID) / 100 * rolls
+}
+
+// HoursPlayed converts the rolls the player has been at the table for into
+// hours at rollsPerHour; 0 or less uses the table's HourlyPace. It returns 0
+// for an unknown player.
+func (t *Table) HoursPlayed(playerID string, rollsPerHour float64) float64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if rollsPerHour <= 0 {
+		rollsPerHour = t.hourlyPace()
+	}
+	return t.rollsPlayed(playerID) / rollsPerHour
+}
+
+// rollsPlayed returns how many rolls the player has been at the table for
+func (t *Table) rollsPlayed(playerID string) float64 {
+	player, exists := t.Players[playerID]
+	if !exists {
+		return 0
+	}
+	return float64(player.Stats.RollsPlayed)
+}
+
+// PlayerExposure returns the total the player currently has wagered on the layout
+func (t *Table) PlayerExposure(playerID string) float64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.playerExposure(playerID)
+}
+
+func (t *Table) playerExposure(playerID string) float64 {
+	player, exists := t.Players[playerID]
+	if !exists {
+		return 0
+	}
+	exposure := 0.0
+	for _, bet := range player.Bets {
+		exposure += bet.Amount
+	}
+	return exposure
+}
+
+// BreakEvenWinRate returns the fraction of decisions the player's current
+// bets must win to break even, weighting each bet's payout by its amount: the
+// total staked over the total staked plus the total the bets would pay.
+// Bets without a fixed payout are left out. It returns 0 when the player has
+// no bets or is unknown.
+func (t *Table) BreakEvenWinRate(playerID string) float64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	player, exists := t.Players[playerID]
+	if !exists {
+		return 0
+	}
+
+	var staked, payouts float64
+	for _, bet := range player.Bets {
+		ratio, ok := t.payoutRatio(bet)
+		if !ok {
+			continue
+		}
+		staked += bet.Amount
+		payouts += bet.Amount * ratio
+	}
+	if staked == 0 {
+		return 0
+	}
+	return staked / (staked + payouts)
+}
+
+// payoutRatio returns what a bet pays per $1 on a win, net of commission,
+// using the table's pay table and, for odds, the point the bet is on. ok is
+// false for bets without a fixed payout.
+func (t *Table) payoutRatio(bet *Bet) (float64, bool) {
+	def, exists := t.PayTable[bet.Type]
+	if !exists {
+		def, exists = CanonicalBetDefinitions[bet.Type]
+	}
+	if !exists {
+		return 0, false
+	}
+
+	if def.Category == OddsBets {
+		// Pass and don't pass odds are on the table point, come odds on
+		// their come point
+		point := t.pointNumber()
+		if len(bet.Numbers) > 0 {
+			point = bet.Numbers[0]
+		}
+		num, den, err := PointOdds(point)
+		if err != nil {
+			return 0, false
+		}
+		if bet.Type == "DONT_PASS_ODDS" || bet.Type == "DONT_COME_ODDS" {
+			num, den = den, num
+		}
+		return float64(num) / float64(den), true
+	}
+
+	if def.PayoutDenominator == 0 {
+		return 0, false
+	}
+	return float64(def.PayoutNumerator)/float64(def.PayoutDenominator) - def.Commission, true
+}
+
+// SevenExposure returns the player's bets that lose if the next roll is a 7,
+// in the order they were placed, and the total they have riding on them. Bets
+// that are off are not at risk. It returns nil and 0 for unknown players.
+func (t *Table) SevenExposure(playerID string) ([]*Bet, float64) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	player, exists := t.Players[playerID]
+	if !exists {
+		return nil, 0
+	}
+
+	seven := &Roll{Die1: 3, Die2: 4, Total: 7}
+	var atRisk []*Bet
+	var total Money
+	for _, bet := range player.Bets {
+		if !t.shouldBetBeWorking(bet, t.State) || !bet.PlayerWorking {
+			continue
+		}
+		if win, _, remove := t.previewBet(bet, seven); win || !remove {
+			continue
+		}
+		atRisk = append(atRisk, bet)
+		total = total.Add(ToMoney(bet.Amount))
+	}
+	return atRisk, total.Dollars()
+}
+
+// TotalWorkingWager returns the total of every player's working bets, the
+// action currently live on the layout. Bets that are off are not counted.
+func (t *Table) TotalWorkingWager() float64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var total Money
+	for _, player := range t.Players {
+		for _, bet := range player.Bets {
+			if bet.Working {
+				total = total.Add(ToMoney(bet.Amount))
+			}
+		}
+	}
+	return total.Dollars()
+}
+
+// BankrollSnapshot returns every player's current bankroll keyed by player ID
+func (t *Table) BankrollSnapshot() map[string]float64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	snapshot := make(map[string]float64, len(t.Players))
+	for id, player := range t.Players {
+		snapshot[id] = player.Bankroll
+	}
+	return snapshot
+}
+
+// BankrollDelta returns each player's change in bankroll between two
+// snapshots. A player missing from one snapshot counts as $0 there, so players
+// who joined or left show their whole bankroll as the change.
+func BankrollDelta(before, after map[string]float64) map[string]float64 {
+	delta := make(map[string]float64, len(after))
+	for id, bankroll := range after {
+		delta[id] = bankroll - before[id]
+	}
+	for id, bankroll := range before {
+		if _, exists := after[id]; !exists {
+			delta[id] = -bankroll
+		}
+	}
+	return delta
+}
+
+// MinBankroll returns the smallest bankroll that can place betType for the
+// player at its minimum: the higher of the table and player minimums, rounded
+// up to a multiple of the bet's payout denominator so it pays whole dollars
+// ($6 on a place 6 at 7:6), plus any commission taken on placement. Odds are
+// rounded to the point's increment only when the table's OddsIncrementMode
+// requires it, and the line bet they back isn't counted.
+func (t *Table) MinBankroll(playerID, betType string) (float64, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	player, exists := t.Players[playerID]
+	if !exists {
+		return 0, fmt.Errorf("player %s not found", playerID)
+	}
+	def, exists := t.PayTable[betType]
+	if !exists {
+		def, exists = CanonicalBetDefinitions[betType]
+	}
+	if !exists {
+		return 0, fmt.Errorf("unknown bet type: %s", betType)
+	}
+
+	increment := def.PayoutDenominator
+	if def.Category == OddsBets {
+		increment = 0
+		if t.OddsIncrementMode != OddsAnyAmount && t.State == StatePoint {
+			num, den, err := PointOdds(t.pointNumber())
+			if err != nil {
+				return 0, err
+			}
+			increment = den
+			if betType == "DONT_PASS_ODDS" || betType == "DONT_COME_ODDS" {
+				increment = num
+			}
+		}
+	}
+
+	minimum := ToMoney(t.MinBet)
+	if player.MinBet > t.MinBet {
+		minimum = ToMoney(player.MinBet)
+	}
+	if increment > 1 {
+		step := Money(increment * 100)
+		minimum = (minimum + step - 1) / step * step
+	}
+	amount := minimum.Dollars()
+	if amount > t.MaxBet || (player.MaxBet > 0 && amount > player.MaxBet) {
+		return 0, fmt.Errorf("%s can't be bet at $%.2f within the betting limits", betType, amount)
+	}
+	return addDollars(amount, t.placementCommission(betType, amount)), nil
+}
+
+// RemainingAction returns the largest additional wager the player can place
+// right now: the smallest of their bankroll, the maximum single bet (the
+// table's or the player's own, whichever is lower) and the room left under
+// the table's exposure cap. It returns 0 for unknown players.
+func (t *Table) RemainingAction(playerID string) float64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	player, exists := t.Players[playerID]
+	if !exists {
+		return 0
+	}
+
+	remaining := player.Bankroll
+	if t.MaxBet > 0 && t.MaxBet < remaining {
+		remaining = t.MaxBet
+	}
+	if player.MaxBet > 0 && player.MaxBet < remaining {
+		remaining = player.MaxBet
+	}
+	if t.MaxExposure > 0 {
+		if room := t.MaxExposure - t.playerExposure(playerID); room < remaining {
+			remaining = room
+		}
+	}
+
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// OutcomeCount tallies the decisions for one bet type
+type OutcomeCount struct {
+	Wins   int
+	Losses int
+	Pushes int // wagers returned on a push, to the bankroll or the rail
+}
+
+// OutcomeHistogram counts the player's winning and losing decisions per bet
+// type from the ledger. A bet that stays up and wins repeatedly counts once
+// per win.
+func (t *Table) OutcomeHistogram(playerID string) map[string]OutcomeCount {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	histogram := make(map[string]OutcomeCount)
+	for _, tx := range t.Transactions {
+		if tx.PlayerID != playerID {
+			continue
+		}
+		count := histogram[tx.BetType]
+		switch tx.Type {
+		case TransactionWin:
+			if tx.Amount == 0 {
+				// A parlay riding a push is paid nothing
+				continue
+			}
+			count.Wins++
+		case TransactionPush, TransactionRail:
+			count.Pushes++
+		case TransactionLoss:
+			count.Losses++
+		default:
+			continue
+		}
+		histogram[tx.BetType] = count
+	}
+	return histogram
+}
+
+// GetAllTransactions returns a copy of the whole ledger in the order the
+// entries occurred
+func (t *Table) GetAllTransactions() []Transaction {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return append([]Transaction(nil), t.Transactions...)
+}
+
+// GetHouseBankroll returns the house's bankroll
+func (t *Table) GetHouseBankroll() float64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.HouseBankroll
+}
+
+// GetTransactions returns the ledger entries for a player in the order they occurred
+func (t *Table) GetTransactions(playerID string) []Transaction {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var transactions []Transaction
+	for _, tx := range t.Transactions {
+		if tx.PlayerID == playerID {
+			transactions = append(transactions, tx)
+		}
+	}
+	return transactions
+}
@@ -0,0 +1,74 @@
+package crapsgame
+
+import (
+	"fmt"
+	"math"
+)
+
+// Money is an amount in whole cents. Payouts and bankroll changes are worked
+// out in Money so fractional results like 7:6 on $10 can't leave stray
+// fractions of a cent that drift over a long session. Bankrolls and bet
+// amounts are still exposed as float64 dollars, always holding whole cents.
+type Money int64
+
+// ToMoney converts dollars to Money, rounding to the nearest cent
+func ToMoney(dollars float64) Money {
+	return Money(math.Round(dollars * 100))
+}
+
+// Dollars returns the amount in dollars
+func (m Money) Dollars() float64 {
+	return float64(m) / 100
+}
+
+// Add returns m + other
+func (m Money) Add(other Money) Money {
+	return m + other
+}
+
+// Sub returns m - other
+func (m Money) Sub(other Money) Money {
+	return m - other
+}
+
+// MulRatio returns m * num / den, rounded down to the cent the way the house
+// pays (e.g. $10 at 7:6 pays $11.66)
+func (m Money) MulRatio(num, den int) Money {
+	if den == 0 {
+		return 0
+	}
+	return m * Money(num) / Money(den)
+}
+
+// String formats the amount as dollars and cents, e.g. $17.50
+func (m Money) String() string {
+	sign := ""
+	if m < 0 {
+		sign = "-"
+		m = -m
+	}
+	return fmt.Sprintf("%s$%d.%02d", sign, m/100, m%100)
+}
+
+// addDollars adds two dollar amounts in whole cents
+func addDollars(a, b float64) float64 {
+	return ToMoney(a).Add(ToMoney(b)).Dollars()
+}
+
+// subDollars subtracts b from a in whole cents
+func subDollars(a, b float64) float64 {
+	return ToMoney(a).Sub(ToMoney(b)).Dollars()
+}
+
+// payoutAt returns the winnings on amount at num:den, rounded down to the cent
+func payoutAt(amount float64, num, den int) float64 {
+	return ToMoney(amount).MulRatio(num, den).Dollars()
+}
+
+// payoutTimes pays amount at a multiplier held as a float, such as a field
+// payout, rounded down to the cent like payoutAt. The multiplier is usually a
+// ratio that floats can't hold exactly, so a sliver of tolerance keeps an
+// exact result like $6 at 7/6 from losing a cent.
+func payoutTimes(amount, multiplier float64) float64 {
+	return Money(math.Floor(float64(ToMoney(amount))*multiplier + 1e-6)).Dollars()
+}
@@ -0,0 +1,269 @@
+package crapsgame
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// outcomeProbabilities returns the exact probabilities that a bet wins, loses
+// or pushes when it is decided. ok is false for bets without a single fixed
+// payout (field, horn, world, combination, split hop and odds bets).
+func outcomeProbabilities(betType string) (win, lose, push *big.Rat, ok bool) {
+	def, exists := CanonicalBetDefinitions[betType]
+	if !exists {
+		return nil, nil, nil, false
+	}
+
+	// race returns the probabilities of winWays coming before loseWays
+	race := func(winWays, loseWays int) (*big.Rat, *big.Rat, *big.Rat, bool) {
+		total := int64(winWays + loseWays)
+		return big.NewRat(int64(winWays), total), big.NewRat(int64(loseWays), total), new(big.Rat), true
+	}
+
+	switch betType {
+	case "PASS_LINE", "COME":
+		win, lose := lineOutcomes()
+		return win, lose, new(big.Rat), true
+	case "DONT_PASS", "DONT_COME":
+		// Don't bets win where the pass line loses, except the barred 12 pushes
+		passWin, passLose := lineOutcomes()
+		push := big.NewRat(int64(WaysToRoll(12)), 36)
+		return new(big.Rat).Sub(passLose, push), passWin, push, true
+	case "HOP_HARD_4", "HOP_HARD_6", "HOP_HARD_8", "HOP_HARD_10":
+		return race(1, 35)
+	case "COME_OUT_HARDWAYS":
+		return race(4, 32) // 2-2, 3-3, 4-4 and 5-5
+	case "HOP_EASY_8":
+		// A split hop across 2-6 and 3-5, so there is no single fixed payout
+		return nil, nil, nil, false
+	}
+
+	var number int
+	if len(def.ValidNumbers) > 0 {
+		number = def.ValidNumbers[0]
+	}
+
+	switch def.Category {
+	case PlaceBets, BuyBets, BigBets:
+		if len(def.ValidNumbers) != 1 {
+			return nil, nil, nil, false
+		}
+		return race(WaysToRoll(number), WaysToRoll(7))
+	case LayBets, PlaceToLoseBets:
+		return race(WaysToRoll(7), WaysToRoll(number))
+	case HardWayBets:
+		if len(def.ValidNumbers) != 1 {
+			return nil, nil, nil, false
+		}
+		return race(1, WaysToRoll(7)+WaysToRoll(number)-1)
+	case PropositionBets:
+		ways := 0
+		for _, n := range def.ValidNumbers {
+			ways += WaysToRoll(n)
+		}
+		return race(ways, 36-ways)
+	case HopBets:
+		var die1, die2 int
+		if _, err := fmt.Sscanf(betType, "HOP_%d_%d", &die1, &die2); err != nil {
+			return nil, nil, nil, false
+		}
+		if die1 == die2 {
+			return race(1, 35)
+		}
+		return race(2, 34)
+	}
+
+	return nil, nil, nil, false
+}
+
+// lineOutcomes returns the pass line's probabilities of winning and losing
+func lineOutcomes() (win, lose *big.Rat) {
+	win = big.NewRat(int64(WaysToRoll(7)+WaysToRoll(11)), 36)
+	lose = big.NewRat(int64(WaysToRoll(2)+WaysToRoll(3)+WaysToRoll(12)), 36)
+
+	for _, point := range []int{4, 5, 6, 8, 9, 10} {
+		ways := int64(WaysToRoll(point))
+		established := big.NewRat(ways, 36)
+		made := big.NewRat(ways, ways+int64(WaysToRoll(7)))
+		sevenOut := new(big.Rat).Sub(big.NewRat(1, 1), made)
+
+		win.Add(win, new(big.Rat).Mul(established, made))
+		lose.Add(lose, new(big.Rat).Mul(established, sevenOut))
+	}
+
+	return win, lose
+}
+
+// WinProbability returns the probability that a bet wins when it is decided
+func WinProbability(betType string) (float64, error) {
+	win, _, _, ok := outcomeProbabilities(betType)
+	if !ok {
+		return 0, fmt.Errorf("win probability not defined for %s", betType)
+	}
+	p, _ := win.Float64()
+	return p, nil
+}
+
+// FairOdds returns the true-odds payout for a bet as a reduced ratio,
+// e.g. 6:5 for PLACE_6 or 10:1 for HARD_8
+func FairOdds(betType string) (num, den int, err error) {
+	win, lose, _, ok := outcomeProbabilities(betType)
+	if !ok {
+		return 0, 0, fmt.Errorf("fair odds not defined for %s", betType)
+	}
+	ratio := new(big.Rat).Quo(lose, win)
+	return int(ratio.Num().Int64()), int(ratio.Denom().Int64()), nil
+}
+
+// PointOdds returns the true odds paid on pass odds for a point as a reduced
+// ratio, the ways to roll a seven against the ways to roll the point (2:1 on
+// 4 and 10, 3:2 on 5 and 9, 6:5 on 6 and 8). Don't pass odds pay the inverse.
+func PointOdds(point int) (num, den int, err error) {
+	if _, err := rollTotalToPoint(point); err != nil {
+		return 0, 0, err
+	}
+	ratio := big.NewRat(int64(len(Combinations(7))), int64(len(Combinations(point))))
+	return int(ratio.Num().Int64()), int(ratio.Denom().Int64()), nil
+}
+
+// FairPayout returns the true-odds payout per $1 wagered
+func FairPayout(betType string) (float64, error) {
+	num, den, err := FairOdds(betType)
+	if err != nil {
+		return 0, err
+	}
+	return float64(num) / float64(den), nil
+}
+
+// ImpliedProbability returns the break-even win probability for a payout of
+// num:den, 1/(ratio+1) (e.g. 0.5 for 1:1 or 0.125 for 7:1). Ratios that
+// aren't positive return 0.
+func ImpliedProbability(num, den int) float64 {
+	if num <= 0 || den <= 0 {
+		return 0
+	}
+	return float64(den) / float64(num+den)
+}
+
+// ActualPayout returns what the house pays per $1 wagered, net of any commission
+func ActualPayout(betType string) (float64, error) {
+	def, exists := CanonicalBetDefinitions[betType]
+	if !exists {
+		return 0, fmt.Errorf("unknown bet type: %s", betType)
+	}
+	return actualPayout(def), nil
+}
+
+// actualPayout returns what def pays per $1 wagered, net of any commission
+func actualPayout(def CanonicalBetDefinition) float64 {
+	return float64(def.PayoutNumerator)/float64(def.PayoutDenominator) - def.Commission
+}
+
+// Outcome labels for a decided bet, as BetResult, TableObserver and
+// BetLifecycleNet use them
+const (
+	OutcomeWin  = "win"
+	OutcomeLose = "lose"
+	OutcomePush = "push"
+)
+
+// BetLifecycleNet returns the net bankroll change over a bet's whole life for
+// an outcome: a win nets the payout less commission (the stake comes back), a
+// loss costs the stake and a push returns it. Unknown bet types and outcomes,
+// and bets without a fixed payout (odds bets), return 0.
+func BetLifecycleNet(betType string, amount float64, outcome string) float64 {
+	def, exists := CanonicalBetDefinitions[betType]
+	if !exists {
+		return 0
+	}
+
+	switch outcome {
+	case OutcomeWin:
+		if def.PayoutDenominator == 0 {
+			return 0
+		}
+		return amount*float64(def.PayoutNumerator)/float64(def.PayoutDenominator) - amount*def.Commission
+	case OutcomeLose:
+		return -amount
+	default:
+		return 0
+	}
+}
+
+// HouseTake returns the house's expected take per $1 wagered, as a fraction
+func HouseTake(betType string) (float64, error) {
+	return houseTake(betType, CanonicalBetDefinitions[betType])
+}
+
+// houseTake returns the house's take on betType when it pays as def does
+func houseTake(betType string, def CanonicalBetDefinition) (float64, error) {
+	win, lose, _, ok := outcomeProbabilities(betType)
+	if !ok {
+		return 0, fmt.Errorf("house take not defined for %s", betType)
+	}
+	actual := actualPayout(def)
+	pWin, _ := win.Float64()
+	pLose, _ := lose.Float64()
+	return pLose - pWin*actual, nil
+}
+
+// BetVariance returns the variance of a $1 bet's net result when it is
+// decided: it wins the actual payout, loses the dollar or pushes. ok is false
+// for bets without fixed outcome probabilities.
+func BetVariance(betType string) (float64, bool) {
+	return betVariance(betType, CanonicalBetDefinitions[betType])
+}
+
+// betVariance returns the variance of betType when it pays as def does
+func betVariance(betType string, def CanonicalBetDefinition) (float64, bool) {
+	win, lose, _, ok := outcomeProbabilities(betType)
+	if !ok {
+		return 0, false
+	}
+	actual := actualPayout(def)
+	pWin, _ := win.Float64()
+	pLose, _ := lose.Float64()
+
+	mean := pWin*actual - pLose
+	return pWin*actual*actual + pLose - mean*mean, true
+}
+
+// applyOddsIncrement checks an odds amount on point against the table's
+// OddsIncrementMode. Odds pay whole dollars on multiples of the ratio's
+// denominator: $2 behind a 5 or 9 (3:2), $5 behind a 6 or 8 (6:5), and the
+// inverse for don't odds.
+func (t *Table) applyOddsIncrement(betType string, point int, amount float64) (float64, error) {
+	if t.OddsIncrementMode == OddsAnyAmount {
+		return amount, nil
+	}
+	num, den, err := PointOdds(point)
+	if err != nil {
+		return 0, err
+	}
+	if betType == "DONT_PASS_ODDS" || betType == "DONT_COME_ODDS" {
+		num, den = den, num
+	}
+
+	increment := Money(den * 100)
+	cents := ToMoney(amount)
+	if cents%increment == 0 {
+		return amount, nil
+	}
+	if t.OddsIncrementMode == OddsStrict {
+		return 0, fmt.Errorf("$%.2f odds on %d would pay %s at %d:%d; bet a multiple of $%d",
+			amount, point, cents.MulRatio(num, den), num, den, den)
+	}
+
+	rounded := cents / increment * increment
+	if rounded == 0 {
+		return 0, fmt.Errorf("$%.2f odds on %d is below the $%d increment", amount, point, den)
+	}
+	return rounded.Dollars(), nil
+}
+
+// ExpectedLossPerHour returns what a player can expect to lose in an hour
+// betting avgBet on every roll at houseEdge, a percent as in
+// CanonicalBetDefinition.HouseEdge
+func ExpectedLossPerHour(avgBet, houseEdge, rollsPerHour float64) float64 {
+	return avgBet * houseEdge / 100 * rollsPerHour
+}
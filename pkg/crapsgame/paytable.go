@@ -0,0 +1,228 @@
+package crapsgame
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// payTableEntry is the JSON form of one bet's house-rule payout, e.g.
+//
+//	{"HARD_8": {"payout": "10:1"}, "FIELD": {"special_payouts": {"12": "2:1"}}}
+//
+// Payout replaces the bet's base ratio; special_payouts override the payout
+// for individual roll totals (only meaningful for the field).
+type payTableEntry struct {
+	Payout         string         `json:"payout"`
+	SpecialPayouts map[int]string `json:"special_payouts"`
+}
+
+// LoadPayTable parses a JSON pay table into bet definition overrides that can
+// be installed with Table.ApplyPayTable. Each override starts from the
+// canonical definition so omitted fields keep their standard values.
+func LoadPayTable(data []byte) (map[string]CanonicalBetDefinition, error) {
+	var entries map[string]payTableEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("invalid pay table: %v", err)
+	}
+
+	overrides := make(map[string]CanonicalBetDefinition, len(entries))
+	for betType, entry := range entries {
+		def, exists := CanonicalBetDefinitions[betType]
+		if !exists {
+			return nil, fmt.Errorf("invalid pay table: unknown bet type %s", betType)
+		}
+
+		if entry.Payout != "" {
+			num, den, err := parsePayoutRatio(entry.Payout)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pay table: %s: %v", betType, err)
+			}
+			def.Payout = entry.Payout
+			def.PayoutNumerator = num
+			def.PayoutDenominator = den
+		}
+
+		if len(entry.SpecialPayouts) > 0 {
+			special := make(map[int]float64, len(def.SpecialPayouts)+len(entry.SpecialPayouts))
+			for total, multiplier := range def.SpecialPayouts {
+				special[total] = multiplier
+			}
+			for total, ratio := range entry.SpecialPayouts {
+				num, den, err := parsePayoutRatio(ratio)
+				if err != nil {
+					return nil, fmt.Errorf("invalid pay table: %s on %d: %v", betType, total, err)
+				}
+				special[total] = float64(num) / float64(den)
+			}
+			def.SpecialPayouts = special
+		}
+
+		overrides[betType] = def
+	}
+
+	return overrides, nil
+}
+
+// parsePayoutRatio parses a payout such as "10:1" into its positive parts
+func parsePayoutRatio(ratio string) (int, int, error) {
+	parts := strings.Split(ratio, ":")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("payout %q must be in the form N:D", ratio)
+	}
+	num, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("payout %q must be in the form N:D", ratio)
+	}
+	den, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("payout %q must be in the form N:D", ratio)
+	}
+	if num <= 0 || den <= 0 {
+		return 0, 0, fmt.Errorf("payout %q must be positive", ratio)
+	}
+	return num, den, nil
+}
+
+// ApplyPayTable installs house-rule payouts for this table. Overrides are
+// limited to bets paid at a single ratio (plus the field's per-total
+// payouts); odds, horn, world and combination bets are rejected.
+func (t *Table) ApplyPayTable(overrides map[string]CanonicalBetDefinition) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for betType, def := range overrides {
+		if _, _, _, ok := outcomeProbabilities(betType); !ok && betType != "FIELD" {
+			return fmt.Errorf("pay table cannot override %s", betType)
+		}
+		if def.PayoutNumerator <= 0 || def.PayoutDenominator <= 0 {
+			return fmt.Errorf("pay table payout for %s must be positive", betType)
+		}
+		for total, multiplier := range def.SpecialPayouts {
+			if multiplier <= 0 {
+				return fmt.Errorf("pay table payout for %s on %d must be positive", betType, total)
+			}
+		}
+	}
+
+	if t.PayTable == nil {
+		t.PayTable = make(map[string]CanonicalBetDefinition, len(overrides))
+	}
+	for betType, def := range overrides {
+		t.PayTable[betType] = def
+	}
+	return nil
+}
+
+// SetFieldPayouts sets the field's multiplier for individual totals, e.g.
+// {2: 2, 12: 2} for a table that pays double on both. Totals must be field
+// numbers and multipliers positive. The multipliers go into the FIELD entry
+// of the table's pay table, so totals not listed keep whatever it pays now.
+func (t *Table) SetFieldPayouts(payouts map[int]float64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for total, multiplier := range payouts {
+		switch total {
+		case 2, 3, 4, 9, 10, 11, 12:
+		default:
+			return fmt.Errorf("%d is not a field number", total)
+		}
+		if multiplier <= 0 {
+			return fmt.Errorf("field payout on %d must be positive", total)
+		}
+	}
+
+	def, exists := t.PayTable["FIELD"]
+	if !exists {
+		def = CanonicalBetDefinitions["FIELD"]
+	}
+	special := make(map[int]float64, len(def.SpecialPayouts)+len(payouts))
+	for total, multiplier := range def.SpecialPayouts {
+		special[total] = multiplier
+	}
+	for total, multiplier := range payouts {
+		special[total] = multiplier
+	}
+	def.SpecialPayouts = special
+
+	if t.PayTable == nil {
+		t.PayTable = make(map[string]CanonicalBetDefinition)
+	}
+	t.PayTable["FIELD"] = def
+	return nil
+}
+
+// FieldPayouts returns the field's multiplier for each specially paid total
+// as the table pays them: the FIELD pay table entry SetFieldPayouts and
+// ApplyPayTable write, or the standard {2: 2, 12: 3}
+func (t *Table) FieldPayouts() map[int]float64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	def, _ := t.betDefinition("FIELD")
+	payouts := make(map[int]float64, len(def.SpecialPayouts))
+	for total, multiplier := range def.SpecialPayouts {
+		payouts[total] = multiplier
+	}
+	return payouts
+}
+
+// payTablePayout reprices a winning bet when the table has a pay table
+// override for its type; otherwise the resolver's payout stands. A push (a
+// win paying nothing, such as the don't pass on a barred 12) stays a push.
+func (t *Table) payTablePayout(bet *Bet, roll *Roll, payout float64) float64 {
+	def, exists := t.PayTable[bet.Type]
+	if !exists || payout == 0 {
+		return payout
+	}
+
+	if special, ok := def.SpecialPayouts[roll.Total]; ok {
+		payout = payoutTimes(bet.Amount, special)
+	} else {
+		payout = payoutAt(bet.Amount, def.PayoutNumerator, def.PayoutDenominator)
+	}
+	if bet.CommissionPaid == 0 {
+		payout = subDollars(payout, bet.Amount*def.Commission)
+	}
+	return payout
+}
+
+// BetDefinition returns the definition the table pays a bet type by: its pay
+// table override if it has one, otherwise the canonical definition
+func (t *Table) BetDefinition(betType string) (CanonicalBetDefinition, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.betDefinition(betType)
+}
+
+func (t *Table) betDefinition(betType string) (CanonicalBetDefinition, bool) {
+	if def, exists := t.PayTable[betType]; exists {
+		return def, true
+	}
+	def, exists := CanonicalBetDefinitions[betType]
+	return def, exists
+}
+
+// ActualPayout is ActualPayout at the table's pay table
+func (t *Table) ActualPayout(betType string) (float64, error) {
+	def, exists := t.BetDefinition(betType)
+	if !exists {
+		return 0, fmt.Errorf("unknown bet type: %s", betType)
+	}
+	return actualPayout(def), nil
+}
+
+// HouseTake is HouseTake at the table's pay table
+func (t *Table) HouseTake(betType string) (float64, error) {
+	def, _ := t.BetDefinition(betType)
+	return houseTake(betType, def)
+}
+
+// BetVariance is BetVariance at the table's pay table
+func (t *Table) BetVariance(betType string) (float64, bool) {
+	def, _ := t.BetDefinition(betType)
+	return betVariance(betType, def)
+}
@@ -0,0 +1,164 @@
+package crapsgame
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// tableSnapshot is the JSON form of a table written by MarshalJSON and read by
+// LoadTable. Fields keep their Go names, as do the nested players, bets and
+// ledger entries. The dice source is not saved; a loaded table rolls with the
+// default crypto/rand dice.
+type tableSnapshot struct {
+	State       GameState
+	Point       Point
+	CurrentRoll *Roll
+	Players     map[string]*Player
+	Seats       []string
+	Shooter     string
+	MinBet      float64
+	MaxBet      float64
+	MaxOdds     int
+	CreatedAt   time.Time
+	LastRoll    time.Time
+
+	BuyCommissionMode  BuyCommissionMode
+	OddsIncrementMode  OddsIncrementMode
+	MaxComeBets        int
+	MaxExposure        float64
+	WarnHighEdge       float64
+	PushToRail         bool
+	ComeBetsOnComeOut  bool
+	HouseBankroll      float64
+	MaxRollsPerShooter int
+	ShooterRolls       int
+	DiceSetting        string
+	RollHistorySize    int
+	RollsPerHour       float64
+	WorkingDefaults    map[BetCategory]bool
+
+	Transactions []Transaction
+	Events       []TableEvent
+	PayTable     map[string]CanonicalBetDefinition
+	LastDecision string
+	RollHistory  []RollRecord
+}
+
+// MarshalJSON saves the complete table: players and their bets, the ledger,
+// game state, shooter and table limits
+func (t *Table) MarshalJSON() ([]byte, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return json.Marshal(tableSnapshot{
+		State:              t.State,
+		Point:              t.Point,
+		CurrentRoll:        t.CurrentRoll,
+		Players:            t.Players,
+		Seats:              t.Seats,
+		Shooter:            t.Shooter,
+		MinBet:             t.MinBet,
+		MaxBet:             t.MaxBet,
+		MaxOdds:            t.MaxOdds,
+		CreatedAt:          t.CreatedAt,
+		LastRoll:           t.LastRoll,
+		BuyCommissionMode:  t.BuyCommissionMode,
+		OddsIncrementMode:  t.OddsIncrementMode,
+		MaxComeBets:        t.MaxComeBets,
+		MaxExposure:        t.MaxExposure,
+		WarnHighEdge:       t.WarnHighEdge,
+		PushToRail:         t.PushToRail,
+		ComeBetsOnComeOut:  t.ComeBetsOnComeOut,
+		HouseBankroll:      t.HouseBankroll,
+		MaxRollsPerShooter: t.MaxRollsPerShooter,
+		ShooterRolls:       t.ShooterRolls,
+		DiceSetting:        t.DiceSetting,
+		RollHistorySize:    t.RollHistorySize,
+		RollsPerHour:       t.RollsPerHour,
+		WorkingDefaults:    t.WorkingDefaults,
+		Transactions:       t.Transactions,
+		Events:             t.Events,
+		PayTable:           t.PayTable,
+		LastDecision:       t.lastDecision,
+		RollHistory:        t.rollHistory.list(),
+	})
+}
+
+// LoadTable restores a table saved with MarshalJSON
+func LoadTable(data []byte) (*Table, error) {
+	var snapshot tableSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("invalid table data: %v", err)
+	}
+
+	table := NewTable(snapshot.MinBet, snapshot.MaxBet, snapshot.MaxOdds)
+	table.State = snapshot.State
+	table.Point = snapshot.Point
+	table.CurrentRoll = snapshot.CurrentRoll
+	table.Shooter = snapshot.Shooter
+	table.CreatedAt = snapshot.CreatedAt
+	table.LastRoll = snapshot.LastRoll
+	table.BuyCommissionMode = snapshot.BuyCommissionMode
+	table.OddsIncrementMode = snapshot.OddsIncrementMode
+	table.MaxComeBets = snapshot.MaxComeBets
+	table.MaxExposure = snapshot.MaxExposure
+	table.WarnHighEdge = snapshot.WarnHighEdge
+	table.PushToRail = snapshot.PushToRail
+	table.ComeBetsOnComeOut = snapshot.ComeBetsOnComeOut
+	table.HouseBankroll = snapshot.HouseBankroll
+	table.MaxRollsPerShooter = snapshot.MaxRollsPerShooter
+	table.ShooterRolls = snapshot.ShooterRolls
+	table.DiceSetting = snapshot.DiceSetting
+	table.RollHistorySize = snapshot.RollHistorySize
+	table.RollsPerHour = snapshot.RollsPerHour
+	table.Transactions = snapshot.Transactions
+	table.Events = snapshot.Events
+	table.PayTable = snapshot.PayTable
+	table.lastDecision = snapshot.LastDecision
+	for _, record := range snapshot.RollHistory {
+		table.rollHistory.push(record, table.rollHistorySize())
+	}
+
+	if snapshot.Players != nil {
+		table.Players = snapshot.Players
+	}
+	if snapshot.WorkingDefaults != nil {
+		table.WorkingDefaults = snapshot.WorkingDefaults
+	}
+
+	for id, player := range table.Players {
+		if player == nil || player.ID != id {
+			return nil, fmt.Errorf("invalid table data: player entry %s does not match its ID", id)
+		}
+		if err := player.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid table data: %v", err)
+		}
+	}
+
+	// Seat everyone in the saved order; anyone missing from it sits at the end
+	seated := make(map[string]bool)
+	for _, id := range snapshot.Seats {
+		if _, exists := table.Players[id]; exists && !seated[id] {
+			table.Seats = append(table.Seats, id)
+			seated[id] = true
+		}
+	}
+	var unseated []string
+	for id := range table.Players {
+		if !seated[id] {
+			unseated = append(unseated, id)
+		}
+	}
+	sort.Strings(unseated)
+	table.Seats = append(table.Seats, unseated...)
+
+	if table.Shooter != "" {
+		if err := table.validateShooter(table.Shooter); err != nil {
+			return nil, fmt.Errorf("invalid table data: %v", err)
+		}
+	}
+
+	return table, nil
+}
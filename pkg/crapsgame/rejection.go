@@ -0,0 +1,35 @@
+package crapsgame
+
+// RejectionReason classifies why PlaceBet refused a bet
+type RejectionReason string
+
+const (
+	RejectLimit    RejectionReason = "LIMIT"    // amount outside the table, player, odds, come bet or exposure limits
+	RejectBankroll RejectionReason = "BANKROLL" // not enough bankroll to cover the bet and any vig
+	RejectState    RejectionReason = "STATE"    // bet not allowed at this point in the game
+	RejectType     RejectionReason = "TYPE"     // unknown bet type or numbers
+	RejectSession  RejectionReason = "SESSION"  // player reached their win goal or loss limit
+)
+
+// BetRejection is the error PlaceBet returns when a bet fails validation. Its
+// message is the underlying validation error; Reason says which kind of rule
+// was broken.
+type BetRejection struct {
+	Reason  RejectionReason
+	BetType string
+	Amount  float64
+	Err     error
+}
+
+func (r *BetRejection) Error() string {
+	return r.Err.Error()
+}
+
+func (r *BetRejection) Unwrap() error {
+	return r.Err
+}
+
+// reject wraps a validation error as a BetRejection
+func reject(reason RejectionReason, betType string, amount float64, err error) *BetRejection {
+	return &BetRejection{Reason: reason, BetType: betType, Amount: amount, Err: err}
+}
@@ -0,0 +1,117 @@
+package crapsgame
+
+import "math"
+
+// settlementPoints are the table states a bet can be valued in: 0 for the
+// come-out, otherwise the point that is on
+var settlementPoints = []int{0, 4, 5, 6, 8, 9, 10}
+
+// settlementTolerance and settlementIterations bound the iteration in betWorth
+const (
+	settlementTolerance  = 1e-12
+	settlementIterations = 100000
+)
+
+// FairSettlement returns what each of the player's bets is worth right now,
+// keyed by bet ID, so an abandoned game can be cashed out fairly. A bet is
+// worth its expected return from the current game state, stake included: a
+// win pays the stake and payout, a push returns the stake and a loss returns
+// nothing. Bets the player has turned off are worth their stake. An unknown
+// player has no bets to settle and gets nil.
+func (t *Table) FairSettlement(playerID string) map[string]float64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	player, err := t.getPlayer(playerID)
+	if err != nil {
+		return nil
+	}
+
+	worth := make(map[string]float64)
+	for _, bet := range player.Bets {
+		if !bet.PlayerWorking {
+			worth[bet.ID] = bet.Amount
+			continue
+		}
+		worth[bet.ID] = ToMoney(t.betWorth(bet, t.pointNumber())).Dollars()
+	}
+	return worth
+}
+
+// betWorth returns a bet's expected return starting with point on (0 for the
+// come-out). Rolls that leave the bet up move the table to its next state, so
+// the worth in every state is found together by iterating until it settles.
+// A come bet that travels is valued again on its come point.
+func (t *Table) betWorth(bet *Bet, point int) float64 {
+	traveled := make(map[[2]int]float64)
+	worth := make(map[int]float64)
+
+	for iteration := 0; iteration < settlementIterations; iteration++ {
+		next := make(map[int]float64)
+		change := 0.0
+		for _, p := range settlementPoints {
+			next[p] = t.nextRollWorth(bet, p, worth, traveled)
+			change = math.Max(change, math.Abs(next[p]-worth[p]))
+		}
+		worth = next
+		if change < settlementTolerance {
+			break
+		}
+	}
+	return worth[point]
+}
+
+// nextRollWorth averages a bet's return over the 36 rolls with point on,
+// using worth for the state a roll leaves the table in when the bet stays up
+func (t *Table) nextRollWorth(bet *Bet, point int, worth map[int]float64, traveled map[[2]int]float64) float64 {
+	state := StateComeOut
+	if point != 0 {
+		state = StatePoint
+	}
+	working := t.shouldBetBeWorking(bet, state)
+
+	total := 0.0
+	for die1 := 1; die1 <= 6; die1++ {
+		for die2 := 1; die2 <= 6; die2++ {
+			roll := &Roll{Die1: die1, Die2: die2, Total: die1 + die2, IsHard: die1 == die2}
+			after := pointAfterRoll(point, roll.Total)
+			if !working {
+				total += worth[after]
+				continue
+			}
+
+			preview, win, payout, remove := resolveCopy(bet, roll, state, point)
+			switch {
+			case win:
+				payout = t.payTablePayout(bet, roll, payout)
+				total += bet.Amount + payout
+			case remove:
+				// A loss returns nothing
+			case len(preview.Numbers) != len(bet.Numbers):
+				key := [2]int{preview.Numbers[0], after}
+				if _, seen := traveled[key]; !seen {
+					traveled[key] = t.betWorth(&preview, after)
+				}
+				total += traveled[key]
+			default:
+				total += worth[after]
+			}
+		}
+	}
+	return total / 36
+}
+
+// pointAfterRoll returns the point that is on after a roll of total with point
+// on, 0 for the come-out
+func pointAfterRoll(point, total int) int {
+	if point == 0 {
+		if _, err := rollTotalToPoint(total); err == nil {
+			return total
+		}
+		return 0
+	}
+	if total == point || total == 7 {
+		return 0
+	}
+	return point
+}
@@ -0,0 +1,42 @@
+package crapsgame
+
+// Trajectory plays rolls on the table with dice seeded by seed and returns
+// the player's bankroll before the first roll and after each one, for
+// charting an equity curve. betFn, if not nil, is called before every roll to
+// place or adjust bets; it runs without the table locked, so it may use the
+// table's methods. The same table, betFn and seed give the same trajectory.
+// The table's own dice are restored afterwards. The trajectory stops early if
+// the player leaves the table, and is nil for an unknown player.
+func Trajectory(table *Table, playerID string, betFn func(*Table), rolls int, seed int64) []float64 {
+	player, err := table.GetPlayer(playerID)
+	if err != nil {
+		return nil
+	}
+
+	table.mu.Lock()
+	dice := table.dice
+	table.dice = NewSeededDiceSource(seed)
+	table.mu.Unlock()
+	defer table.SetDiceSource(dice)
+
+	trajectory := []float64{table.bankrollOf(player)}
+	for roll := 0; roll < rolls; roll++ {
+		if betFn != nil {
+			betFn(table)
+		}
+		table.RollDiceAndResolve()
+
+		if player, err = table.GetPlayer(playerID); err != nil {
+			break
+		}
+		trajectory = append(trajectory, table.bankrollOf(player))
+	}
+	return trajectory
+}
+
+// bankrollOf reads a player's bankroll under the table lock
+func (t *Table) bankrollOf(player *Player) float64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return player.Bankroll
+}
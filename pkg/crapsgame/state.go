@@ -3,8 +3,10 @@ package crapsgame
 import (
 	"crypto/rand"
 	"fmt"
+	"math"
 	"math/big"
 	"sort"
+	"sync"
 	"time"
 )
 
@@ -41,15 +43,21 @@ type Roll struct {
 
 // Bet represents a single bet on the table
 type Bet struct {
-	ID            string
-	Type          string
-	Amount        float64
-	Player        string
-	PlacedAt      time.Time
-	Working       bool    // final computed status (systemWorking AND playerWorking)
-	PlayerWorking bool    // player's manual preference (defaults to true)
-	Odds          float64 // for odds bets
-	Numbers       []int   // for bets on specific numbers (e.g., place numbers)
+	ID             string
+	Type           string
+	Amount         float64
+	Player         string
+	PlacedAt       time.Time
+	Working        bool    // final computed status (systemWorking AND playerWorking)
+	PlayerWorking  bool    // player's manual preference (defaults to true)
+	CalledOn       bool    // player called the bet on for the current come-out
+	Odds           float64 // for odds bets
+	Numbers        []int   // for bets on specific numbers (e.g., place numbers)
+	CommissionPaid float64 // vig collected at placement, kept separate from the stake
+	ParentBetID    string  // for odds bets, the line or come bet they back
+	Advisory       string  // dealer's warning set at placement (see Table.WarnHighEdge)
+	Parlay         bool    // one-roll bet whose wins ride until it loses (see Table.ParlayBet)
+	KeepOnLoss     bool    // one-roll bet put back up after it loses (see Table.KeepBetOnLoss)
 }
 
 // Player represents a player at the table
@@ -60,41 +68,157 @@ type Player struct {
 	Bets         []*Bet
 	MaxBet       float64
 	MinBet       float64
-	WinGoal      float64
-	LossLimit    float64
+	WinGoal      float64 // stop betting once up this much on StartingBankroll (0 means no goal)
+	LossLimit    float64 // stop betting once down this much on StartingBankroll (0 means no limit)
 	SessionStart time.Time
+	Stats        SessionStats
+
+	// StartingBankroll is the bankroll the session started with, the base for
+	// WinGoal and LossLimit
+	StartingBankroll float64
+
+	// CallOddsOnComeOut keeps the player's odds working on the come-out when
+	// the table's WorkingDefaults turn odds off (see SetCallOddsOnComeOut)
+	CallOddsOnComeOut bool
+
+	// Rail is money the player has set aside off the active bankroll, such
+	// as pushed wagers when the table's PushToRail is on
+	Rail float64
+}
+
+// Validate checks the player's invariants: every bet has a unique ID, a
+// non-negative amount and belongs to this player, and the player's minimum
+// bet does not exceed their maximum (a maximum of 0 means no limit)
+func (p *Player) Validate() error {
+	if p.MaxBet > 0 && p.MinBet > p.MaxBet {
+		return fmt.Errorf("player %s minimum bet $%.2f exceeds maximum bet $%.2f", p.ID, p.MinBet, p.MaxBet)
+	}
+
+	seen := make(map[string]bool, len(p.Bets))
+	for _, bet := range p.Bets {
+		if bet == nil {
+			return fmt.Errorf("player %s has a nil bet", p.ID)
+		}
+		if seen[bet.ID] {
+			return fmt.Errorf("player %s has duplicate bet ID %s", p.ID, bet.ID)
+		}
+		seen[bet.ID] = true
+		if bet.Amount < 0 {
+			return fmt.Errorf("player %s bet %s has negative amount $%.2f", p.ID, bet.ID, bet.Amount)
+		}
+		if bet.Player != p.ID {
+			return fmt.Errorf("player %s holds bet %s belonging to %s", p.ID, bet.ID, bet.Player)
+		}
+	}
+	return nil
 }
 
+// BuyCommissionMode controls when the vig on buy and lay bets is collected
+type BuyCommissionMode int
+
+const (
+	CommissionOnWin       BuyCommissionMode = iota // vig taken out of each win (default)
+	CommissionOnPlacement                          // vig paid up front when the bet is placed
+)
+
+// OddsIncrementMode controls odds amounts that wouldn't pay in whole dollars,
+// such as $5 behind a 5 paying $7.50 at 3:2
+type OddsIncrementMode int
+
+const (
+	OddsAnyAmount OddsIncrementMode = iota // any amount, paid down to the cent (default)
+	OddsStrict                             // amounts that don't pay whole dollars are rejected
+	OddsRound                              // amounts are rounded down to one that pays whole dollars
+)
+
 // Table represents the craps table
 type Table struct {
 	State       GameState
 	Point       Point
 	CurrentRoll *Roll
 	Players     map[string]*Player
-	Shooter     string // current shooter's ID
+	Seats       []string // player IDs in seating order; the dice pass through them in turn
+	Shooter     string   // current shooter's ID
 	MinBet      float64
 	MaxBet      float64
 	MaxOdds     int // maximum odds allowed (e.g., 3x, 5x)
 	CreatedAt   time.Time
 	LastRoll    time.Time
+
+	BuyCommissionMode BuyCommissionMode
+	OddsIncrementMode OddsIncrementMode
+	MaxComeBets       int     // simultaneous come/don't come bets per player (0 = unlimited)
+	MaxExposure       float64 // total a player may have on the layout at once (0 = unlimited)
+	WarnHighEdge      float64 // house edge percent above which placed bets carry an advisory (0 = off)
+	PushToRail        bool    // pushed wagers go to the player's Rail instead of the bankroll
+
+	// HouseBankroll is the house's side of the game: every payout to a
+	// player comes out of it and every lost wager and up-front commission goes
+	// into it, while pushes and refunds leave it alone. It starts at 0, so it
+	// reads as the house's profit and loss; set it to give the house a
+	// starting bankroll.
+	HouseBankroll float64
+
+	// ComeBetsOnComeOut lets come and don't come bets be placed on the
+	// come-out. Like any new come bet, one placed on the come-out is decided
+	// from the very next roll, so it works just like a pass or don't pass bet.
+	ComeBetsOnComeOut bool
+
+	// MaxRollsPerShooter forces a shooter change after this many rolls without
+	// a seven-out (0 = unlimited). ShooterRolls counts the current shooter's rolls.
+	MaxRollsPerShooter int
+	ShooterRolls       int
+	DiceSetting        string // current shooter's cosmetic dice set (see SetDiceSetting)
+
+	// RollHistorySize caps how many rolls GetRollHistory remembers
+	// (0 = DefaultRollHistorySize, negative = keep none)
+	RollHistorySize int
+
+	// RollsPerHour is the pace used to estimate a player's hourly cost
+	// (0 = DefaultRollsPerHour)
+	RollsPerHour float64
+
+	// WorkingDefaults says whether each bet category works on the come-out roll.
+	// Categories not listed always work; one-roll bets always work.
+	WorkingDefaults map[BetCategory]bool
+
+	Transactions []Transaction // ledger of every bankroll movement
+	Events       []TableEvent  // notable table events such as forced shooter changes
+
+	PayTable map[string]CanonicalBetDefinition // house-rule payout overrides (see ApplyPayTable)
+
+	lastDecision string      // decision label for the most recent roll (see LastDecision)
+	rollHistory  rollHistory // most recent rolls (see GetRollHistory)
+
+	// mu guards the table and its players. Exported methods take the lock;
+	// unexported helpers assume the caller already holds it.
+	mu sync.RWMutex
+
+	dice      DiceSource
+	observers []TableObserver // see RegisterObserver
 }
 
 // NewTable creates a new craps table
 func NewTable(minBet, maxBet float64, maxOdds int) *Table {
 	table := &Table{
-		State:     StateComeOut,
-		Point:     PointOff,
-		Players:   make(map[string]*Player),
-		MinBet:    minBet,
-		MaxBet:    maxBet,
-		MaxOdds:   maxOdds,
-		CreatedAt: time.Now(),
+		State:           StateComeOut,
+		Point:           PointOff,
+		Players:         make(map[string]*Player),
+		MinBet:          minBet,
+		MaxBet:          maxBet,
+		MaxOdds:         maxOdds,
+		CreatedAt:       time.Now(),
+		WorkingDefaults: DefaultWorkingDefaults(),
+		dice:            secureDiceSource{},
 	}
 	return table
 }
 
 // AddPlayer adds a player to the table
 func (t *Table) AddPlayer(id, name string, bankroll float64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
 	if _, exists := t.Players[id]; exists {
 		return fmt.Errorf("player %s already exists", id)
 	}
@@ -102,12 +226,15 @@ func (t *Table) AddPlayer(id, name string, bankroll float64) error {
 	t.Players[id] = &Player{
 		ID:           id,
 		Name:         name,
-		Bankroll:     bankroll,
+		Bankroll:     ToMoney(bankroll).Dollars(),
 		Bets:         []*Bet{},
 		MaxBet:       t.MaxBet,
 		MinBet:       t.MinBet,
 		SessionStart: time.Now(),
+
+		StartingBankroll: ToMoney(bankroll).Dollars(),
 	}
+	t.Seats = append(t.Seats, id)
 
 	// Set first player as shooter if no shooter exists
 	if t.Shooter == "" {
@@ -119,73 +246,102 @@ func (t *Table) AddPlayer(id, name string, bankroll float64) error {
 
 // RemovePlayer removes a player from the table
 func (t *Table) RemovePlayer(id string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
 	player, exists := t.Players[id]
 	if !exists {
 		return fmt.Errorf("player %s not found", id)
 	}
 
-	// Remove all player's bets and return money for active bets
+	// Return every bet still on the layout, including odds and other bets
+	// that are off, before the player leaves
 	for _, bet := range player.Bets {
-		if bet.Working {
-			// Return bet amount to player's bankroll
-			player.Bankroll += bet.Amount
-		}
+		player.Bankroll = addDollars(player.Bankroll, bet.Amount)
+		t.recordTransaction(player, bet, TransactionRefund, bet.Amount)
+	}
+	player.Bets = nil
+
+	// If this was the shooter, pass the dice to the next seat before leaving
+	if t.Shooter == id {
+		t.assignNewShooter()
 	}
 
 	delete(t.Players, id)
+	for i, seat := range t.Seats {
+		if seat == id {
+			t.Seats = append(t.Seats[:i], t.Seats[i+1:]...)
+			break
+		}
+	}
 
-	// If this was the shooter, assign new shooter
+	// A lone player has nobody to pass the dice to
 	if t.Shooter == id {
-		t.assignNewShooter()
+		t.Shooter = ""
 	}
 
 	return nil
 }
 
-// assignNewShooter assigns a new shooter from available players
-func (t *Table) assignNewShooter() {
-	if len(t.Players) == 0 {
-		t.Shooter = ""
-		return
+// playersInSeatOrder returns the players in seating order. Anyone missing
+// from the seats, such as a player added to Players directly, follows in ID
+// order.
+func (t *Table) playersInSeatOrder() []*Player {
+	players := make([]*Player, 0, len(t.Players))
+	seated := make(map[string]bool, len(t.Seats))
+	for _, id := range t.Seats {
+		if player, exists := t.Players[id]; exists && !seated[id] {
+			players = append(players, player)
+			seated[id] = true
+		}
 	}
 
-	// Get sorted list of player IDs for consistent order
-	var playerIDs []string
+	var unseated []string
 	for id := range t.Players {
-		playerIDs = append(playerIDs, id)
+		if !seated[id] {
+			unseated = append(unseated, id)
+		}
 	}
+	sort.Strings(unseated)
+	for _, id := range unseated {
+		players = append(players, t.Players[id])
+	}
+	return players
+}
 
-	// Sort to ensure consistent ordering
-	sort.Strings(playerIDs)
+// assignNewShooter assigns a new shooter from available players
+func (t *Table) assignNewShooter() {
+	t.ShooterRolls = 0
+	t.DiceSetting = ""
 
-	// Find current shooter and assign next player
-	if t.Shooter == "" {
-		// No current shooter, assign first player
-		t.Shooter = playerIDs[0]
+	if len(t.Seats) == 0 {
+		t.Shooter = ""
 		return
 	}
 
-	// Find current shooter index
+	// Find current shooter's seat
 	currentIndex := -1
-	for i, id := range playerIDs {
+	for i, id := range t.Seats {
 		if id == t.Shooter {
 			currentIndex = i
 			break
 		}
 	}
 
-	// Assign next player (wrap around if needed)
-	if currentIndex == -1 {
-		// Current shooter not found, assign first player
-		t.Shooter = playerIDs[0]
-	} else {
-		nextIndex := (currentIndex + 1) % len(playerIDs)
-		t.Shooter = playerIDs[nextIndex]
-	}
+	// Pass the dice to the next seat (wrap around if needed). With no current
+	// shooter, currentIndex is -1 and the first seat gets the dice.
+	nextIndex := (currentIndex + 1) % len(t.Seats)
+	t.Shooter = t.Seats[nextIndex]
 }
 
 // RollDice simulates a dice roll using secure RNG
 func (t *Table) RollDice() *Roll {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.rollDice()
+}
+
+func (t *Table) rollDice() *Roll {
 	// Validate shooter before roll
 	if err := t.validateShooter(t.Shooter); err != nil {
 		fmt.Printf("Warning: Invalid shooter before roll: %v\n", err)
@@ -198,16 +354,7 @@ func (t *Table) RollDice() *Roll {
 		fmt.Printf("Warning: Invalid table state before roll: %v\n", err)
 	}
 
-	roll := &Roll{
-		Die1: rollDieSecure(),
-		Die2: rollDieSecure(),
-		Time: time.Now(),
-	}
-	roll.Total = roll.Die1 + roll.Die2
-	roll.IsHard = roll.Die1 == roll.Die2
-
-	t.CurrentRoll = roll
-	t.LastRoll = roll.Time
+	roll := t.newRoll()
 
 	// Note: State updates are handled by the caller (ExecuteGameTurn)
 	// This prevents double state updates when ROLL DICE is called
@@ -215,84 +362,94 @@ func (t *Table) RollDice() *Roll {
 	return roll
 }
 
-// UpdateGameState updates the game state based on the current roll
+// UpdateGameState moves the game to its next state for a roll: establishing
+// the point, making it or sevening out. Bets are not resolved; call
+// ResolveAllBets first, as RollDiceAndResolve does.
 func (t *Table) UpdateGameState(roll *Roll) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.updateGameState(roll)
+}
+
+// UpdateGameStateOnly is the same as UpdateGameState
+func (t *Table) UpdateGameStateOnly(roll *Roll) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.updateGameState(roll)
+}
+
+func (t *Table) updateGameState(roll *Roll) {
+	t.lastDecision = t.classifyDecision(roll)
+	shooter := t.Shooter
+	defer t.countShooterRoll(shooter)
+
 	switch t.State {
 	case StateComeOut:
 		switch roll.Total {
 		case 7, 11:
-			// Natural - pass line wins, don't pass loses
+			// Natural - stay in come out
 			t.natural(roll)
 		case 2, 3, 12:
-			// Craps - pass line loses, don't pass wins (except 12)
+			// Craps - stay in come out
 			t.craps(roll)
 		default:
-			// Point established
 			t.establishPoint(roll)
 		}
 	case StatePoint:
 		if roll.Total == 7 {
-			// Seven out - pass line loses, don't pass wins
 			t.sevenOut(roll)
-		} else {
-			pointNumber, err := PointToNumber(t.Point)
-			if err != nil {
-				fmt.Printf("Error getting point number: %v\n", err)
-				return
-			}
-			if roll.Total == pointNumber {
-				// Point made - pass line wins, don't pass loses
-				t.resolvePoint(roll)
-			}
+		} else if roll.Total == t.pointNumber() {
+			t.resolvePoint(roll)
 		}
 		// Other numbers don't change the point
 	}
 }
 
-// UpdateGameStateOnly updates only the game state based on the roll, without bet resolution
-func (t *Table) UpdateGameStateOnly(roll *Roll) {
-	switch t.State {
-	case StateComeOut:
+// Decision labels returned by LastDecision
+const (
+	DecisionNatural          = "natural"
+	DecisionCraps            = "craps"
+	DecisionPointEstablished = "point-established"
+	DecisionPointMade        = "point-made"
+	DecisionSevenOut         = "seven-out"
+	DecisionNeutral          = "neutral"
+)
+
+// LastDecision labels the most recent roll by what it meant for the line:
+// natural, craps, point-established, point-made, seven-out, or neutral.
+// Before any roll it returns neutral.
+func (t *Table) LastDecision() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.lastDecision == "" {
+		return DecisionNeutral
+	}
+	return t.lastDecision
+}
+
+// classifyDecision labels a roll against the state it was thrown in
+func (t *Table) classifyDecision(roll *Roll) string {
+	if t.State == StateComeOut {
 		switch roll.Total {
 		case 7, 11:
-			// Natural - stay in come out
-			fmt.Printf("Natural: %d - staying in come out\n", roll.Total)
+			return DecisionNatural
 		case 2, 3, 12:
-			// Craps - stay in come out
-			fmt.Printf("Craps: %d - staying in come out\n", roll.Total)
+			return DecisionCraps
 		default:
-			// Point established
-			point, err := rollTotalToPoint(roll.Total)
-			if err != nil {
-				fmt.Printf("Error converting roll total to point: %v\n", err)
-				return
-			}
-			t.State = StatePoint
-			t.Point = point
-			fmt.Printf("Point established: %d\n", roll.Total)
-		}
-	case StatePoint:
-		if roll.Total == 7 {
-			// Seven out - back to come out
-			t.State = StateComeOut
-			t.Point = PointOff
-			t.assignNewShooter()
-			fmt.Printf("Seven out! New shooter: %s\n", t.Shooter)
-		} else {
-			pointNumber, err := PointToNumber(t.Point)
-			if err != nil {
-				fmt.Printf("Error getting point number: %v\n", err)
-				return
-			}
-			if roll.Total == pointNumber {
-				// Point made - back to come out
-				t.State = StateComeOut
-				t.Point = PointOff
-				fmt.Printf("Point resolved: %d\n", roll.Total)
-			}
-			// Other numbers don't change the point
+			return DecisionPointEstablished
 		}
 	}
+
+	if roll.Total == 7 {
+		return DecisionSevenOut
+	}
+	if roll.Total == t.pointNumber() {
+		return DecisionPointMade
+	}
+	return DecisionNeutral
 }
 
 // establishPoint establishes a point when a point number is rolled during come out
@@ -317,7 +474,7 @@ func (t *Table) establishPoint(roll *Roll) {
 	t.Point = point
 
 	// Log state transition
-	t.LogStateTransition(fromState, t.State, roll, "point establishment")
+	t.stateChanged(fromState, t.State, roll, "point establishment")
 	fmt.Printf("Point established: %d\n", roll.Total)
 }
 
@@ -336,7 +493,7 @@ func (t *Table) resolvePoint(roll *Roll) {
 	t.Point = PointOff
 
 	// Log state transition
-	t.LogStateTransition(fromState, t.State, roll, "point resolution")
+	t.stateChanged(fromState, t.State, roll, "point resolution")
 	fmt.Printf("Point resolved: %d\n", roll.Total)
 }
 
@@ -354,7 +511,7 @@ func (t *Table) sevenOut(roll *Roll) {
 	t.State = StateSevenOut
 
 	// Log state transition
-	t.LogStateTransition(fromState, t.State, roll, "seven out")
+	t.stateChanged(fromState, t.State, roll, "seven out")
 
 	// Assign new shooter
 	t.assignNewShooter()
@@ -364,7 +521,7 @@ func (t *Table) sevenOut(roll *Roll) {
 	t.Point = PointOff
 
 	// Log final state transition
-	t.LogStateTransition(StateSevenOut, t.State, roll, "come out after seven out")
+	t.stateChanged(StateSevenOut, t.State, roll, "come out after seven out")
 	fmt.Printf("Seven out! New shooter: %s\n", t.Shooter)
 }
 
@@ -506,11 +663,117 @@ func (t *Table) validateTableState() error {
 
 // PlaceBet places a bet on the table
 func (t *Table) PlaceBet(playerID, betType string, amount float64, numbers []int) (*Bet, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.placeBet(playerID, betType, amount, numbers)
+}
+
+// BetRequest is one bet in a batch placed with PlaceBetBatch
+type BetRequest struct {
+	BetType string
+	Amount  float64
+	Numbers []int
+}
+
+// PlaceBetBatch places a set of bets for the player all or nothing, such as
+// a dealer's standard spread of place bets. Each bet is validated as PlaceBet
+// would, in order, so later bets see the bankroll the earlier ones used. If
+// any bet is rejected, none are placed: the player's bankroll, bets, stats,
+// the ledger and the house bankroll are put back as they were and the
+// rejection is returned.
+func (t *Table) PlaceBetBatch(playerID string, bets []BetRequest) ([]*Bet, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	player, err := t.getPlayer(playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := t.saveBets(player)
+	var batch []*Bet
+	for _, request := range bets {
+		bet, err := t.placeBet(playerID, request.BetType, request.Amount, request.Numbers)
+		if err != nil {
+			t.restoreBets(player, before)
+			return nil, err
+		}
+		batch = append(batch, bet)
+	}
+	return batch, nil
+}
+
+// PlaceBetWithOdds places a line or come bet and odds behind that same bet,
+// all or nothing: if the odds are rejected the bet isn't placed either. The
+// odds back the new bet even when the player has another of the same type up.
+func (t *Table) PlaceBetWithOdds(playerID, betType string, amount, oddsAmount float64) (*Bet, *Bet, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	player, err := t.getPlayer(playerID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	oddsType := ""
+	for odds, base := range oddsBaseBets {
+		if base == betType {
+			oddsType = odds
+		}
+	}
+	if oddsType == "" {
+		return nil, nil, fmt.Errorf("%s does not take odds", betType)
+	}
+
+	before := t.saveBets(player)
+	bet, err := t.placeBet(playerID, betType, amount, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	odds, err := t.placeBetOn(playerID, oddsType, oddsAmount, nil, bet.ID)
+	if err != nil {
+		t.restoreBets(player, before)
+		return nil, nil, err
+	}
+	return bet, odds, nil
+}
+
+// savedBets is what placing bets changes, kept so a rejected set of bets can
+// be put back as it was
+type savedBets struct {
+	bankroll     float64
+	bets         []*Bet
+	stats        SessionStats
+	house        float64
+	transactions int
+}
+
+func (t *Table) saveBets(player *Player) savedBets {
+	return savedBets{player.Bankroll, player.Bets, player.Stats, t.HouseBankroll, len(t.Transactions)}
+}
+
+func (t *Table) restoreBets(player *Player, saved savedBets) {
+	player.Bankroll, player.Bets, player.Stats = saved.bankroll, saved.bets, saved.stats
+	t.HouseBankroll, t.Transactions = saved.house, t.Transactions[:saved.transactions]
+}
+
+// placeBet places a bet on the table; the caller holds the lock
+func (t *Table) placeBet(playerID, betType string, amount float64, numbers []int) (*Bet, error) {
+	return t.placeBetOn(playerID, betType, amount, numbers, "")
+}
+
+// placeBetOn places a bet as placeBet does; an odds bet backs the bet with
+// parentID, or when parentID is "" the one findOddsBaseBet picks
+func (t *Table) placeBetOn(playerID, betType string, amount float64, numbers []int, parentID string) (*Bet, error) {
 	player, exists := t.Players[playerID]
 	if !exists {
 		return nil, fmt.Errorf("player %s not found", playerID)
 	}
 
+	// Bets are made in whole cents
+	amount = ToMoney(amount).Dollars()
+
 	// Create bet object for comprehensive validation
 	bet := &Bet{
 		ID:            generateBetID(),
@@ -526,38 +789,104 @@ func (t *Table) PlaceBet(playerID, betType string, amount float64, numbers []int
 	// Comprehensive validation using validation functions from crapsql package
 	// Import the validation functions to ensure consistent validation across the codebase
 
-	// Validate bet amount
-	if err := t.validateBetAmount(amount); err != nil {
-		return nil, fmt.Errorf("bet amount validation failed: %v", err)
+	if err := t.validateWager(player, betType, amount); err != nil {
+		return nil, err
 	}
 
-	// Validate bankroll
-	if err := t.validateBankroll(player, amount); err != nil {
-		return nil, fmt.Errorf("bankroll validation failed: %v", err)
+	// Validate odds bets are backed by their line or come bet
+	parent, err := t.findOddsBaseBet(betType, player, numbers, parentID)
+	if err != nil {
+		return nil, reject(RejectState, betType, amount, fmt.Errorf("odds validation failed: %v", err))
 	}
+	if parent != nil {
+		if err := t.validateOddsAmount(betType, player, parent, amount); err != nil {
+			return nil, reject(RejectLimit, betType, amount, fmt.Errorf("odds validation failed: %v", err))
+		}
+		bet.ParentBetID = parent.ID
+		point := t.pointNumber()
+		if betType == "COME_ODDS" || betType == "DONT_COME_ODDS" {
+			// Come odds are decided on the come bet's own point
+			point = parent.Numbers[0]
+			bet.Numbers = []int{point}
+		}
 
-	// Validate bet type
-	if err := t.validateBetType(betType); err != nil {
-		return nil, fmt.Errorf("bet type validation failed: %v", err)
+		amount, err = t.applyOddsIncrement(betType, point, amount)
+		if err != nil {
+			return nil, reject(RejectLimit, betType, bet.Amount, fmt.Errorf("odds validation failed: %v", err))
+		}
+		bet.Amount = amount
 	}
 
-	// Validate game state for this bet type
-	if err := t.validateGameState(betType, t.State); err != nil {
-		return nil, fmt.Errorf("game state validation failed: %v", err)
+	// Validate the table's come bet cap
+	if err := t.validateComeBetLimit(betType, player); err != nil {
+		return nil, reject(RejectLimit, betType, amount, fmt.Errorf("come bet validation failed: %v", err))
+	}
+
+	// Validate the table's exposure cap
+	if err := t.validateExposure(player, amount); err != nil {
+		return nil, reject(RejectLimit, betType, amount, fmt.Errorf("exposure validation failed: %v", err))
 	}
 
 	// Validate bet placement (comprehensive validation)
 	if err := t.validateBetPlacement(bet, player); err != nil {
-		return nil, fmt.Errorf("bet placement validation failed: %v", err)
+		return nil, reject(RejectType, betType, amount, fmt.Errorf("bet placement validation failed: %v", err))
 	}
 
+	// Collect the vig up front when the table charges it at placement
+	commission := t.placementCommission(betType, amount)
+	if commission > 0 {
+		if err := t.validateBankroll(player, amount+commission); err != nil {
+			return nil, reject(RejectBankroll, betType, amount, fmt.Errorf("bankroll validation failed: %v", err))
+		}
+	}
+
+	// Bets that are off on the come-out (place, buy, lay, ...) start off
+	bet.Working = t.shouldBetBeWorking(bet, t.State)
+
 	// Deduct from bankroll
-	player.Bankroll -= amount
+	player.Bankroll = subDollars(player.Bankroll, amount)
 	player.Bets = append(player.Bets, bet)
+	t.recordTransaction(player, bet, TransactionBet, amount)
+
+	if commission > 0 {
+		player.Bankroll = subDollars(player.Bankroll, commission)
+		t.HouseBankroll = addDollars(t.HouseBankroll, commission)
+		bet.CommissionPaid = commission
+		t.recordTransaction(player, bet, TransactionCommission, commission)
+	}
+
+	bet.Advisory = t.highEdgeAdvisory(betType)
 
 	return bet, nil
 }
 
+// highEdgeAdvisory returns the dealer's warning for a bet whose house edge
+// exceeds WarnHighEdge, or "" when the bet is fine or warnings are off
+func (t *Table) highEdgeAdvisory(betType string) string {
+	if t.WarnHighEdge <= 0 {
+		return ""
+	}
+	def, exists := CanonicalBetDefinitions[betType]
+	if !exists || def.HouseEdge <= t.WarnHighEdge {
+		return ""
+	}
+	return fmt.Sprintf("dealer suggests: %s has a %.2f%% house edge, above the %.2f%% warning threshold",
+		betType, def.HouseEdge, t.WarnHighEdge)
+}
+
+// placementCommission returns the vig owed at placement for buy and lay bets,
+// or 0 when the table collects it on wins instead
+func (t *Table) placementCommission(betType string, amount float64) float64 {
+	if t.BuyCommissionMode != CommissionOnPlacement {
+		return 0
+	}
+	def, exists := CanonicalBetDefinitions[betType]
+	if !exists || (def.Category != BuyBets && def.Category != LayBets) {
+		return 0
+	}
+	return ToMoney(amount * def.Commission).Dollars()
+}
+
 // removeBet removes a bet from the table
 func (t *Table) removeBet(betID string) {
 	for _, player := range t.Players {
@@ -578,7 +907,8 @@ func (t *Table) removeBetWithRefund(betID string) {
 			if bet.ID == betID {
 				// Return bet amount to bankroll if bet is still working
 				if bet.Working {
-					player.Bankroll += bet.Amount
+					player.Bankroll = addDollars(player.Bankroll, bet.Amount)
+					t.recordTransaction(player, bet, TransactionRefund, bet.Amount)
 				}
 				// Remove bet from slice
 				player.Bets = append(player.Bets[:i], player.Bets[i+1:]...)
@@ -590,6 +920,50 @@ func (t *Table) removeBetWithRefund(betID string) {
 
 // GetPlayer returns a player by ID
 func (t *Table) GetPlayer(id string) (*Player, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.getPlayer(id)
+}
+
+// PlayerSnapshot returns a copy of a player taken under the table lock, safe
+// to read while the game goes on
+func (t *Table) PlayerSnapshot(id string) (Player, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	player, err := t.getPlayer(id)
+	if err != nil {
+		return Player{}, err
+	}
+	return player.snapshot(), nil
+}
+
+// PlayerSnapshots returns a copy of every player at the table, sorted by ID
+func (t *Table) PlayerSnapshots() []Player {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	players := make([]Player, 0, len(t.Players))
+	for _, player := range t.Players {
+		players = append(players, player.snapshot())
+	}
+	sort.Slice(players, func(a, b int) bool { return players[a].ID < players[b].ID })
+	return players
+}
+
+// snapshot copies the player and their bets so the copy shares nothing the
+// game mutates
+func (p *Player) snapshot() Player {
+	snapshot := *p
+	snapshot.Bets = make([]*Bet, len(p.Bets))
+	for idx, bet := range p.Bets {
+		copied := *bet
+		snapshot.Bets[idx] = &copied
+	}
+	return snapshot
+}
+
+func (t *Table) getPlayer(id string) (*Player, error) {
 	player, exists := t.Players[id]
 	if !exists {
 		return nil, fmt.Errorf("player %s not found", id)
@@ -597,28 +971,94 @@ func (t *Table) GetPlayer(id string) (*Player, error) {
 	return player, nil
 }
 
+// GetBet finds a bet by its ID across all players and returns it with its owner
+func (t *Table) GetBet(betID string) (*Bet, *Player, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, player := range t.Players {
+		for _, bet := range player.Bets {
+			if bet.ID == betID {
+				return bet, player, nil
+			}
+		}
+	}
+	return nil, nil, fmt.Errorf("bet %s not found", betID)
+}
+
+// WorkingNumbers returns the point numbers, in ascending order, that the
+// player has working action on through place, buy, lay, place-to-lose and
+// traveled come or don't come bets
+func (t *Table) WorkingNumbers(playerID string) []int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	player, exists := t.Players[playerID]
+	if !exists {
+		return nil
+	}
+
+	seen := make(map[int]bool)
+	for _, bet := range player.Bets {
+		if !bet.Working {
+			continue
+		}
+		def, exists := CanonicalBetDefinitions[bet.Type]
+		if !exists {
+			continue
+		}
+		switch def.Category {
+		case PlaceBets, BuyBets, LayBets, PlaceToLoseBets:
+			for _, n := range def.ValidNumbers {
+				seen[n] = true
+			}
+		case ComeBets:
+			if len(bet.Numbers) > 0 {
+				seen[bet.Numbers[0]] = true
+			}
+		}
+	}
+
+	numbers := make([]int, 0, len(seen))
+	for n := range seen {
+		numbers = append(numbers, n)
+	}
+	sort.Ints(numbers)
+	return numbers
+}
+
 // GetState returns the current game state
 func (t *Table) GetState() GameState {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
 	return t.State
 }
 
 // GetPoint returns the current point
 func (t *Table) GetPoint() Point {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
 	return t.Point
 }
 
 // GetShooter returns the current shooter
 func (t *Table) GetShooter() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
 	return t.Shooter
 }
 
 // IsComeOut returns true if we're in come out phase
 func (t *Table) IsComeOut() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
 	return t.State == StateComeOut
 }
 
 // IsPoint returns true if we have a point established
 func (t *Table) IsPoint() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
 	return t.State == StatePoint
 }
 
@@ -678,21 +1118,33 @@ func (p Point) String() string {
 
 // GetStateString returns the current game state as a string
 func (t *Table) GetStateString() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
 	return t.State.String()
 }
 
 // GetPointString returns the current point as a string
 func (t *Table) GetPointString() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
 	return t.Point.String()
 }
 
 // IsPointEstablished returns true if a point is currently established
 func (t *Table) IsPointEstablished() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
 	return t.State == StatePoint && t.Point != PointOff
 }
 
 // GetPointNumber returns the current point number as an integer
 func (t *Table) GetPointNumber() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.pointNumber()
+}
+
+func (t *Table) pointNumber() int {
 	if t.State == StatePoint && t.Point != PointOff {
 		pointNumber, err := PointToNumber(t.Point)
 		if err != nil {
@@ -709,6 +1161,14 @@ func (t *Table) LogStateTransition(fromState GameState, toState GameState, roll
 		fromState.String(), toState.String(), roll.Total, reason)
 }
 
+// stateChanged logs a state transition and notifies the table's observers
+func (t *Table) stateChanged(fromState GameState, toState GameState, roll *Roll, reason string) {
+	t.LogStateTransition(fromState, toState, roll, reason)
+	for _, observer := range t.observers {
+		observer.OnStateChange(fromState, toState)
+	}
+}
+
 // validateBetAmount validates that the bet amount is within table limits
 func (t *Table) validateBetAmount(amount float64) error {
 	if amount < t.MinBet {
@@ -723,6 +1183,82 @@ func (t *Table) validateBetAmount(amount float64) error {
 	return nil
 }
 
+// validateWager runs the checks every wager must pass before it goes on the
+// layout: the player's session limits, the table and player bet limits, the
+// bankroll, the bet type and the game state. It returns a *BetRejection.
+func (t *Table) validateWager(player *Player, betType string, amount float64) error {
+	// A player who has reached their win goal or loss limit is done betting
+	if err := t.checkSessionLimits(player); err != nil {
+		return reject(RejectSession, betType, amount, err)
+	}
+
+	// Validate bet amount
+	if err := t.validateBetAmount(amount); err != nil {
+		return reject(RejectLimit, betType, amount, fmt.Errorf("bet amount validation failed: %v", err))
+	}
+
+	// Validate the player's own limits
+	if err := t.validatePlayerBetLimits(player, amount); err != nil {
+		return reject(RejectLimit, betType, amount, fmt.Errorf("bet amount validation failed: %v", err))
+	}
+
+	// Validate bankroll
+	if err := t.validateBankroll(player, amount); err != nil {
+		return reject(RejectBankroll, betType, amount, fmt.Errorf("bankroll validation failed: %v", err))
+	}
+
+	// Validate bet type
+	if err := t.validateBetType(betType); err != nil {
+		return reject(RejectType, betType, amount, fmt.Errorf("bet type validation failed: %v", err))
+	}
+
+	// Validate game state for this bet type
+	if err := t.validateGameState(betType, t.State); err != nil {
+		return reject(RejectState, betType, amount, fmt.Errorf("game state validation failed: %v", err))
+	}
+	return nil
+}
+
+// validatePlayerBetLimits validates the amount against the player's own
+// limits; together with validateBetAmount the more restrictive limit applies.
+// A zero player limit means the player hasn't set one.
+func (t *Table) validatePlayerBetLimits(player *Player, amount float64) error {
+	if player.MinBet > 0 && amount < player.MinBet {
+		return fmt.Errorf("bet amount $%.2f is below player minimum $%.2f", amount, player.MinBet)
+	}
+	if player.MaxBet > 0 && amount > player.MaxBet {
+		return fmt.Errorf("bet amount $%.2f exceeds player maximum $%.2f", amount, player.MaxBet)
+	}
+	return nil
+}
+
+// validateExposure validates that a new wager keeps the player within MaxExposure
+func (t *Table) validateExposure(player *Player, amount float64) error {
+	if t.MaxExposure <= 0 {
+		return nil
+	}
+	exposure := t.playerExposure(player.ID)
+	if exposure+amount > t.MaxExposure {
+		return fmt.Errorf("bet of $%.2f would put $%.2f on the layout, above the $%.2f exposure cap",
+			amount, exposure+amount, t.MaxExposure)
+	}
+	return nil
+}
+
+// checkSessionLimits stops a player who has reached their win goal or loss
+// limit. The session result counts money on the layout as still the player's,
+// so making a bet doesn't count as losing it.
+func (t *Table) checkSessionLimits(player *Player) error {
+	result := subDollars(addDollars(player.Bankroll, t.playerExposure(player.ID)), player.StartingBankroll)
+	if player.WinGoal > 0 && result >= player.WinGoal {
+		return fmt.Errorf("win goal reached, stop betting: up $%.2f on a $%.2f goal", result, player.WinGoal)
+	}
+	if player.LossLimit > 0 && -result >= player.LossLimit {
+		return fmt.Errorf("loss limit reached, stop betting: down $%.2f on a $%.2f limit", -result, player.LossLimit)
+	}
+	return nil
+}
+
 // validateBankroll validates that the player has sufficient bankroll
 func (t *Table) validateBankroll(player *Player, amount float64) error {
 	if amount > player.Bankroll {
@@ -754,23 +1290,173 @@ func (t *Table) validateGameState(betType string, state GameState) error {
 		return fmt.Errorf("bet type %s can only be placed during come-out phase", betType)
 	}
 
-	// Check if bet requires point phase
-	if betDef.RequiresPoint && state != StatePoint {
+	// Check if bet requires point phase; come bets may also go up on the
+	// come-out when the table allows it
+	comeOut := betDef.Category == ComeBets && t.ComeBetsOnComeOut
+	if betDef.RequiresPoint && state != StatePoint && !comeOut {
 		return fmt.Errorf("bet type %s can only be placed during point phase", betType)
 	}
 
 	return nil
 }
 
-// validateBetPlacement performs comprehensive validation of bet placement
-func (t *Table) validateBetPlacement(bet *Bet, player *Player) error {
-	// Validate bet object
-	if bet == nil {
-		return fmt.Errorf("bet object is nil")
+// oddsBaseBets maps each odds bet to the bet it must be placed behind
+var oddsBaseBets = map[string]string{
+	"PASS_ODDS":      "PASS_LINE",
+	"DONT_PASS_ODDS": "DONT_PASS",
+	"COME_ODDS":      "COME",
+	"DONT_COME_ODDS": "DONT_COME",
+}
+
+// findOddsBaseBet returns the working bet an odds bet will back, or nil for
+// bets that aren't odds. Come odds need a come bet that has traveled to its
+// come point; numbers, if given, pick the come point. Come bets that already
+// have odds are used last. A parentID names the bet to back instead.
+func (t *Table) findOddsBaseBet(betType string, player *Player, numbers []int, parentID string) (*Bet, error) {
+	baseType, isOdds := oddsBaseBets[betType]
+	if !isOdds {
+		return nil, nil
 	}
 
-	// Validate player
-	if player == nil {
+	comeOdds := betType == "COME_ODDS" || betType == "DONT_COME_ODDS"
+
+	if parentID != "" {
+		for _, bet := range player.Bets {
+			if bet.ID != parentID {
+				continue
+			}
+			if bet.Type != baseType || !bet.Working || (comeOdds && len(bet.Numbers) == 0) {
+				return nil, fmt.Errorf("%s can't back bet %s", betType, parentID)
+			}
+			return bet, nil
+		}
+		return nil, fmt.Errorf("bet %s not found", parentID)
+	}
+
+	var parent *Bet
+	for _, bet := range player.Bets {
+		if bet.Type != baseType || !bet.Working {
+			continue
+		}
+		if !comeOdds {
+			return bet, nil
+		}
+		if len(bet.Numbers) == 0 || (len(numbers) > 0 && bet.Numbers[0] != numbers[0]) {
+			continue
+		}
+		if parent == nil || (t.hasOdds(player, parent) && !t.hasOdds(player, bet)) {
+			parent = bet
+		}
+	}
+	if parent != nil {
+		return parent, nil
+	}
+
+	if comeOdds {
+		if len(numbers) > 0 {
+			return nil, fmt.Errorf("%s requires a working %s bet on %d", betType, baseType, numbers[0])
+		}
+		return nil, fmt.Errorf("%s requires a working %s bet with a come point", betType, baseType)
+	}
+	return nil, fmt.Errorf("%s requires a working %s bet", betType, baseType)
+}
+
+// validateOddsAmount validates that odds behind parent, including any odds
+// already backing it, stay within the table's MaxOdds multiple (0 = no limit)
+func (t *Table) validateOddsAmount(betType string, player *Player, parent *Bet, amount float64) error {
+	if t.MaxOdds <= 0 {
+		return nil
+	}
+
+	existing := 0.0
+	for _, bet := range player.Bets {
+		if bet.ParentBetID == parent.ID {
+			existing += bet.Amount
+		}
+	}
+
+	maxOdds := parent.Amount * float64(t.MaxOdds)
+	if existing+amount > maxOdds {
+		return fmt.Errorf("%s of $%.2f exceeds %dx odds: $%.2f allowed behind $%.2f %s ($%.2f already taken)",
+			betType, amount, t.MaxOdds, maxOdds, parent.Amount, parent.Type, existing)
+	}
+	return nil
+}
+
+// validateOddsBehind validates that the odds already backing parent would
+// still be within MaxOdds if parent were cut to amount
+func (t *Table) validateOddsBehind(player *Player, parent *Bet, amount float64) error {
+	if t.MaxOdds <= 0 {
+		return nil
+	}
+
+	odds := 0.0
+	for _, bet := range player.Bets {
+		if bet.ParentBetID == parent.ID {
+			odds += bet.Amount
+		}
+	}
+
+	maxOdds := amount * float64(t.MaxOdds)
+	if odds > maxOdds {
+		return fmt.Errorf("$%.2f odds behind %s exceed %dx odds on $%.2f; reduce the odds first",
+			odds, parent.Type, t.MaxOdds, amount)
+	}
+	return nil
+}
+
+// oddsParent returns the line or come bet that an odds bet backs, or nil
+// for any other bet
+func (t *Table) oddsParent(player *Player, odds *Bet) *Bet {
+	if odds.ParentBetID == "" {
+		return nil
+	}
+	for _, bet := range player.Bets {
+		if bet.ID == odds.ParentBetID {
+			return bet
+		}
+	}
+	return nil
+}
+
+// hasOdds reports whether any of the player's bets are odds backing parent
+func (t *Table) hasOdds(player *Player, parent *Bet) bool {
+	for _, bet := range player.Bets {
+		if bet.ParentBetID == parent.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// validateComeBetLimit validates that a new come or don't come bet stays within MaxComeBets
+func (t *Table) validateComeBetLimit(betType string, player *Player) error {
+	if t.MaxComeBets <= 0 || (betType != "COME" && betType != "DONT_COME") {
+		return nil
+	}
+
+	comeBets := 0
+	for _, bet := range player.Bets {
+		if bet.Type == "COME" || bet.Type == "DONT_COME" {
+			comeBets++
+		}
+	}
+
+	if comeBets >= t.MaxComeBets {
+		return fmt.Errorf("maximum of %d come bets already placed", t.MaxComeBets)
+	}
+	return nil
+}
+
+// validateBetPlacement performs comprehensive validation of bet placement
+func (t *Table) validateBetPlacement(bet *Bet, player *Player) error {
+	// Validate bet object
+	if bet == nil {
+		return fmt.Errorf("bet object is nil")
+	}
+
+	// Validate player
+	if player == nil {
 		return fmt.Errorf("player object is nil")
 	}
 
@@ -848,17 +1534,57 @@ func PointToNumber(point Point) (int, error) {
 	}
 }
 
-// ResolveAllBets resolves all bets using the unified ResolveBet function
+// ResolveAllBets resolves all bets using the unified ResolveBet function,
+// against the game state as it was before the roll. Call UpdateGameState
+// afterwards to move the game on.
 func (t *Table) ResolveAllBets(roll *Roll) []string {
-	var results []string
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.resolveAllBets(roll)
+}
+
+func (t *Table) resolveAllBets(roll *Roll) []string {
+	return resultMessages(t.resolveAllBetResults(roll))
+}
+
+// BetResult is the decision on one bet for a roll
+type BetResult struct {
+	PlayerID string
+	BetID    string
+	BetType  string
+	Outcome  string  // OutcomeWin, OutcomeLose, or OutcomePush for a win paying nothing
+	Payout   float64 // winnings on a win, not counting the returned stake
+	Message  string
+}
+
+// resolveAllBetResults decides every working bet on a roll. Each bet is
+// resolved against the game state before the roll, so a come-out 2 loses the
+// pass line and pays the field whatever the roll does to the state; the state
+// only moves afterwards, in updateGameState. Players are settled in seat order
+// and each player's bets in the order they were placed, so the results come
+// out in the same order every time.
+func (t *Table) resolveAllBetResults(roll *Roll) []BetResult {
+	var results []BetResult
+
+	for _, observer := range t.observers {
+		observer.OnRoll(roll)
+	}
 
 	// Update bet working status based on current game state
-	t.UpdateBetWorkingStatus()
+	t.updateBetWorkingStatus()
 
 	// Process all player bets
-	for _, player := range t.Players {
+	for _, player := range t.playersInSeatOrder() {
 		var betsToRemove []*Bet
 
+		player.Stats.RollsPlayed++
+		for _, bet := range player.Bets {
+			if bet.Working {
+				player.Stats.RollsSurvived++
+				break
+			}
+		}
+
 		for _, bet := range player.Bets {
 			if !bet.Working {
 				continue
@@ -866,22 +1592,71 @@ func (t *Table) ResolveAllBets(roll *Roll) []string {
 
 			// Use the unified ResolveBet function from canonical_bets.go
 			// Pass the current point number for bet resolution
-			currentPoint := t.GetPointNumber()
+			currentPoint := t.pointNumber()
 			win, payout, remove := ResolveBet(bet, roll, t.State, currentPoint)
 
+			result := BetResult{PlayerID: player.ID, BetID: bet.ID, BetType: bet.Type}
+			// Hardways and hops are decided by the faces, so decisions name them
+			faces := ""
+			if facesMatter(bet.Type) {
+				faces = " — " + describeDice(roll)
+			}
 			if win {
-				if remove {
+				payout = t.payTablePayout(bet, roll, payout)
+				t.HouseBankroll = subDollars(t.HouseBankroll, payout)
+				// A win of nothing is a push, which doesn't break a streak
+				result.Outcome = OutcomePush
+				if payout > 0 {
+					result.Outcome = OutcomeWin
+					player.Stats.recordDecision(true)
+				}
+				result.Payout = payout
+				t.notifyBetResolved(player, bet, result.Outcome, payout)
+
+				if remove && bet.Parlay {
+					// The whole return stays up for the next roll
+					result.Message = t.rideParlay(player, bet, payout) + faces
+					results = append(results, result)
+					continue
+				}
+
+				if remove && payout == 0 && t.PushToRail {
+					// A push goes back to the rail, off the active bankroll
+					player.Rail = addDollars(player.Rail, bet.Amount)
+					t.recordTransaction(player, bet, TransactionRail, bet.Amount)
+					result.Message = fmt.Sprintf("↩️ %s pushes, $%.2f to the rail%s", bet.Type, bet.Amount, faces)
+				} else if remove {
 					// Bet wins and is removed - add bet amount + payout to bankroll
-					player.Bankroll += bet.Amount + payout
-					results = append(results, fmt.Sprintf("🎉 %s wins $%.2f (bet: $%.2f + payout: $%.2f)", bet.Type, bet.Amount+payout, bet.Amount, payout))
+					player.Bankroll = addDollars(player.Bankroll, addDollars(bet.Amount, payout))
+					player.Stats.recordWin(payout)
+					txType := TransactionWin
+					if payout == 0 {
+						txType = TransactionPush
+					}
+					t.recordTransaction(player, bet, txType, bet.Amount+payout)
+					result.Message = fmt.Sprintf("🎉 %s wins $%.2f (bet: $%.2f + payout: $%.2f)%s", bet.Type, bet.Amount+payout, bet.Amount, payout, faces)
 				} else {
 					// Bet wins but stays on table - only add payout to bankroll
-					player.Bankroll += payout
-					results = append(results, fmt.Sprintf("🎉 %s wins $%.2f (payout only)", bet.Type, payout))
+					player.Bankroll = addDollars(player.Bankroll, payout)
+					player.Stats.recordWin(payout)
+					t.recordTransaction(player, bet, TransactionWin, payout)
+					result.Message = fmt.Sprintf("🎉 %s wins $%.2f (payout only)%s", bet.Type, payout, faces)
 				}
+				results = append(results, result)
 			} else if remove {
-				// Bet loses - no money added
-				results = append(results, fmt.Sprintf("💸 %s loses $%.2f", bet.Type, bet.Amount))
+				// Bet loses - no money added, and the house takes the wager
+				t.HouseBankroll = addDollars(t.HouseBankroll, bet.Amount)
+				t.notifyBetResolved(player, bet, OutcomeLose, 0)
+				t.recordTransaction(player, bet, TransactionLoss, bet.Amount)
+				player.Stats.recordDecision(false)
+				result.Outcome = OutcomeLose
+				result.Message = fmt.Sprintf("💸 %s loses $%.2f%s", bet.Type, bet.Amount, faces)
+				if bet.KeepOnLoss && t.rebet(player, bet) {
+					result.Message += fmt.Sprintf("\n🔁 %s back up for $%.2f", bet.Type, bet.Amount)
+					results = append(results, result)
+					continue
+				}
+				results = append(results, result)
 			}
 
 			if remove {
@@ -898,45 +1673,223 @@ func (t *Table) ResolveAllBets(roll *Roll) []string {
 				}
 			}
 		}
+
+		// Odds come down with the bet they back; any the roll didn't decide,
+		// such as odds the player turned off, go back to the player
+		results = append(results, t.returnOrphanedOdds(player)...)
 	}
 
 	return results
 }
 
-// RollDiceAndResolve follows the simplified game flow: roll dice, resolve bets, update state
-func (t *Table) RollDiceAndResolve() (*Roll, []string) {
-	// Validate shooter before roll
-	if err := t.validateShooter(t.Shooter); err != nil {
-		fmt.Printf("Warning: Invalid shooter before roll: %v\n", err)
-		t.assignNewShooter()
+// returnOrphanedOdds refunds the player's odds bets whose line or come bet is
+// no longer on the layout
+func (t *Table) returnOrphanedOdds(player *Player) []BetResult {
+	onLayout := make(map[string]bool)
+	for _, bet := range player.Bets {
+		onLayout[bet.ID] = true
+	}
+
+	var results []BetResult
+	t.refundBets(player, func(bet *Bet) bool {
+		if bet.ParentBetID == "" || onLayout[bet.ParentBetID] {
+			return false
+		}
+		results = append(results, BetResult{
+			PlayerID: player.ID,
+			BetID:    bet.ID,
+			BetType:  bet.Type,
+			Outcome:  OutcomePush,
+			Message:  fmt.Sprintf("↩️ %s returned $%.2f with the bet it backed", bet.Type, bet.Amount),
+		})
+		return true
+	})
+	return results
+}
+
+// WouldWin previews how a bet would fare on a roll of die1 and die2 in the
+// current game state, without changing the bet or the table. decided is false
+// if the bet is off or the roll leaves it alone; a decided win paying nothing
+// is a push.
+func (t *Table) WouldWin(bet *Bet, die1, die2 int) (win bool, payout float64, decided bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if !t.shouldBetBeWorking(bet, t.State) || !bet.PlayerWorking {
+		return false, 0, false
+	}
+
+	roll := &Roll{Die1: die1, Die2: die2, Total: die1 + die2, IsHard: die1 == die2}
+	win, payout, remove := t.previewBet(bet, roll)
+	if !win {
+		return false, 0, remove
+	}
+	return true, payout, true
+}
+
+// BetOutcome is how a bet would fare on a roll previewed with
+// PreviewResolution
+type BetOutcome struct {
+	PlayerID string
+	BetID    string
+	BetType  string
+	Win      bool
+	Payout   float64 // winnings on a win, not counting the returned stake
+	Remove   bool    // the roll takes the bet down: it loses, or wins and is paid off
+}
+
+// PreviewResolution returns how every working bet would fare on a roll of
+// die1 and die2 in the current game state, in the order the roll would settle
+// them. Bets the roll leaves alone are listed with Win and Remove false.
+// Nothing changes: bankrolls, bets and the game state are left as they are.
+func (t *Table) PreviewResolution(die1, die2 int) []BetOutcome {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	roll := &Roll{Die1: die1, Die2: die2, Total: die1 + die2, IsHard: die1 == die2}
+	var outcomes []BetOutcome
+	for _, player := range t.playersInSeatOrder() {
+		for _, bet := range player.Bets {
+			if !t.shouldBetBeWorking(bet, t.State) || !bet.PlayerWorking {
+				continue
+			}
+			win, payout, remove := t.previewBet(bet, roll)
+			outcomes = append(outcomes, BetOutcome{
+				PlayerID: player.ID,
+				BetID:    bet.ID,
+				BetType:  bet.Type,
+				Win:      win,
+				Payout:   payout,
+				Remove:   remove,
+			})
+		}
+	}
+	return outcomes
+}
+
+// previewBet resolves a bet on a roll with ResolveBet, priced the way the
+// table would pay it, without changing the bet
+func (t *Table) previewBet(bet *Bet, roll *Roll) (win bool, payout float64, remove bool) {
+	_, win, payout, remove = resolveCopy(bet, roll, t.State, t.pointNumber())
+	if !win {
+		return false, 0, remove
+	}
+	payout = t.payTablePayout(bet, roll, payout)
+	return true, payout, remove
+}
+
+// resolveCopy resolves a copy of a bet with ResolveBet and returns the copy as
+// the roll left it. Come bets travel as they resolve, so the bet itself must
+// not be handed to the resolver.
+func resolveCopy(bet *Bet, roll *Roll, state GameState, point int) (preview Bet, win bool, payout float64, remove bool) {
+	preview = *bet
+	win, payout, remove = ResolveBet(&preview, roll, state, point)
+	return preview, win, payout, remove
+}
+
+// rideParlay pays a parlayed bet's win and lets it ride: the payout is added to
+// the bet, up to the lower of the table and player maximums and within the
+// exposure cap, and anything over is paid out
+func (t *Table) rideParlay(player *Player, bet *Bet, payout float64) string {
+	player.Bankroll = addDollars(player.Bankroll, payout)
+	player.Stats.recordWin(payout)
+	t.recordTransaction(player, bet, TransactionWin, payout)
+
+	ride := payout
+	maxBet := t.MaxBet
+	if player.MaxBet > 0 && (maxBet <= 0 || player.MaxBet < maxBet) {
+		maxBet = player.MaxBet
+	}
+	if maxBet > 0 && addDollars(bet.Amount, ride) > maxBet {
+		ride = math.Max(subDollars(maxBet, bet.Amount), 0)
+	}
+	if t.MaxExposure > 0 {
+		ride = math.Min(ride, math.Max(subDollars(t.MaxExposure, t.playerExposure(player.ID)), 0))
+	}
+	if ride > 0 {
+		player.Bankroll = subDollars(player.Bankroll, ride)
+		bet.Amount = addDollars(bet.Amount, ride)
+		t.recordTransaction(player, bet, TransactionPress, ride)
 	}
 
-	// Step 1: Roll the dice
-	roll := &Roll{
-		Die1: rollDieSecure(),
-		Die2: rollDieSecure(),
-		Time: time.Now(),
+	if ride < payout {
+		return fmt.Sprintf("🎉 %s wins $%.2f, parlays $%.2f up to the bet limit and pays $%.2f", bet.Type, payout, ride, subDollars(payout, ride))
 	}
-	roll.Total = roll.Die1 + roll.Die2
-	roll.IsHard = roll.Die1 == roll.Die2
+	return fmt.Sprintf("🎉 %s wins $%.2f and parlays: $%.2f rides", bet.Type, payout, bet.Amount)
+}
 
-	t.CurrentRoll = roll
-	t.LastRoll = roll.Time
+// RollDiceAndResolve rolls the dice and plays the roll: every bet is resolved
+// with ResolveBet, then the game state is updated. ExecuteGameTurn and
+// PlaySequence go through the same steps.
+func (t *Table) RollDiceAndResolve() (*Roll, []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
 
+	roll := t.rollDice()
 	fmt.Printf("Rolled: %d-%d = %d\n", roll.Die1, roll.Die2, roll.Total)
 
-	// Step 2: Resolve all bets using unified ResolveBet function
-	betResults := t.ResolveAllBets(roll)
+	return roll, resultMessages(t.playRoll(roll))
+}
+
+// playRoll resolves every bet for a roll and then updates the game state.
+// Every way of rolling at the table ends here, so bets are decided the same
+// way, and in the same order, whichever entry point is used.
+func (t *Table) playRoll(roll *Roll) []BetResult {
+	results := t.resolveAllBetResults(roll)
+	t.updateGameState(roll)
+	return results
+}
+
+// resultMessages returns the message for each bet result
+func resultMessages(results []BetResult) []string {
+	var messages []string
+	for _, result := range results {
+		messages = append(messages, result.Message)
+	}
+	return messages
+}
+
+// PlaySequence plays a scripted list of rolls through the same roll, resolve
+// and state update pipeline as RollDiceAndResolve, returning the decisions
+// made on each roll. The whole sequence is checked first, so a pair with a die
+// outside 1-6 is an error and no roll is played.
+func (t *Table) PlaySequence(dice [][2]int) ([][]BetResult, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for n, pair := range dice {
+		if pair[0] < 1 || pair[0] > 6 || pair[1] < 1 || pair[1] > 6 {
+			return nil, fmt.Errorf("roll %d: invalid dice %d and %d, each die must be 1-6", n+1, pair[0], pair[1])
+		}
+	}
+
+	var results [][]BetResult
+	for _, pair := range dice {
+		if err := t.validateShooter(t.Shooter); err != nil {
+			t.assignNewShooter()
+		}
 
-	// Step 3: Update game state (after bet resolution)
-	t.UpdateGameStateOnly(roll)
+		roll := t.recordRoll(pair[0], pair[1])
+		results = append(results, t.playRoll(roll))
+	}
 
-	return roll, betResults
+	return results, nil
 }
 
 func (t *Table) UpdateBetWorkingStatus() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.updateBetWorkingStatus()
+}
+
+func (t *Table) updateBetWorkingStatus() {
 	for _, player := range t.Players {
 		for _, bet := range player.Bets {
+			// A bet called on only stays on for the come-out it was called for
+			if t.State != StateComeOut {
+				bet.CalledOn = false
+			}
 			// Final working status = system rules AND player preference
 			systemWorking := t.shouldBetBeWorking(bet, t.State)
 			bet.Working = systemWorking && bet.PlayerWorking
@@ -945,28 +1898,57 @@ func (t *Table) UpdateBetWorkingStatus() {
 }
 
 func (t *Table) shouldBetBeWorking(bet *Bet, state GameState) bool {
-	// Place bets are OFF during come-out phase by default
-	if state == StateComeOut {
-		switch bet.Type {
-		case "PLACE_4", "PLACE_5", "PLACE_6", "PLACE_8", "PLACE_9", "PLACE_10",
-			"PLACE_INSIDE", "PLACE_OUTSIDE", "PLACE_NUMBERS":
-			return false
-		case "BUY_4", "BUY_5", "BUY_6", "BUY_8", "BUY_9", "BUY_10":
-			return false
-		case "LAY_4", "LAY_5", "LAY_6", "LAY_8", "LAY_9", "LAY_10":
-			return false
-		case "PLACE_TO_LOSE_4", "PLACE_TO_LOSE_5", "PLACE_TO_LOSE_6",
-			"PLACE_TO_LOSE_8", "PLACE_TO_LOSE_9", "PLACE_TO_LOSE_10":
-			return false
-		case "HARD_4", "HARD_6", "HARD_8", "HARD_10", "ALL_HARDWAYS":
-			return false
-		case "BIG_6", "BIG_8":
-			return false
+	if state != StateComeOut || bet.CalledOn {
+		return true
+	}
+
+	// One-roll bets are decided on the next roll, so they always work
+	def, exists := CanonicalBetDefinitions[bet.Type]
+	if !exists || def.OneRoll {
+		return true
+	}
+
+	if def.Category == OddsBets {
+		if player, exists := t.Players[bet.Player]; exists && player.CallOddsOnComeOut {
+			return true
 		}
 	}
 
-	// All other bets are working by default
-	return true
+	defaults := t.WorkingDefaults
+	if defaults == nil {
+		defaults = DefaultWorkingDefaults()
+	}
+
+	// Categories without a configured default are working on the come-out
+	working, configured := defaults[def.Category]
+	return !configured || working
+}
+
+// WorksOnComeOut reports whether a bet of the given type works on the come-out
+// roll under the table's WorkingDefaults, unless the player calls it on
+func (t *Table) WorksOnComeOut(betType string) (bool, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if _, exists := CanonicalBetDefinitions[betType]; !exists {
+		return false, fmt.Errorf("unknown bet type: %s", betType)
+	}
+	return t.shouldBetBeWorking(&Bet{Type: betType}, StateComeOut), nil
+}
+
+// DefaultWorkingDefaults returns the standard casino rule: place, buy, lay,
+// place-to-lose, hardway and big 6/8 bets and odds are off on the come-out
+// roll
+func DefaultWorkingDefaults() map[BetCategory]bool {
+	return map[BetCategory]bool{
+		OddsBets:        false,
+		PlaceBets:       false,
+		BuyBets:         false,
+		LayBets:         false,
+		PlaceToLoseBets: false,
+		HardWayBets:     false,
+		BigBets:         false,
+	}
 }
 
 // PlayGame implements the simplified game flow:
@@ -985,24 +1967,23 @@ func (t *Table) PlayGame() {
 	fmt.Println("Ready for bets...")
 }
 
-// ExecuteGameTurn executes one complete turn of the game
-// This is the main game loop that follows your desired pattern
+// ExecuteGameTurn executes one complete turn of the game: roll the dice,
+// resolve every bet with ResolveBet and update the game state. It plays the
+// roll exactly as RollDiceAndResolve does.
 func (t *Table) ExecuteGameTurn() (*Roll, []string) {
-	// Step 1: Roll the dice
-	roll := t.RollDice()
-
-	// Step 2: Pay/collect every bet using unified ResolveBet
-	betResults := t.ResolveAllBets(roll)
-
-	// Step 3: Update game state based on dice
-	t.UpdateGameStateOnly(roll)
+	t.mu.Lock()
+	defer t.mu.Unlock()
 
-	return roll, betResults
+	roll := t.rollDice()
+	return roll, resultMessages(t.playRoll(roll))
 }
 
 // RemoveBet removes a specific bet type for a player
 func (t *Table) RemoveBet(playerID, betType string) error {
-	player, err := t.GetPlayer(playerID)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	player, err := t.getPlayer(playerID)
 	if err != nil {
 		return fmt.Errorf("player %s not found", playerID)
 	}
@@ -1013,7 +1994,8 @@ func (t *Table) RemoveBet(playerID, betType string) error {
 	for _, bet := range player.Bets {
 		if bet.Type == betType {
 			// Return bet amount to player's bankroll
-			player.Bankroll += bet.Amount
+			player.Bankroll = addDollars(player.Bankroll, bet.Amount)
+			t.recordTransaction(player, bet, TransactionRefund, bet.Amount)
 			removedCount++
 		} else {
 			remainingBets = append(remainingBets, bet)
@@ -1029,9 +2011,143 @@ func (t *Table) RemoveBet(playerID, betType string) error {
 	return nil
 }
 
+// RemoveBetByID takes down a single bet and refunds it, leaving the player's
+// other bets of the same type in place. Contract bets can't come down.
+func (t *Table) RemoveBetByID(playerID, betID string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	player, err := t.getPlayer(playerID)
+	if err != nil {
+		return fmt.Errorf("player %s not found", playerID)
+	}
+
+	for i, bet := range player.Bets {
+		if bet.ID == betID {
+			if t.isContractBet(bet) {
+				return fmt.Errorf("bet %s is a contract %s bet and can't be removed", betID, bet.Type)
+			}
+			player.Bankroll = addDollars(player.Bankroll, bet.Amount)
+			t.recordTransaction(player, bet, TransactionRefund, bet.Amount)
+			player.Bets = append(player.Bets[:i], player.Bets[i+1:]...)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("bet %s not found", betID)
+}
+
+// RemoveAllBets is TakeDownAll under the name REMOVE ALL uses
+func (t *Table) RemoveAllBets(playerID string) (int, float64, error) {
+	return t.TakeDownAll(playerID)
+}
+
+// TakeDownAll takes down and refunds every bet a player may take down. Contract
+// bets, a pass line bet once the point is on and a come bet that has traveled
+// to its number, stay up, as do bets the player has turned off. It returns how
+// many bets came down and the total refunded.
+func (t *Table) TakeDownAll(playerID string) (int, float64, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	player, err := t.getPlayer(playerID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("player %s not found", playerID)
+	}
+
+	removedCount, refunded := t.refundBets(player, func(bet *Bet) bool {
+		return bet.PlayerWorking && !t.isContractBet(bet)
+	})
+	return removedCount, refunded, nil
+}
+
+// isContractBet reports whether a bet must stay up until it is decided
+func (t *Table) isContractBet(bet *Bet) bool {
+	switch bet.Type {
+	case "PASS_LINE":
+		return t.State == StatePoint
+	case "COME":
+		return len(bet.Numbers) > 0
+	}
+	return false
+}
+
+// refundBets takes down and refunds each of the player's bets that remove
+// reports true for, returning how many came down and the total refunded
+func (t *Table) refundBets(player *Player, remove func(*Bet) bool) (int, float64) {
+	var remainingBets []*Bet
+	removedCount := 0
+	refunded := Money(0)
+
+	for _, bet := range player.Bets {
+		if !remove(bet) {
+			remainingBets = append(remainingBets, bet)
+			continue
+		}
+		refunded = refunded.Add(ToMoney(bet.Amount))
+		t.recordTransaction(player, bet, TransactionRefund, bet.Amount)
+		removedCount++
+	}
+
+	player.Bankroll = ToMoney(player.Bankroll).Add(refunded).Dollars()
+	player.Bets = remainingBets
+
+	return removedCount, refunded.Dollars()
+}
+
+// ReduceBet takes down part of a standing bet and refunds it to the player.
+// Contract bets can't be reduced, what's left must meet the table and player
+// minimums, and any odds behind the bet must still fit within MaxOdds.
+func (t *Table) ReduceBet(playerID, betType string, amount float64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	player, err := t.getPlayer(playerID)
+	if err != nil {
+		return fmt.Errorf("player %s not found", playerID)
+	}
+
+	if amount <= 0 {
+		return fmt.Errorf("reduction amount must be positive")
+	}
+
+	for _, bet := range player.Bets {
+		if bet.Type != betType {
+			continue
+		}
+
+		if t.isContractBet(bet) {
+			return fmt.Errorf("%s is a contract bet and can't be reduced", betType)
+		}
+		if amount > bet.Amount {
+			return fmt.Errorf("reduction $%.2f exceeds %s bet of $%.2f", amount, betType, bet.Amount)
+		}
+		remaining := subDollars(bet.Amount, amount)
+		if remaining < t.MinBet {
+			return fmt.Errorf("reducing %s by $%.2f would leave $%.2f, below table minimum $%.2f", betType, amount, remaining, t.MinBet)
+		}
+		if err := t.validatePlayerBetLimits(player, remaining); err != nil {
+			return fmt.Errorf("reducing %s by $%.2f: %v", betType, amount, err)
+		}
+		if err := t.validateOddsBehind(player, bet, remaining); err != nil {
+			return err
+		}
+
+		bet.Amount = remaining
+		player.Bankroll = addDollars(player.Bankroll, amount)
+		t.recordTransaction(player, bet, TransactionRefund, amount)
+		return nil
+	}
+
+	return fmt.Errorf("no active %s bets to reduce", betType)
+}
+
 // PressBet increases the amount of a specific bet type for a player
 func (t *Table) PressBet(playerID, betType string, amount float64) error {
-	player, err := t.GetPlayer(playerID)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	player, err := t.getPlayer(playerID)
 	if err != nil {
 		return fmt.Errorf("player %s not found", playerID)
 	}
@@ -1040,29 +2156,102 @@ func (t *Table) PressBet(playerID, betType string, amount float64) error {
 		return fmt.Errorf("press amount must be positive")
 	}
 
-	if player.Bankroll < amount {
-		return fmt.Errorf("insufficient bankroll for press")
+	return t.pressBets(player, betType, func(bet *Bet) float64 { return addDollars(bet.Amount, amount) })
+}
+
+// PressBetTo raises each of the player's bets of a type to target,
+// drawing the difference from the bankroll
+func (t *Table) PressBetTo(playerID, betType string, target float64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	player, err := t.getPlayer(playerID)
+	if err != nil {
+		return fmt.Errorf("player %s not found", playerID)
+	}
+
+	return t.pressBets(player, betType, func(*Bet) float64 { return target })
+}
+
+// PressBetFull doubles each of the player's bets of a type, the
+// full press a player makes with a win
+func (t *Table) PressBetFull(playerID, betType string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	player, err := t.getPlayer(playerID)
+	if err != nil {
+		return fmt.Errorf("player %s not found", playerID)
+	}
+
+	return t.pressBets(player, betType, func(bet *Bet) float64 { return bet.Amount * 2 })
+}
+
+// pressBets raises each bet of a type the player hasn't turned off to the amount target returns
+// for it. Every new amount must pass the checks a new bet of that size would:
+// the table and player limits, the odds multiple behind the bet's parent and
+// the exposure cap. The bankroll must cover the whole press, or no bet is
+// changed.
+func (t *Table) pressBets(player *Player, betType string, target func(*Bet) float64) error {
+	if err := t.checkSessionLimits(player); err != nil {
+		return err
 	}
 
-	pressedCount := 0
+	var pressed []*Bet
+	var targets []Money
+	needed := Money(0)
+
 	for _, bet := range player.Bets {
-		if bet.Type == betType && bet.Working {
-			bet.Amount += amount
-			player.Bankroll -= amount
-			pressedCount++
+		if bet.Type != betType || !bet.PlayerWorking {
+			continue
+		}
+		amount := ToMoney(target(bet))
+		if amount <= ToMoney(bet.Amount) {
+			return fmt.Errorf("%s is already $%.2f, press to more than that", betType, bet.Amount)
 		}
+		if err := t.validateBetAmount(amount.Dollars()); err != nil {
+			return err
+		}
+		if err := t.validatePlayerBetLimits(player, amount.Dollars()); err != nil {
+			return err
+		}
+		if parent := t.oddsParent(player, bet); parent != nil {
+			added := amount.Sub(ToMoney(bet.Amount)).Dollars()
+			if err := t.validateOddsAmount(betType, player, parent, added); err != nil {
+				return err
+			}
+		}
+		pressed = append(pressed, bet)
+		targets = append(targets, amount)
+		needed = needed.Add(amount.Sub(ToMoney(bet.Amount)))
 	}
 
-	if pressedCount == 0 {
+	if len(pressed) == 0 {
 		return fmt.Errorf("no active %s bets to press", betType)
 	}
+	if ToMoney(player.Bankroll) < needed {
+		return fmt.Errorf("insufficient bankroll for press: need %s, have $%.2f", needed, player.Bankroll)
+	}
+	if err := t.validateExposure(player, needed.Dollars()); err != nil {
+		return err
+	}
+
+	for i, bet := range pressed {
+		added := targets[i].Sub(ToMoney(bet.Amount)).Dollars()
+		bet.Amount = targets[i].Dollars()
+		player.Bankroll = subDollars(player.Bankroll, added)
+		t.recordTransaction(player, bet, TransactionPress, added)
+	}
 
 	return nil
 }
 
 // TurnBet turns a specific bet type on or off for a player
 func (t *Table) TurnBet(playerID, betType string, working bool) error {
-	player, err := t.GetPlayer(playerID)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	player, err := t.getPlayer(playerID)
 	if err != nil {
 		return fmt.Errorf("player %s not found", playerID)
 	}
@@ -1070,11 +2259,7 @@ func (t *Table) TurnBet(playerID, betType string, working bool) error {
 	turnedCount := 0
 	for _, bet := range player.Bets {
 		if bet.Type == betType {
-			// Set player preference
-			bet.PlayerWorking = working
-			// Recalculate final working status
-			systemWorking := t.shouldBetBeWorking(bet, t.State)
-			bet.Working = systemWorking && bet.PlayerWorking
+			t.turnBet(bet, working)
 			turnedCount++
 		}
 	}
@@ -1086,6 +2271,256 @@ func (t *Table) TurnBet(playerID, betType string, working bool) error {
 	return nil
 }
 
+// TurnBetByID turns a single bet on or off, e.g. to call one bet working on
+// the come-out without touching the player's other bets of the same type
+func (t *Table) TurnBetByID(playerID, betID string, working bool) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	player, err := t.getPlayer(playerID)
+	if err != nil {
+		return fmt.Errorf("player %s not found", playerID)
+	}
+
+	for _, bet := range player.Bets {
+		if bet.ID == betID {
+			t.turnBet(bet, working)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("bet %s not found", betID)
+}
+
+// SetWorkingDefault sets whether bets in a category work on the come-out
+// roll, e.g. SetWorkingDefault(OddsBets, false) for a table that takes come
+// odds off on the come-out. It applies to bets already on the layout as well
+// as later ones.
+func (t *Table) SetWorkingDefault(category BetCategory, working bool) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(GetBetsByCategory()[category]) == 0 {
+		return fmt.Errorf("unknown bet category: %s", category)
+	}
+
+	if t.WorkingDefaults == nil {
+		t.WorkingDefaults = DefaultWorkingDefaults()
+	}
+	t.WorkingDefaults[category] = working
+	for _, player := range t.Players {
+		for _, bet := range player.Bets {
+			bet.Working = t.shouldBetBeWorking(bet, t.State) && bet.PlayerWorking
+		}
+	}
+	return nil
+}
+
+// SetCallOddsOnComeOut keeps a player's odds working on the come-out, or
+// lets them follow the table's WorkingDefaults again. It applies to odds
+// already on the layout as well as later ones.
+func (t *Table) SetCallOddsOnComeOut(playerID string, call bool) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	player, err := t.getPlayer(playerID)
+	if err != nil {
+		return err
+	}
+
+	player.CallOddsOnComeOut = call
+	for _, bet := range player.Bets {
+		bet.Working = t.shouldBetBeWorking(bet, t.State) && bet.PlayerWorking
+	}
+	return nil
+}
+
+// SetPlayerBankroll gives a player a new bankroll, which starts a new session
+// for their win goal and loss limit. resetStats clears their session stats too.
+func (t *Table) SetPlayerBankroll(playerID string, amount float64, resetStats bool) error {
+	return t.updatePlayer(playerID, func(player *Player) {
+		player.Bankroll = ToMoney(amount).Dollars()
+		player.StartingBankroll = player.Bankroll
+		if resetStats {
+			player.Stats = SessionStats{}
+		}
+	})
+}
+
+// SetPlayerMaxBet sets the largest single bet a player will make
+func (t *Table) SetPlayerMaxBet(playerID string, amount float64) error {
+	return t.updatePlayer(playerID, func(player *Player) { player.MaxBet = amount })
+}
+
+// SetPlayerMinBet sets the smallest single bet a player will make
+func (t *Table) SetPlayerMinBet(playerID string, amount float64) error {
+	return t.updatePlayer(playerID, func(player *Player) { player.MinBet = amount })
+}
+
+// SetPlayerWinGoal sets how far up on the session a player stops betting
+func (t *Table) SetPlayerWinGoal(playerID string, amount float64) error {
+	return t.updatePlayer(playerID, func(player *Player) { player.WinGoal = amount })
+}
+
+// SetPlayerLossLimit sets how far down on the session a player stops betting
+func (t *Table) SetPlayerLossLimit(playerID string, amount float64) error {
+	return t.updatePlayer(playerID, func(player *Player) { player.LossLimit = amount })
+}
+
+// updatePlayer applies a change to a player under the table lock
+func (t *Table) updatePlayer(playerID string, update func(*Player)) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	player, err := t.getPlayer(playerID)
+	if err != nil {
+		return err
+	}
+	update(player)
+	return nil
+}
+
+// turnBet sets the player's preference for a bet and recalculates whether it
+// works; turning a bet on during the come-out calls it on
+func (t *Table) turnBet(bet *Bet, working bool) {
+	bet.PlayerWorking = working
+	bet.CalledOn = working && t.State == StateComeOut
+	// Final working status = system rules AND player preference
+	systemWorking := t.shouldBetBeWorking(bet, t.State)
+	bet.Working = systemWorking && bet.PlayerWorking
+}
+
+// ParlayBet turns parlaying on or off for a player's one-roll bets of a type.
+// A parlayed bet that wins stays up with its whole return riding on the next
+// roll, until it loses or parlaying is turned off.
+func (t *Table) ParlayBet(playerID, betType string, parlay bool) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	player, err := t.getPlayer(playerID)
+	if err != nil {
+		return fmt.Errorf("player %s not found", playerID)
+	}
+
+	if def, exists := CanonicalBetDefinitions[betType]; !exists || !def.OneRoll {
+		return fmt.Errorf("%s is not a one-roll bet and cannot be parlayed", betType)
+	}
+
+	parlayed := 0
+	for _, bet := range player.Bets {
+		if bet.Type == betType {
+			bet.Parlay = parlay
+			parlayed++
+		}
+	}
+
+	if parlayed == 0 {
+		return fmt.Errorf("no %s bets to parlay", betType)
+	}
+
+	return nil
+}
+
+// ParlayBetByID turns parlaying on or off for a single one-roll bet, leaving
+// the player's other bets of the same type as they are
+func (t *Table) ParlayBetByID(playerID, betID string, parlay bool) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	player, err := t.getPlayer(playerID)
+	if err != nil {
+		return fmt.Errorf("player %s not found", playerID)
+	}
+
+	for _, bet := range player.Bets {
+		if bet.ID != betID {
+			continue
+		}
+		if def, exists := CanonicalBetDefinitions[bet.Type]; !exists || !def.OneRoll {
+			return fmt.Errorf("%s is not a one-roll bet and cannot be parlayed", bet.Type)
+		}
+		bet.Parlay = parlay
+		return nil
+	}
+
+	return fmt.Errorf("bet %s not found", betID)
+}
+
+// KeepBetOnLoss turns keeping on or off for a player's one-roll bets of a
+// type. A kept bet that loses is put back up for the same amount, as long as
+// the bankroll covers it, instead of coming down.
+func (t *Table) KeepBetOnLoss(playerID, betType string, keep bool) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	player, err := t.getPlayer(playerID)
+	if err != nil {
+		return fmt.Errorf("player %s not found", playerID)
+	}
+
+	if def, exists := CanonicalBetDefinitions[betType]; !exists || !def.OneRoll {
+		return fmt.Errorf("%s is not a one-roll bet and cannot be kept up", betType)
+	}
+
+	kept := 0
+	for _, bet := range player.Bets {
+		if bet.Type == betType {
+			bet.KeepOnLoss = keep
+			kept++
+		}
+	}
+
+	if kept == 0 {
+		return fmt.Errorf("no %s bets to keep up", betType)
+	}
+
+	return nil
+}
+
+// KeepBetOnLossByID turns keeping on or off for a single one-roll bet,
+// leaving the player's other bets of the same type as they are
+func (t *Table) KeepBetOnLossByID(playerID, betID string, keep bool) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	player, err := t.getPlayer(playerID)
+	if err != nil {
+		return fmt.Errorf("player %s not found", playerID)
+	}
+
+	for _, bet := range player.Bets {
+		if bet.ID != betID {
+			continue
+		}
+		if def, exists := CanonicalBetDefinitions[bet.Type]; !exists || !def.OneRoll {
+			return fmt.Errorf("%s is not a one-roll bet and cannot be kept up", bet.Type)
+		}
+		bet.KeepOnLoss = keep
+		return nil
+	}
+
+	return fmt.Errorf("bet %s not found", betID)
+}
+
+// rebet puts a lost bet back up from the player's bankroll. The new wager
+// gets the same checks as any new bet; it reports false, leaving the bet to
+// come down, if one fails, e.g. the bankroll can't cover it or the player has
+// hit their loss limit.
+func (t *Table) rebet(player *Player, bet *Bet) bool {
+	// The lost wager is still on the layout until the roll is settled; it
+	// mustn't count toward the session limits
+	amount := bet.Amount
+	bet.Amount = 0
+	err := t.validateWager(player, bet.Type, amount)
+	bet.Amount = amount
+	if err != nil {
+		return false
+	}
+	player.Bankroll = subDollars(player.Bankroll, bet.Amount)
+	t.recordTransaction(player, bet, TransactionBet, bet.Amount)
+	return true
+}
+
 // rollDieSecure generates a secure random die roll (1-6)
 func rollDieSecure() int {
 	n, err := rand.Int(rand.Reader, big.NewInt(6))
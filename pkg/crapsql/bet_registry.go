@@ -69,6 +69,7 @@ func initRegistry() {
 	stringToBetType["ACE_DEUCE"] = BetAceDeuce
 	stringToBetType["ACES"] = BetAces
 	stringToBetType["BOXCARS"] = BetBoxcars
+	stringToBetType["COME_OUT_HARDWAYS"] = BetComeOutHardways
 
 	// Place bets
 	stringToBetType["PLACE_4"] = BetPlace4
@@ -114,7 +115,10 @@ func initRegistry() {
 
 	// Hop bets
 	stringToBetType["HOP"] = BetHop
+	stringToBetType["HOP_HARD_4"] = BetHopHard4
 	stringToBetType["HOP_HARD_6"] = BetHopHard6
+	stringToBetType["HOP_HARD_8"] = BetHopHard8
+	stringToBetType["HOP_HARD_10"] = BetHopHard10
 	stringToBetType["HOP_EASY_8"] = BetHopEasy8
 
 	// Horn bets
@@ -1,8 +1,12 @@
 package crapsql
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -333,6 +337,89 @@ func TestBasicTokenRecognition(t *testing.T) {
 	}
 }
 
+func TestEveryBetTypeIsAKeyword(t *testing.T) {
+	interpreter := NewInterpreter(crapsgame.NewTable(5, 1000, 3))
+	// Bet types that take their numbers in parentheses
+	args := map[string]string{"PLACE_NUMBERS": "(4, 10)"}
+
+	for _, betType := range crapsgame.GetAllBetTypes() {
+		lexer := NewLexer(betType)
+		token := lexer.NextToken()
+		if token.Type == IDENT || token.Literal != betType {
+			t.Errorf("%s: expected a bet type keyword, got %v %q", betType, token.Type, token.Literal)
+			continue
+		}
+		if next := lexer.NextToken(); next.Type != EOF {
+			t.Errorf("%s: expected a single token, then got %v %q", betType, next.Type, next.Literal)
+		}
+
+		// The keyword parses as the bet type it names
+		parser := NewParser(NewLexer("PLACE $5 ON " + betType + args[betType] + ";"))
+		program := parser.ParseProgram()
+		if errors := parser.Errors(); len(errors) > 0 {
+			t.Errorf("%s: failed to parse: %v", betType, errors)
+			continue
+		}
+		stmt, ok := program.Statements[0].(*BetStatement)
+		if !ok {
+			t.Errorf("%s: expected a bet statement, got %T", betType, program.Statements[0])
+			continue
+		}
+		if got := interpreter.betTypeToString(stmt.BetType.Type); got != betType {
+			t.Errorf("%s: parsed as %s", betType, got)
+		}
+		if !IsValidBetType(betType) {
+			t.Errorf("%s: missing from the bet type registry", betType)
+		}
+	}
+}
+
+func TestSpokenBetTypes(t *testing.T) {
+	testCases := []struct {
+		spoken     string
+		underscore string
+		expected   TokenType
+	}{
+		{"ACE DEUCE", "ACE_DEUCE", ACE_DEUCE},
+		{"HORN HIGH 11", "HORN_HIGH_11", HORN_HIGH_11},
+		{"HORN HIGH 2", "HORN_HIGH_2", HORN_HIGH_2},
+		{"HORN  HIGH\t12", "HORN_HIGH_12", HORN_HIGH_12},
+		{"HORN HIGH ACE DEUCE", "HORN_HIGH_ACE_DEUCE", HORN_HIGH_ACE_DEUCE},
+	}
+
+	for _, tc := range testCases {
+		for _, input := range []string{tc.spoken, tc.underscore} {
+			lexer := NewLexer("PLACE $5 ON " + input + ";")
+			tokens := lexer.Tokenize()
+			if len(tokens) != 7 || tokens[4].Type != tc.expected {
+				t.Errorf("%q: expected %v as one token, got %v", input, tc.expected, tokens)
+				continue
+			}
+			if tokens[4].Column != 13 || tokens[5].Type != SEMICOLON {
+				t.Errorf("%q: expected the bet at column 13 followed by ;, got %v", input, tokens)
+			}
+		}
+	}
+
+	// Words that don't finish a spoken bet type are left alone
+	tokens := NewLexer("PLACE $5 ON HORN WORKING;").Tokenize()
+	if tokens[4].Type != HORN || tokens[5].Type != WORKING_KEYWORD {
+		t.Errorf("Expected HORN then WORKING, got %v", tokens)
+	}
+	tokens = NewLexer("PLACE $5 ON HORN HIGH;").Tokenize()
+	if tokens[4].Type != HORN || tokens[5].Type != IDENT || tokens[5].Literal != "HIGH" {
+		t.Errorf("Expected HORN then HIGH, got %v", tokens)
+	}
+
+	// A spoken bet places like its keyword
+	table, players := setupTestGame(t)
+	if _, err := executeCrapsQLForPlayer(t, table, players[0], "PLACE $5 ON ACE DEUCE; PLACE $5 ON HORN HIGH 11;"); err != nil {
+		t.Fatalf("Failed to place spoken bets: %v", err)
+	}
+	verifyBetExists(t, table, players[0], "ACE_DEUCE", 5.0)
+	verifyBetExists(t, table, players[0], "HORN_HIGH_11", 5.0)
+}
+
 func TestNumberParsing(t *testing.T) {
 	// Test number parsing (integers, decimals)
 	input := "PLACE $25.50 ON FIELD"
@@ -668,6 +755,103 @@ func TestEOFHandling(t *testing.T) {
 	}
 }
 
+func TestTokenize(t *testing.T) {
+	input := "PLACE $25 ON PASS_LINE;\nROLL DICE;"
+	expected := []Token{
+		{Type: PLACE, Literal: "PLACE", Line: 1, Column: 1},
+		{Type: DOLLAR, Literal: "$", Line: 1, Column: 7},
+		{Type: NUMBER, Literal: "25", Line: 1, Column: 8},
+		{Type: ON, Literal: "ON", Line: 1, Column: 11},
+		{Type: PASS_LINE, Literal: "PASS_LINE", Line: 1, Column: 14},
+		{Type: SEMICOLON, Literal: ";", Line: 1, Column: 23},
+		{Type: ROLL, Literal: "ROLL", Line: 2, Column: 1},
+		{Type: DICE, Literal: "DICE", Line: 2, Column: 6},
+		{Type: SEMICOLON, Literal: ";", Line: 2, Column: 10},
+		{Type: EOF, Literal: "", Line: 2, Column: 11},
+	}
+
+	tokens := NewLexer(input).Tokenize()
+	if len(tokens) != len(expected) {
+		t.Fatalf("Expected %d tokens, got %d: %v", len(expected), len(tokens), tokens)
+	}
+	for i, exp := range expected {
+		if tokens[i] != exp {
+			t.Errorf("Token %d: expected %+v, got %+v", i, exp, tokens[i])
+		}
+	}
+
+	// Empty input still yields a single EOF token
+	tokens = NewLexer("").Tokenize()
+	if len(tokens) != 1 || tokens[0].Type != EOF {
+		t.Errorf("Expected only EOF for empty input, got %v", tokens)
+	}
+}
+
+func TestStringTokens(t *testing.T) {
+	tokens := NewLexer(`SET DICE "hard_ways";`).Tokenize()
+	if len(tokens) != 5 {
+		t.Fatalf("Expected 5 tokens, got %d: %v", len(tokens), tokens)
+	}
+	expected := Token{Type: STRING, Literal: "hard_ways", Line: 1, Column: 10}
+	if tokens[2] != expected {
+		t.Errorf("Expected %+v, got %+v", expected, tokens[2])
+	}
+	if tokens[3].Type != SEMICOLON {
+		t.Errorf("Expected SEMICOLON after string, got %+v", tokens[3])
+	}
+
+	// An unterminated string is illegal
+	tokens = NewLexer(`SET DICE "hard_ways;`).Tokenize()
+	if tokens[2].Type != ILLEGAL {
+		t.Errorf("Expected ILLEGAL for unterminated string, got %+v", tokens[2])
+	}
+}
+
+func TestComments(t *testing.T) {
+	input := "// opening bets\nPLACE $25 /* flat */ ON PASS_LINE; // line\n/* spans\ntwo lines */ ROLL DICE;"
+	expected := []Token{
+		{Type: PLACE, Literal: "PLACE", Line: 2, Column: 1},
+		{Type: DOLLAR, Literal: "$", Line: 2, Column: 7},
+		{Type: NUMBER, Literal: "25", Line: 2, Column: 8},
+		{Type: ON, Literal: "ON", Line: 2, Column: 22},
+		{Type: PASS_LINE, Literal: "PASS_LINE", Line: 2, Column: 25},
+		{Type: SEMICOLON, Literal: ";", Line: 2, Column: 34},
+		{Type: ROLL, Literal: "ROLL", Line: 4, Column: 14},
+		{Type: DICE, Literal: "DICE", Line: 4, Column: 19},
+		{Type: SEMICOLON, Literal: ";", Line: 4, Column: 23},
+		{Type: EOF, Literal: "", Line: 4, Column: 24},
+	}
+
+	tokens := NewLexer(input).Tokenize()
+	if len(tokens) != len(expected) {
+		t.Fatalf("Expected %d tokens, got %d: %v", len(expected), len(tokens), tokens)
+	}
+	for i, exp := range expected {
+		if tokens[i] != exp {
+			t.Errorf("Token %d: expected %+v, got %+v", i, exp, tokens[i])
+		}
+	}
+
+	// A single slash is still division
+	tokens = NewLexer("BANKROLL / 2").Tokenize()
+	if len(tokens) != 4 || tokens[1].Type != SLASH {
+		t.Errorf("Expected BANKROLL / 2 to keep its SLASH, got %v", tokens)
+	}
+
+	// An unterminated block comment is illegal and reported where it starts
+	tokens = NewLexer("PLACE $25 ON FIELD;\n  /* never closed").Tokenize()
+	illegal := tokens[len(tokens)-2]
+	if illegal.Type != ILLEGAL || illegal.Literal != "/* never closed" || illegal.Line != 2 || illegal.Column != 3 {
+		t.Errorf("Expected ILLEGAL unterminated comment at 2:3, got %+v", illegal)
+	}
+
+	// Comments are tolerated by the parser
+	program := NewParser(NewLexer("PLACE $25 ON PASS_LINE; // flat bet\nPLACE $10 ON FIELD;")).ParseProgram()
+	if len(program.Statements) != 2 {
+		t.Errorf("Expected 2 statements around a comment, got %d", len(program.Statements))
+	}
+}
+
 // ============================================================================
 // 3. Parser Tests
 // ============================================================================
@@ -792,7 +976,7 @@ func TestQueryStatementParsing(t *testing.T) {
 	}
 
 	// Test other query types
-	input2 := "SHOW BETS;"
+	input2 := "SHOW BET TYPES;"
 	lexer2 := NewLexer(input2)
 	parser2 := NewParser(lexer2)
 
@@ -802,8 +986,8 @@ func TestQueryStatementParsing(t *testing.T) {
 		t.Fatalf("Expected QueryStatement, got %T", program2.Statements[0])
 	}
 
-	if stmt2.Type != QueryBets {
-		t.Errorf("Expected query type QueryBets, got %v", stmt2.Type)
+	if stmt2.Type != QueryBetTypes {
+		t.Errorf("Expected query type QueryBetTypes, got %v", stmt2.Type)
 	}
 }
 
@@ -854,6 +1038,53 @@ func TestManagementStatementParsing(t *testing.T) {
 	}
 }
 
+func TestManagementAmountParsing(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected ManagementType
+		amount   float64
+	}{
+		{"SET BANKROLL $1000;", ManageBankroll, 1000},
+		{"SET MAX_BET $500;", ManageMaxBet, 500},
+		{"SET MIN_BET $10;", ManageMinBet, 10},
+		{"SET WIN_GOAL $2000;", ManageWinGoal, 2000},
+		{"SET LOSS_LIMIT $300;", ManageLossLimit, 300},
+		{"SET MAX_BET TO $250;", ManageMaxBet, 250},
+		{"SET BANKROLL = $1000;", ManageBankroll, 1000},
+		{"SET LOSS_LIMIT = $300.50;", ManageLossLimit, 300.50},
+	}
+
+	for _, tt := range tests {
+		parser := NewParser(NewLexer(tt.input))
+		program := parser.ParseProgram()
+		if len(parser.Errors()) > 0 {
+			t.Errorf("%s: unexpected parser errors: %v", tt.input, parser.Errors())
+			continue
+		}
+		if len(program.Statements) != 1 {
+			t.Errorf("%s: expected 1 statement, got %d", tt.input, len(program.Statements))
+			continue
+		}
+
+		stmt, ok := program.Statements[0].(*ManagementStatement)
+		if !ok {
+			t.Errorf("%s: expected ManagementStatement, got %T", tt.input, program.Statements[0])
+			continue
+		}
+		if stmt.Type != tt.expected {
+			t.Errorf("%s: expected type %v, got %v", tt.input, tt.expected, stmt.Type)
+		}
+		amount, ok := stmt.Value.(*AmountExpression)
+		if !ok {
+			t.Errorf("%s: expected AmountExpression, got %T", tt.input, stmt.Value)
+			continue
+		}
+		if amount.Value != tt.amount {
+			t.Errorf("%s: expected amount %.2f, got %.2f", tt.input, tt.amount, amount.Value)
+		}
+	}
+}
+
 func TestConditionalStatementParsing(t *testing.T) {
 	// Test conditional statement parsing
 	input := `IF BANKROLL > $500 THEN
@@ -904,6 +1135,126 @@ func TestConditionalStatementParsing(t *testing.T) {
 	}
 }
 
+func TestElseIfParsing(t *testing.T) {
+	input := `IF BANKROLL > 1000 THEN
+		PLACE $25 ON FIELD;
+		SHOW BANKROLL;
+	ELSE IF BANKROLL > 500 THEN
+		PLACE $10 ON FIELD;
+	ELSE
+		PLACE $5 ON FIELD;
+	END;
+	SHOW POINT;`
+	parser := NewParser(NewLexer(input))
+	program := parser.ParseProgram()
+	if len(parser.Errors()) > 0 {
+		t.Fatalf("Unexpected parser errors: %v", parser.Errors())
+	}
+	if len(program.Statements) != 2 {
+		t.Fatalf("Expected 2 statements, got %d", len(program.Statements))
+	}
+
+	outer := program.Statements[0].(*ConditionalStatement)
+	if len(outer.Consequence.Statements) != 2 {
+		t.Errorf("Expected 2 statements in the first branch, got %d", len(outer.Consequence.Statements))
+	}
+
+	// ELSE IF nests the next conditional as the alternative
+	if outer.Alternative == nil || len(outer.Alternative.Statements) != 1 {
+		t.Fatalf("Expected the ELSE IF as the only alternative statement, got %v", outer.Alternative)
+	}
+	inner, ok := outer.Alternative.Statements[0].(*ConditionalStatement)
+	if !ok {
+		t.Fatalf("Expected nested ConditionalStatement, got %T", outer.Alternative.Statements[0])
+	}
+	if len(inner.Consequence.Statements) != 1 || inner.Alternative == nil || len(inner.Alternative.Statements) != 1 {
+		t.Errorf("Expected one statement in each inner branch, got %v and %v", inner.Consequence, inner.Alternative)
+	}
+
+	for _, input := range []string{
+		"IF POINT THEN SHOW POINT; ELSE SHOW BANKROLL; ELSE SHOW BETS; END;",
+		"IF POINT THEN SHOW POINT; ELSE IF BANKROLL > 5 THEN SHOW BANKROLL;",
+	} {
+		parser := NewParser(NewLexer(input))
+		parser.ParseProgram()
+		if len(parser.Errors()) == 0 {
+			t.Errorf("Input %q: expected parser errors, got none", input)
+		}
+	}
+}
+
+func TestConditionExpressionParsing(t *testing.T) {
+	parser := NewParser(NewLexer("IF BANKROLL - $500 > PROFIT * 2 + 100 THEN SHOW POINT; END;"))
+	program := parser.ParseProgram()
+	if len(parser.Errors()) > 0 {
+		t.Fatalf("Unexpected parser errors: %v", parser.Errors())
+	}
+
+	stmt := program.Statements[0].(*ConditionalStatement)
+	comparison, ok := stmt.Condition.(*InfixExpression)
+	if !ok || comparison.Operator != ">" {
+		t.Fatalf("Expected '>' comparison, got %v", stmt.Condition)
+	}
+
+	left, ok := comparison.Left.(*InfixExpression)
+	if !ok || left.Operator != "-" {
+		t.Fatalf("Expected '-' on the left, got %v", comparison.Left)
+	}
+
+	// * binds tighter than +: (PROFIT * 2) + 100
+	right, ok := comparison.Right.(*InfixExpression)
+	if !ok || right.Operator != "+" {
+		t.Fatalf("Expected '+' on the right, got %v", comparison.Right)
+	}
+	product, ok := right.Left.(*InfixExpression)
+	if !ok || product.Operator != "*" {
+		t.Errorf("Expected PROFIT * 2 to group first, got %v", right.Left)
+	}
+}
+
+func TestWhileStatementParsing(t *testing.T) {
+	input := `WHILE BANKROLL > $500 DO
+		PLACE $25 ON FIELD;
+		IF POINT THEN ROLL DICE; END;
+		ROLL DICE;
+	END;
+	SHOW BANKROLL;`
+	parser := NewParser(NewLexer(input))
+	program := parser.ParseProgram()
+	if len(parser.Errors()) > 0 {
+		t.Fatalf("Unexpected parser errors: %v", parser.Errors())
+	}
+
+	if len(program.Statements) != 2 {
+		t.Fatalf("Expected 2 statements, got %d", len(program.Statements))
+	}
+
+	stmt, ok := program.Statements[0].(*WhileStatement)
+	if !ok {
+		t.Fatalf("Expected WhileStatement, got %T", program.Statements[0])
+	}
+	if infix, ok := stmt.Condition.(*InfixExpression); !ok || infix.Operator != ">" {
+		t.Errorf("Expected '>' condition, got %v", stmt.Condition)
+	}
+	if len(stmt.Body.Statements) != 3 {
+		t.Fatalf("Expected 3 statements in body, got %d", len(stmt.Body.Statements))
+	}
+	if _, ok := stmt.Body.Statements[1].(*ConditionalStatement); !ok {
+		t.Errorf("Expected nested ConditionalStatement, got %T", stmt.Body.Statements[1])
+	}
+
+	for _, input := range []string{
+		"WHILE BANKROLL > $500 ROLL DICE; END;",
+		"WHILE BANKROLL > $500 DO ROLL DICE;",
+	} {
+		parser := NewParser(NewLexer(input))
+		parser.ParseProgram()
+		if len(parser.Errors()) == 0 {
+			t.Errorf("Expected parser error for %q", input)
+		}
+	}
+}
+
 func TestRollStatementParsing(t *testing.T) {
 	// Test roll statement parsing
 	input := "ROLL DICE;"
@@ -925,6 +1276,26 @@ func TestRollStatementParsing(t *testing.T) {
 	if stmt.Token.Type != ROLL {
 		t.Errorf("Expected token type ROLL, got %v", stmt.Token.Type)
 	}
+	if stmt.UntilResolved {
+		t.Error("Expected ROLL DICE not to roll until resolved")
+	}
+
+	// ROLL UNTIL RESOLVED
+	parser = NewParser(NewLexer("ROLL UNTIL RESOLVED;"))
+	program = parser.ParseProgram()
+	if len(parser.Errors()) > 0 {
+		t.Fatalf("Unexpected parser errors: %v", parser.Errors())
+	}
+	stmt, ok = program.Statements[0].(*RollStatement)
+	if !ok || !stmt.UntilResolved {
+		t.Errorf("Expected RollStatement with UntilResolved, got %+v", program.Statements[0])
+	}
+
+	parser = NewParser(NewLexer("ROLL UNTIL DONE;"))
+	parser.ParseProgram()
+	if len(parser.Errors()) == 0 {
+		t.Error("Expected parser error for ROLL UNTIL DONE")
+	}
 }
 
 func TestTurnStatementParsing(t *testing.T) {
@@ -1030,6 +1401,101 @@ func TestErrorRecoveryMalformedStatements(t *testing.T) {
 	}
 }
 
+func TestParseErrorPositions(t *testing.T) {
+	input := "ROLL DICE;\nPLACE $10 ON PASS_LINE;\n    INVALID;"
+	parser := NewParser(NewLexer(input))
+	parser.ParseProgram()
+
+	parseErrors := parser.ParseErrors()
+	if len(parseErrors) == 0 {
+		t.Fatal("Expected a parse error for the statement on line 3")
+	}
+	first := parseErrors[0]
+	if first.Line != 3 || first.Column != 5 || first.Message != "unexpected token 'INVALID'" {
+		t.Errorf("Expected unexpected token 'INVALID' at line 3, column 5, got %q at line %d, column %d",
+			first.Message, first.Line, first.Column)
+	}
+	if errors := parser.Errors(); errors[0] != "line 3, col 5: unexpected token 'INVALID'" {
+		t.Errorf("Expected the string form to lead with the position, got %q", errors[0])
+	}
+
+	// A missing token is reported where the unexpected one sits
+	parser = NewParser(NewLexer("ROLL DICE;\n\nLET x 5;"))
+	parser.ParseProgram()
+	errors := parser.Errors()
+	if len(errors) == 0 || !strings.HasPrefix(errors[0], "line 3, col 7: ") {
+		t.Errorf("Expected an error at line 3, column 7, got %v", errors)
+	}
+}
+
+func TestNonPositiveAmountParsing(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"PLACE $0 ON PASS_LINE;", "line 1, col 7: bet amount must be positive"},
+		{"PLACE $-5 ON PASS_LINE;", "line 1, col 7: bet amount must be positive"},
+		{"PLACE $-25 ON PASS_LINE;", "line 1, col 7: bet amount must be positive"},
+		{"PLACE $-0.5 ON PASS_LINE;", "line 1, col 7: bet amount must be positive"},
+		{"ROLL DICE;\n  PLACE $0 ON FIELD;", "line 2, col 9: bet amount must be positive"},
+	}
+
+	for _, tc := range testCases {
+		parser := NewParser(NewLexer(tc.input))
+		parser.ParseProgram()
+
+		errors := parser.Errors()
+		if len(errors) != 1 {
+			t.Errorf("Input %q: expected 1 parser error, got %v", tc.input, errors)
+			continue
+		}
+		if errors[0] != tc.expected {
+			t.Errorf("Input %q: expected error %q, got %q", tc.input, tc.expected, errors[0])
+		}
+	}
+}
+
+func TestArithmeticAmountParsing(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"PLACE $10 + $5 * 2 ON PASS_LINE;", "(10 + (5 * 2))"},
+		{"PLACE ($10 + $5) * 2 ON PASS_LINE;", "((10 + 5) * 2)"},
+		{"PLACE $100 / 4 - $5 ON PASS_LINE;", "((100 / 4) - 5)"},
+		{"PLACE unit * 2 ON PASS_LINE;", "(unit * 2)"},
+	}
+
+	for _, tc := range testCases {
+		parser := NewParser(NewLexer(tc.input))
+		program := parser.ParseProgram()
+		if len(parser.Errors()) > 0 {
+			t.Errorf("Input %q: unexpected parser errors: %v", tc.input, parser.Errors())
+			continue
+		}
+		stmt := program.Statements[0].(*BetStatement)
+		if got := formatArithmetic(stmt.Amount.Expr); got != tc.expected {
+			t.Errorf("Input %q: expected %s, got %s", tc.input, tc.expected, got)
+		}
+	}
+}
+
+// formatArithmetic renders an amount expression with explicit grouping
+func formatArithmetic(expr Expression) string {
+	switch e := expr.(type) {
+	case *InfixExpression:
+		return fmt.Sprintf("(%s %s %s)", formatArithmetic(e.Left), e.Operator, formatArithmetic(e.Right))
+	case *AmountExpression:
+		return fmt.Sprintf("%g", e.Value)
+	case *NumberExpression:
+		return fmt.Sprintf("%g", e.Value)
+	case *IdentifierExpression:
+		return e.Value
+	default:
+		return fmt.Sprintf("%T", expr)
+	}
+}
+
 func TestMultipleStatementParsingSequence(t *testing.T) {
 	// Test multiple statement parsing sequence
 	input := `PLACE $25 ON PASS_LINE;
@@ -1389,7 +1855,12 @@ func TestCompletePlaceBetScenario(t *testing.T) {
 	verifyBetExists(t, table, playerID, "PLACE_6", 12.0) // Place bets stay on table after winning
 	verifyPlayerBankroll(t, table, playerID, 1027.0)     // 963 + 14 (place payout) + 50 (pass line bet+win)
 
-	// Step 5: Roll 7 (place bet loses)
+	// Step 5: Place bets are off on the come-out, so re-establish a point with a 5
+	simulateDiceRoll(t, table, 2, 3) // 5
+	verifyBetExists(t, table, playerID, "PLACE_6", 12.0)
+	verifyGameState(t, table, crapsgame.StatePoint, crapsgame.Point5)
+
+	// Step 6: Seven out (place bet loses)
 	simulateDiceRoll(t, table, 3, 4) // 7
 	verifyBetNotExists(t, table, playerID, "PLACE_6")
 	verifyPlayerBankroll(t, table, playerID, 1027.0) // No change - place bet loses but already on table
@@ -1533,6 +2004,259 @@ func TestShooterRotation(t *testing.T) {
 	verifyBetNotExists(t, table, players[1], "PASS_LINE")
 }
 
+func TestShooterRotationFollowsSeats(t *testing.T) {
+	table, players := setupTestGame(t)
+
+	// Each seven-out passes the dice to the next seat, wrapping around
+	expected := []string{players[1], players[2], players[0], players[1]}
+	for i, want := range expected {
+		simulateDiceRoll(t, table, 2, 2) // Point 4
+		simulateDiceRoll(t, table, 3, 4) // Seven out
+		if table.Shooter != want {
+			t.Fatalf("Seven-out %d: expected shooter %s, got %s", i+1, want, table.Shooter)
+		}
+	}
+
+	// A late arrival sits at the end of the rail, whatever their ID
+	if err := table.AddPlayer("player0", "Late Arrival", 1000.0); err != nil {
+		t.Fatalf("Failed to add player: %v", err)
+	}
+	simulateDiceRoll(t, table, 2, 2)
+	simulateDiceRoll(t, table, 3, 4) // player2 -> player3
+	simulateDiceRoll(t, table, 2, 2)
+	simulateDiceRoll(t, table, 3, 4) // player3 -> player0
+	if table.Shooter != "player0" {
+		t.Errorf("Expected shooter player0 after player3, got %s", table.Shooter)
+	}
+
+	// The shooter leaving passes the dice to the next seat
+	if err := table.RemovePlayer("player0"); err != nil {
+		t.Fatalf("Failed to remove player: %v", err)
+	}
+	if table.Shooter != players[0] {
+		t.Errorf("Expected shooter %s after player0 left, got %s", players[0], table.Shooter)
+	}
+}
+
+func TestPlaySequence(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON PASS_LINE; PLACE $12 ON PLACE_8;"); err != nil {
+		t.Fatalf("Failed to place bets: %v", err)
+	}
+
+	// Establish 6, roll 8, make the 6
+	rolls, err := table.PlaySequence([][2]int{{3, 3}, {4, 4}, {2, 4}})
+	if err != nil {
+		t.Fatalf("PlaySequence failed: %v", err)
+	}
+	if len(rolls) != 3 {
+		t.Fatalf("Expected results for 3 rolls, got %d", len(rolls))
+	}
+
+	// The place 8 is off on the come-out, so nothing is decided
+	if len(rolls[0]) != 0 {
+		t.Errorf("Roll 1: expected no decisions, got %v", rolls[0])
+	}
+
+	expected := [][]crapsgame.BetResult{
+		nil,
+		{{PlayerID: playerID, BetType: "PLACE_8", Outcome: crapsgame.OutcomeWin, Payout: 14.0}},
+		{{PlayerID: playerID, BetType: "PASS_LINE", Outcome: crapsgame.OutcomeWin, Payout: 10.0}},
+	}
+	for i := 1; i < len(expected); i++ {
+		if len(rolls[i]) != 1 {
+			t.Errorf("Roll %d: expected 1 decision, got %v", i+1, rolls[i])
+			continue
+		}
+		got, want := rolls[i][0], expected[i][0]
+		if got.PlayerID != want.PlayerID || got.BetType != want.BetType || got.Outcome != want.Outcome || got.Payout != want.Payout {
+			t.Errorf("Roll %d: expected %s %s $%.2f, got %s %s $%.2f", i+1,
+				want.BetType, want.Outcome, want.Payout, got.BetType, got.Outcome, got.Payout)
+		}
+		if got.BetID == "" || got.Message == "" {
+			t.Errorf("Roll %d: expected the bet ID and message to be set, got %+v", i+1, got)
+		}
+	}
+
+	verifyGameState(t, table, crapsgame.StateComeOut, crapsgame.PointOff)
+	verifyBetNotExists(t, table, playerID, "PASS_LINE")
+	verifyBetExists(t, table, playerID, "PLACE_8", 12.0)
+	verifyPlayerBankroll(t, table, playerID, 1000.0-22.0+14.0+20.0)
+	if history := table.GetRollHistory(); len(history) != 3 {
+		t.Errorf("Expected 3 rolls in the history, got %d", len(history))
+	}
+
+	// A bad die anywhere in the sequence rejects all of it
+	if _, err := table.PlaySequence([][2]int{{3, 3}, {7, 1}}); err == nil {
+		t.Error("Expected error for a die of 7, got nil")
+	}
+	verifyGameState(t, table, crapsgame.StateComeOut, crapsgame.PointOff)
+	if history := table.GetRollHistory(); len(history) != 3 {
+		t.Errorf("Expected no rolls played from an invalid sequence, got %d in the history", len(history))
+	}
+}
+
+func TestRollEntryPointsAgree(t *testing.T) {
+	rolls := [][2]int{{2, 2}, {3, 3}, {5, 3}, {1, 3}, {3, 4}, {4, 4}, {2, 5}}
+
+	// play runs the same session through one way of rolling and returns the
+	// player's bankroll and the table state afterwards
+	play := func(roll func(table *crapsgame.Table, dice [2]int)) (float64, crapsgame.GameState) {
+		table, players := setupTestGame(t)
+		playerID := players[0]
+		table.SetDiceSource(newScriptedDice(rolls...))
+
+		for n, dice := range rolls {
+			var statement string
+			switch n {
+			case 0:
+				statement = "PLACE $10 ON PASS_LINE; PLACE $10 ON HARD_8;"
+			case 1:
+				statement = "PLACE $10 ON COME; PLACE $12 ON PLACE_6; PLACE $5 ON FIELD;"
+			case 4:
+				statement = "PLACE $10 ON DONT_PASS;"
+			}
+			if statement != "" {
+				if _, err := executeCrapsQLForPlayer(t, table, playerID, statement); err != nil {
+					t.Fatalf("Roll %d: failed to place bets: %v", n+1, err)
+				}
+			}
+			roll(table, dice)
+		}
+
+		player, _ := table.GetPlayer(playerID)
+		return player.Bankroll, table.State
+	}
+
+	entryPoints := map[string]func(table *crapsgame.Table, dice [2]int){
+		"ExecuteGameTurn":    func(table *crapsgame.Table, _ [2]int) { table.ExecuteGameTurn() },
+		"RollDiceAndResolve": func(table *crapsgame.Table, _ [2]int) { table.RollDiceAndResolve() },
+		"PlaySequence": func(table *crapsgame.Table, dice [2]int) {
+			playSequence(t, table, [][2]int{dice})
+		},
+		"ResolveAllBets+UpdateGameState": func(table *crapsgame.Table, dice [2]int) {
+			simulateDiceRoll(t, table, dice[0], dice[1])
+		},
+	}
+
+	wantBankroll, wantState := play(entryPoints["RollDiceAndResolve"])
+	t.Logf("Session ends with $%.2f in %v", wantBankroll, wantState)
+	for name, roll := range entryPoints {
+		bankroll, state := play(roll)
+		if bankroll != wantBankroll || state != wantState {
+			t.Errorf("%s: expected bankroll $%.2f in %v, got $%.2f in %v", name, wantBankroll, wantState, bankroll, state)
+		}
+	}
+}
+
+func TestMaxRollsPerShooter(t *testing.T) {
+	table, players := setupTestGame(t)
+	table.MaxRollsPerShooter = 3
+
+	// Dice that never seven out: point 6, then 8 and 4 forever
+	table.SetDiceSource(newScriptedDice([2]int{3, 3}, [2]int{4, 4}, [2]int{2, 2}))
+
+	for roll := 1; roll <= 2; roll++ {
+		table.RollDiceAndResolve()
+		if table.Shooter != players[0] {
+			t.Fatalf("Roll %d: expected shooter %s, got %s", roll, players[0], table.Shooter)
+		}
+	}
+	if len(table.Events) != 0 {
+		t.Fatalf("Expected no events before the limit, got %v", table.Events)
+	}
+
+	// Third roll hits the limit and passes the dice
+	table.RollDiceAndResolve()
+	if table.Shooter != players[1] {
+		t.Errorf("Expected forced shooter change to %s, got %s", players[1], table.Shooter)
+	}
+	if table.ShooterRolls != 0 {
+		t.Errorf("Expected new shooter's roll count to reset, got %d", table.ShooterRolls)
+	}
+	if len(table.Events) != 1 || table.Events[0].Type != crapsgame.EventShooterChange {
+		t.Fatalf("Expected one SHOOTER_CHANGE event, got %v", table.Events)
+	}
+
+	// The point carries over to the new shooter
+	verifyGameState(t, table, crapsgame.StatePoint, crapsgame.Point6)
+
+	// A normal seven-out is not a forced change
+	table.SetDiceSource(newScriptedDice([2]int{3, 4}))
+	table.RollDiceAndResolve()
+	if table.Shooter != players[2] {
+		t.Errorf("Expected shooter %s after seven out, got %s", players[2], table.Shooter)
+	}
+	if len(table.Events) != 1 {
+		t.Errorf("Expected seven out not to record an event, got %d events", len(table.Events))
+	}
+}
+
+func TestSeededDiceSource(t *testing.T) {
+	rollSequence := func(seed int64) [][2]int {
+		table, _ := setupTestGame(t)
+		table.SetDiceSource(crapsgame.NewSeededDiceSource(seed))
+		var rolls [][2]int
+		for i := 0; i < 50; i++ {
+			roll, _ := table.RollDiceAndResolve()
+			if roll.Die1 < 1 || roll.Die1 > 6 || roll.Die2 < 1 || roll.Die2 > 6 {
+				t.Fatalf("Roll %d out of range: %d-%d", i+1, roll.Die1, roll.Die2)
+			}
+			rolls = append(rolls, [2]int{roll.Die1, roll.Die2})
+		}
+		return rolls
+	}
+
+	first := rollSequence(42)
+	if second := rollSequence(42); !reflect.DeepEqual(first, second) {
+		t.Errorf("Expected the same seed to produce the same rolls:\n%v\n%v", first, second)
+	}
+	if other := rollSequence(7); reflect.DeepEqual(first, other) {
+		t.Errorf("Expected different seeds to produce different rolls")
+	}
+}
+
+func TestLastDecision(t *testing.T) {
+	table, _ := setupTestGame(t)
+
+	if decision := table.LastDecision(); decision != crapsgame.DecisionNeutral {
+		t.Errorf("Expected %s before any roll, got %s", crapsgame.DecisionNeutral, decision)
+	}
+
+	rolls := []struct {
+		die1, die2 int
+		expected   string
+	}{
+		{3, 4, crapsgame.DecisionNatural},
+		{5, 6, crapsgame.DecisionNatural},
+		{1, 1, crapsgame.DecisionCraps},
+		{6, 6, crapsgame.DecisionCraps},
+		{2, 3, crapsgame.DecisionPointEstablished},
+		{4, 4, crapsgame.DecisionNeutral},
+		{1, 4, crapsgame.DecisionPointMade},
+		{5, 5, crapsgame.DecisionPointEstablished},
+		{1, 2, crapsgame.DecisionNeutral},
+		{1, 6, crapsgame.DecisionSevenOut},
+	}
+
+	for _, roll := range rolls {
+		simulateDiceRoll(t, table, roll.die1, roll.die2)
+		if decision := table.LastDecision(); decision != roll.expected {
+			t.Errorf("Roll %d-%d: expected %s, got %s", roll.die1, roll.die2, roll.expected, decision)
+		}
+	}
+
+	// The roll-and-resolve path labels rolls the same way
+	table.SetDiceSource(newScriptedDice([2]int{2, 2}, [2]int{2, 2}))
+	table.RollDiceAndResolve()
+	table.RollDiceAndResolve()
+	if decision := table.LastDecision(); decision != crapsgame.DecisionPointMade {
+		t.Errorf("Expected %s after making the point, got %s", crapsgame.DecisionPointMade, decision)
+	}
+}
+
 // 6.3 Bet Resolution and Payout Tests
 func TestPassLineBetResolution(t *testing.T) {
 	table, players := setupTestGame(t)
@@ -1668,25 +2392,65 @@ func TestFieldBetPayouts(t *testing.T) {
 	verifyPlayerBankroll(t, table, playerID, 1050.0)
 }
 
-func TestPlaceBetPayouts(t *testing.T) {
+func TestConfigurableFieldPayouts(t *testing.T) {
 	table, players := setupTestGame(t)
 	playerID := players[0]
 
-	// Establish point first
-	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_LINE;")
-	if err != nil {
-		t.Fatalf("Failed to place pass line bet: %v", err)
+	if got := table.FieldPayouts(); !reflect.DeepEqual(got, map[int]float64{2: 2, 12: 3}) {
+		t.Errorf("Expected the default field to pay 2:1 on 2 and 3:1 on 12, got %v", got)
 	}
 
-	simulateDiceRoll(t, table, 3, 3) // 6
-	verifyGameState(t, table, crapsgame.StatePoint, crapsgame.Point6)
-
-	// Place bet on 6
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $12 ON PLACE_6;")
-	if err != nil {
-		t.Fatalf("Failed to place bet on 6: %v", err)
+	// Both 2 and 12 pay 2:1
+	if err := table.SetFieldPayouts(map[int]float64{2: 2, 12: 2}); err != nil {
+		t.Fatalf("SetFieldPayouts failed: %v", err)
 	}
-
+	if got := table.FieldPayouts(); !reflect.DeepEqual(got, map[int]float64{2: 2, 12: 2}) {
+		t.Errorf("Expected the field to pay 2:1 on 2 and 12, got %v", got)
+	}
+	if got := table.PayTable["FIELD"].SpecialPayouts; !reflect.DeepEqual(got, map[int]float64{2: 2, 12: 2}) {
+		t.Errorf("Expected the FIELD pay table entry to pay 2:1 on 2 and 12, got %v", got)
+	}
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON FIELD;"); err != nil {
+		t.Fatalf("Failed to place field bet: %v", err)
+	}
+	simulateDiceRoll(t, table, 6, 6) // 12
+	verifyBetNotExists(t, table, playerID, "FIELD")
+	verifyPlayerBankroll(t, table, playerID, 990.0+30.0)
+
+	// The other totals still pay even money
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON FIELD;"); err != nil {
+		t.Fatalf("Failed to place field bet: %v", err)
+	}
+	simulateDiceRoll(t, table, 2, 2) // 4
+	verifyPlayerBankroll(t, table, playerID, 1020.0+10.0)
+
+	if err := table.SetFieldPayouts(map[int]float64{7: 2}); err == nil {
+		t.Error("Expected error for a field payout on 7, got nil")
+	}
+	if err := table.SetFieldPayouts(map[int]float64{12: 0}); err == nil {
+		t.Error("Expected error for a zero field payout, got nil")
+	}
+}
+
+func TestPlaceBetPayouts(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	// Establish point first
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_LINE;")
+	if err != nil {
+		t.Fatalf("Failed to place pass line bet: %v", err)
+	}
+
+	simulateDiceRoll(t, table, 3, 3) // 6
+	verifyGameState(t, table, crapsgame.StatePoint, crapsgame.Point6)
+
+	// Place bet on 6
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $12 ON PLACE_6;")
+	if err != nil {
+		t.Fatalf("Failed to place bet on 6: %v", err)
+	}
+
 	initialBankroll := 963.0 // 1000 - 25 - 12
 
 	// Test 1: Hit the place number (6) - pays 7:6, bet stays on table
@@ -1695,1397 +2459,5212 @@ func TestPlaceBetPayouts(t *testing.T) {
 	// Should win 14 (12 * 7:6 payout only, bet stays)
 	verifyPlayerBankroll(t, table, playerID, initialBankroll+14.0+50.0) // +14 place payout, +50 pass line win
 
-	// Test 2: Seven out - place bet loses
+	// Test 2: Place bet is off on the come-out; re-establish a point, then seven out
+	simulateDiceRoll(t, table, 4, 5) // 9
+	verifyBetExists(t, table, playerID, "PLACE_6", 12.0)
 	simulateDiceRoll(t, table, 3, 4) // 7
 	verifyBetNotExists(t, table, playerID, "PLACE_6")
 	// Bankroll should not change (bet was lost, but we already won from before)
 	verifyPlayerBankroll(t, table, playerID, initialBankroll+14.0+50.0)
 }
 
-// 6.4 Odds and Modifiers Tests
-func TestPassLineWithOdds(t *testing.T) {
+func TestPlaceBetOnThePoint(t *testing.T) {
 	table, players := setupTestGame(t)
 	playerID := players[0]
 
-	// Step 1: Place pass line bet
-	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_LINE;")
-	if err != nil {
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON PASS_LINE;"); err != nil {
 		t.Fatalf("Failed to place pass line bet: %v", err)
 	}
+	simulateDiceRoll(t, table, 4, 2) // Point 6
 
-	// Step 2: Establish point (6)
-	simulateDiceRoll(t, table, 3, 3) // 6
-	verifyGameState(t, table, crapsgame.StatePoint, crapsgame.Point6)
-
-	// Step 3: Add odds bet (3X max = $75)
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $75 ON PASS_ODDS;")
-	if err != nil {
-		t.Fatalf("Failed to place odds bet: %v", err)
+	// A place bet on the point number is allowed alongside the line and its odds
+	for _, statement := range []string{"PLACE $20 ON PASS_ODDS;", "PLACE $12 ON PLACE_6;"} {
+		if _, err := executeCrapsQLForPlayer(t, table, playerID, statement); err != nil {
+			t.Fatalf("Failed to execute %q: %v", statement, err)
+		}
 	}
+	verifyPlayerBankroll(t, table, playerID, 958.0)
 
-	verifyBetExists(t, table, playerID, "PASS_ODDS", 75.0)
-	verifyPlayerBankroll(t, table, playerID, 900.0) // 1000 - 25 - 75
-
-	// Step 4: Point hits - verify odds payout (true odds for 6 = 6:5)
-	simulateDiceRoll(t, table, 2, 4) // 6
-	verifyGameState(t, table, crapsgame.StateComeOut, crapsgame.PointOff)
+	// Making the point pays each bet at its own odds
+	_, results := simulateDiceRoll(t, table, 3, 3)
+	expected := map[string]bool{
+		"🎉 PASS_LINE wins $20.00 (bet: $10.00 + payout: $10.00)": true,
+		"🎉 PASS_ODDS wins $44.00 (bet: $20.00 + payout: $24.00)": true,
+		"🎉 PLACE_6 wins $14.00 (payout only)":                    true,
+	}
+	if len(results) != len(expected) {
+		t.Fatalf("Expected %d results, got %q", len(expected), results)
+	}
+	for _, result := range results {
+		if !expected[result] {
+			t.Errorf("Unexpected result %q", result)
+		}
+	}
 	verifyBetNotExists(t, table, playerID, "PASS_LINE")
 	verifyBetNotExists(t, table, playerID, "PASS_ODDS")
+	verifyBetExists(t, table, playerID, "PLACE_6", 12.0)
+	verifyPlayerBankroll(t, table, playerID, 1036.0) // 958 + 20 + 44 + 14
 
-	// Pass line wins 1:1 (25 + 25 = 50), odds win 6:5 (75 + 90 = 165)
-	// Total: 900 + 50 + 165 = 1115
-	verifyPlayerBankroll(t, table, playerID, 1115.0)
+	// The place bet stays up (off for the come-out) after the line is decided
+	simulateDiceRoll(t, table, 5, 1) // New point 6; the place bet is off
+	verifyBetExists(t, table, playerID, "PLACE_6", 12.0)
+	verifyPlayerBankroll(t, table, playerID, 1036.0)
+}
 
-	// Test 2: Seven out - odds bet loses
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_LINE;")
-	if err != nil {
-		t.Fatalf("Failed to place second pass line bet: %v", err)
+func TestDiceCombinations(t *testing.T) {
+	testCases := []struct {
+		total    int
+		expected [][2]int
+	}{
+		{2, [][2]int{{1, 1}}},
+		{7, [][2]int{{1, 6}, {2, 5}, {3, 4}, {4, 3}, {5, 2}, {6, 1}}},
+		{8, [][2]int{{2, 6}, {3, 5}, {4, 4}, {5, 3}, {6, 2}}},
 	}
 
-	simulateDiceRoll(t, table, 3, 3) // 6
-	verifyGameState(t, table, crapsgame.StatePoint, crapsgame.Point6)
+	for _, tc := range testCases {
+		combos := crapsgame.Combinations(tc.total)
+		if len(combos) != len(tc.expected) {
+			t.Errorf("Total %d: expected %v, got %v", tc.total, tc.expected, combos)
+			continue
+		}
+		for i, combo := range combos {
+			if combo != tc.expected[i] {
+				t.Errorf("Total %d: expected %v, got %v", tc.total, tc.expected, combos)
+				break
+			}
+		}
+	}
 
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $75 ON PASS_ODDS;")
-	if err != nil {
-		t.Fatalf("Failed to place second odds bet: %v", err)
+	if combos := crapsgame.Combinations(13); len(combos) != 0 {
+		t.Errorf("Expected no combinations for 13, got %v", combos)
 	}
+}
 
-	// Seven out
-	simulateDiceRoll(t, table, 3, 4) // 7
-	verifyGameState(t, table, crapsgame.StateComeOut, crapsgame.PointOff)
-	verifyBetNotExists(t, table, playerID, "PASS_LINE")
-	verifyBetNotExists(t, table, playerID, "PASS_ODDS")
+func TestWaysToRoll(t *testing.T) {
+	expected := map[int]int{2: 1, 3: 2, 4: 3, 5: 4, 6: 5, 7: 6, 8: 5, 9: 4, 10: 3, 11: 2, 12: 1}
 
-	// Both bets lose - bankroll should be 1115 - 25 - 75 = 1015
-	verifyPlayerBankroll(t, table, playerID, 1015.0)
+	totalWays := 0
+	for total := 2; total <= 12; total++ {
+		ways := crapsgame.WaysToRoll(total)
+		if ways != expected[total] {
+			t.Errorf("Total %d: expected %d ways, got %d", total, expected[total], ways)
+		}
+		totalWays += ways
+	}
+	if totalWays != 36 {
+		t.Errorf("Expected ways to sum to 36, got %d", totalWays)
+	}
+	if crapsgame.WaysToRoll(1) != 0 || crapsgame.WaysToRoll(13) != 0 {
+		t.Error("Expected no ways to roll totals outside 2-12")
+	}
+
+	table, _ := setupTestGame(t)
+	results, err := executeCrapsQL(t, table, "SHOW WAYS 7;")
+	if err != nil {
+		t.Fatalf("SHOW WAYS failed: %v", err)
+	}
+	if len(results) != 1 || results[0] != "Ways to roll 7: 6 of 36 (16.67%)" {
+		t.Errorf("Unexpected SHOW WAYS output: %v", results)
+	}
+	if _, err := executeCrapsQL(t, table, "SHOW WAYS 13;"); err == nil {
+		t.Error("Expected error for SHOW WAYS 13, got nil")
+	}
 }
 
-func TestWorkingVsNonWorkingBets(t *testing.T) {
+func TestHardwayOffOnComeOut(t *testing.T) {
 	table, players := setupTestGame(t)
 	playerID := players[0]
 
-	// SIMPLIFIED TEST: Focus on core craps behavior - field bets are always working (one-roll)
-	// Advanced WORKING/TURN syntax is not implemented yet (parser limitation)
-
-	// Step 1: Place field bet
-	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON FIELD;")
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON HARD_8;")
 	if err != nil {
-		t.Fatalf("Failed to place field bet: %v", err)
+		t.Fatalf("Failed to place hard 8: %v", err)
 	}
 
-	verifyBetExists(t, table, playerID, "FIELD", 10.0)
-	verifyPlayerBankroll(t, table, playerID, 990.0) // 1000 - 10
-
-	// Step 2: Roll dice - field bet should be resolved (one-roll bet)
-	simulateDiceRoll(t, table, 1, 1) // 2 (field wins 2:1)
-
-	// Field bet should be resolved and removed (won)
-	verifyBetNotExists(t, table, playerID, "FIELD")
-
-	// Bankroll: 990 + 10 (bet) + 20 (2:1 payout) = 1020
-	verifyPlayerBankroll(t, table, playerID, 1020.0)
+	// A hard 8 on the come-out neither wins nor loses the off hard 8
+	_, results := simulateDiceRoll(t, table, 4, 4)
+	for _, result := range results {
+		if strings.Contains(result, "HARD_8") {
+			t.Errorf("Expected no HARD_8 resolution on the come-out, got %q", result)
+		}
+	}
+	verifyBetExists(t, table, playerID, "HARD_8", 10.0)
+	verifyPlayerBankroll(t, table, playerID, 990.0)
+	verifyGameState(t, table, crapsgame.StatePoint, crapsgame.Point8)
 
-	t.Logf("✅ Core field bet working behavior verified")
-	t.Logf("⚠️ Advanced WORKING/TURN syntax not implemented yet")
+	// Once the point is on, the hard 8 works
+	simulateDiceRoll(t, table, 4, 4)
+	verifyPlayerBankroll(t, table, playerID, 990.0+90.0)
 }
 
-// 6.5 Bankroll and Limits Tests
-func TestBankrollManagement(t *testing.T) {
+func TestHardwayCalledWorkingOnComeOut(t *testing.T) {
 	table, players := setupTestGame(t)
 	playerID := players[0]
 
-	// Test 1: Bet exceeding bankroll
-	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $2000 ON PASS_LINE;")
-	if err == nil {
-		t.Error("Expected error when betting more than bankroll, got nil")
-	}
-
-	// Verify bankroll unchanged
-	verifyPlayerBankroll(t, table, playerID, 1000.0)
-
-	// Test 2: Multiple bets totaling more than bankroll
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $600 ON PASS_LINE;")
+	results, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON HARD_8 WORKING;")
 	if err != nil {
-		t.Fatalf("Failed to place first bet: %v", err)
+		t.Fatalf("Failed to place hard 8: %v", err)
 	}
-
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $600 ON FIELD;")
-	if err == nil {
-		t.Error("Expected error when second bet would exceed bankroll, got nil")
+	if len(results) != 1 || !strings.Contains(results[0], "HARD_8 is working") {
+		t.Errorf("Expected the hard 8 to be called working, got %v", results)
 	}
 
-	// Verify only first bet was placed
-	verifyBetExists(t, table, playerID, "PASS_LINE", 600.0)
-	verifyBetNotExists(t, table, playerID, "FIELD")
-	verifyPlayerBankroll(t, table, playerID, 400.0) // 1000 - 600
-
-	// Test 3: Win/lose scenarios
-	// Win the pass line bet
-	simulateDiceRoll(t, table, 3, 4) // 7
-	verifyBetNotExists(t, table, playerID, "PASS_LINE")
-	verifyPlayerBankroll(t, table, playerID, 1600.0) // 400 + 600 (bet returned) + 600 (win)
+	// Called working, the hard 8 wins 9:1 on the come-out and stays up
+	simulateDiceRoll(t, table, 4, 4)
+	verifyBetExists(t, table, playerID, "HARD_8", 10.0)
+	verifyPlayerBankroll(t, table, playerID, 990.0+90.0)
 
-	// Test 4: Lose a bet
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $100 ON PASS_LINE;")
-	if err != nil {
-		t.Fatalf("Failed to place bet for loss test: %v", err)
+	// OFF keeps a bet down even once the point is on
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $12 ON PLACE_6 OFF;"); err != nil {
+		t.Fatalf("Failed to place 6: %v", err)
 	}
-
-	simulateDiceRoll(t, table, 1, 1) // 2 (craps)
-	verifyBetNotExists(t, table, playerID, "PASS_LINE")
-	verifyPlayerBankroll(t, table, playerID, 1500.0) // 1600 - 100 (bet lost)
+	simulateDiceRoll(t, table, 3, 3)
+	verifyBetExists(t, table, playerID, "PLACE_6", 12.0)
+	verifyPlayerBankroll(t, table, playerID, 1080.0-12.0)
 }
 
-func TestBetLimitsEnforcement(t *testing.T) {
+func TestPlaceBetOffOnComeOut(t *testing.T) {
 	table, players := setupTestGame(t)
 	playerID := players[0]
 
-	// Test 1: Bet below minimum
-	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $1 ON PASS_LINE;")
-	if err == nil {
-		t.Error("Expected error when betting below minimum, got nil")
-	} else {
-		t.Logf("✅ Below minimum correctly rejected: %v", err)
+	results, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $12 ON PLACE_6;")
+	if err != nil {
+		t.Fatalf("Failed to place 6: %v", err)
 	}
-
-	// Test 2: Bet above maximum
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $2000 ON PASS_LINE;")
-	if err == nil {
-		t.Error("Expected error when betting above maximum, got nil")
-	} else {
-		t.Logf("✅ Above maximum correctly rejected: %v", err)
+	if len(results) != 1 || !strings.Contains(results[0], "PLACE_6 placed but OFF until point established") {
+		t.Errorf("Expected the place 6 to be reported off, got %v", results)
 	}
 
-	// Test 3: Valid bet within limits
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_LINE;")
+	// A come-out 4 sets the point without touching the place bet
+	simulateDiceRoll(t, table, 2, 2)
+	verifyGameState(t, table, crapsgame.StatePoint, crapsgame.Point4)
+	verifyBetExists(t, table, playerID, "PLACE_6", 12.0)
+	verifyPlayerBankroll(t, table, playerID, 988.0)
+
+	// With the point on, the place 6 works and wins 7:6
+	simulateDiceRoll(t, table, 4, 2)
+	verifyBetExists(t, table, playerID, "PLACE_6", 12.0)
+	verifyPlayerBankroll(t, table, playerID, 988.0+14.0)
+
+	// Placed once the point is on, it works right away
+	results, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON PLACE_5;")
 	if err != nil {
-		t.Fatalf("Failed to place valid bet: %v", err)
-	} else {
-		t.Logf("✅ Valid bet correctly accepted")
+		t.Fatalf("Failed to place 5: %v", err)
+	}
+	if len(results) != 1 || strings.Contains(results[0], "OFF") {
+		t.Errorf("Expected the place 5 to be working, got %v", results)
 	}
-
-	// SKIP TEST 4 - SET MAX_BET is not implemented yet (parser issue)
-	t.Logf("⚠️ Skipping player-specific limits test - SET MAX_BET parser not implemented")
 }
 
-// 6.6 Multiple Player Scenarios
-func TestMultiplePlayerGameplay(t *testing.T) {
+func TestHardwaysAndHopsNameTheFaces(t *testing.T) {
 	table, players := setupTestGame(t)
+	playerID := players[0]
 
-	// Each player places different types of bets
-	_, err := executeCrapsQLForPlayer(t, table, players[0], "PLACE $25 ON PASS_LINE;")
+	simulateDiceRoll(t, table, 2, 2) // point is 4, so the hard 8 works
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON HARD_8;")
 	if err != nil {
-		t.Fatalf("Failed to place bet for player 1: %v", err)
+		t.Fatalf("Failed to place hard 8: %v", err)
+	}
+	for _, hop := range []string{"HOP_1_5", "HOP_HARD_6"} {
+		if _, err := table.PlaceBet(playerID, hop, 5.0, nil); err != nil {
+			t.Fatalf("Failed to place %s: %v", hop, err)
+		}
 	}
 
-	_, err = executeCrapsQLForPlayer(t, table, players[1], "PLACE $20 ON DONT_PASS;")
-	if err != nil {
-		t.Fatalf("Failed to place bet for player 2: %v", err)
+	messages := func(results []crapsgame.BetResult) string {
+		var lines []string
+		for _, result := range results {
+			lines = append(lines, result.Message)
+		}
+		return strings.Join(lines, "\n")
 	}
 
-	_, err = executeCrapsQLForPlayer(t, table, players[2], "PLACE $15 ON FIELD;")
-	if err != nil {
-		t.Fatalf("Failed to place bet for player 3: %v", err)
+	// 2-4 is a 6, but neither 1-5 nor 3-3
+	output := messages(playSequence(t, table, [][2]int{{2, 4}})[0])
+	for _, expected := range []string{"HOP_1_5 loses $5.00 — Easy 6 (2-4)", "HOP_HARD_6 loses $5.00 — Easy 6 (2-4)"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected %q, got %q", expected, output)
+		}
 	}
 
-	// Verify all bets were placed
-	verifyBetExists(t, table, players[0], "PASS_LINE", 25.0)
-	verifyBetExists(t, table, players[1], "DONT_PASS", 20.0)
-	verifyBetExists(t, table, players[2], "FIELD", 15.0)
+	output = messages(playSequence(t, table, [][2]int{{4, 4}})[0])
+	if !strings.Contains(output, "HARD_8 wins $90.00 (payout only) — Hard 8 (4-4)") {
+		t.Errorf("Expected the hard 8 to win on 4-4, got %q", output)
+	}
+	verifyBetExists(t, table, playerID, "HARD_8", 10.0)
 
-	// Verify bankrolls were deducted
-	verifyPlayerBankroll(t, table, players[0], 975.0) // 1000 - 25
-	verifyPlayerBankroll(t, table, players[1], 980.0) // 1000 - 20
-	verifyPlayerBankroll(t, table, players[2], 985.0) // 1000 - 15
+	output = messages(playSequence(t, table, [][2]int{{5, 3}})[0])
+	if !strings.Contains(output, "HARD_8 loses $10.00 — Easy 8 (3-5)") {
+		t.Errorf("Expected the hard 8 to lose on 3-5, got %q", output)
+	}
+	verifyBetNotExists(t, table, playerID, "HARD_8")
+	verifyPlayerBankroll(t, table, playerID, 1000.0-10.0-5.0-5.0+90.0)
+}
 
-	// Roll dice and verify bet resolution
-	simulateDiceRoll(t, table, 3, 4) // 7
+func TestShowVig(t *testing.T) {
+	testCases := []struct {
+		betType  string
+		fairNum  int
+		fairDen  int
+		gap      float64
+		expected string
+	}{
+		{"PLACE_6", 6, 5, 1.2 - 7.0/6.0, "PLACE_6: fair 6:5, actual 7:6, gap $0.03 per $1, house take 1.52%"},
+		{"HARD_8", 10, 1, 1.0, "HARD_8: fair 10:1, actual 9:1, gap $1.00 per $1, house take 9.09%"},
+	}
 
-	// Verify all bets were resolved
-	verifyBetNotExists(t, table, players[0], "PASS_LINE")
-	verifyBetNotExists(t, table, players[1], "DONT_PASS")
-	verifyBetNotExists(t, table, players[2], "FIELD")
+	table, _ := setupTestGame(t)
+	for _, tc := range testCases {
+		num, den, err := crapsgame.FairOdds(tc.betType)
+		if err != nil {
+			t.Fatalf("FairOdds(%s) failed: %v", tc.betType, err)
+		}
+		if num != tc.fairNum || den != tc.fairDen {
+			t.Errorf("%s: expected fair odds %d:%d, got %d:%d", tc.betType, tc.fairNum, tc.fairDen, num, den)
+		}
 
-	// Verify bankroll updates (pass line wins, don't pass loses, field loses)
-	verifyPlayerBankroll(t, table, players[0], 1025.0) // 975 + 25 (bet returned) + 25 (win)
-	verifyPlayerBankroll(t, table, players[1], 980.0)  // 980 - 20 (bet lost)
-	verifyPlayerBankroll(t, table, players[2], 985.0)  // 985 - 15 (bet lost)
-}
+		fair, _ := crapsgame.FairPayout(tc.betType)
+		actual, _ := crapsgame.ActualPayout(tc.betType)
+		if diff := fair - actual - tc.gap; diff > 1e-9 || diff < -1e-9 {
+			t.Errorf("%s: expected payout gap %.4f, got %.4f", tc.betType, tc.gap, fair-actual)
+		}
 
-func TestConcurrentBetPlacement(t *testing.T) {
-	table, players := setupTestGame(t)
+		results, err := executeCrapsQL(t, table, fmt.Sprintf("SHOW VIG %s;", tc.betType))
+		if err != nil {
+			t.Fatalf("SHOW VIG %s failed: %v", tc.betType, err)
+		}
+		if len(results) != 1 || results[0] != tc.expected {
+			t.Errorf("Expected %q, got %v", tc.expected, results)
+		}
+	}
 
-	// Test that multiple players can place bets without interference
-	// This is a basic test - in a real concurrent environment, you'd use goroutines
+	if _, err := executeCrapsQL(t, table, "SHOW VIG FIELD;"); err == nil {
+		t.Error("Expected error for SHOW VIG on a multi-payout bet, got nil")
+	}
+}
 
-	// Player 1 places bet
-	_, err := executeCrapsQLForPlayer(t, table, players[0], "PLACE $25 ON PASS_LINE;")
-	if err != nil {
-		t.Fatalf("Failed to place bet for player 1: %v", err)
+func TestImpliedProbability(t *testing.T) {
+	testCases := []struct {
+		num, den int
+		expected float64
+	}{
+		{1, 1, 0.5},
+		{7, 1, 0.125},
+		{7, 6, 6.0 / 13.0},
+		{0, 1, 0},
+	}
+	for _, tc := range testCases {
+		if got := crapsgame.ImpliedProbability(tc.num, tc.den); got != tc.expected {
+			t.Errorf("ImpliedProbability(%d, %d): expected %.4f, got %.4f", tc.num, tc.den, tc.expected, got)
+		}
 	}
 
-	// Player 2 places bet
-	_, err = executeCrapsQLForPlayer(t, table, players[1], "PLACE $20 ON FIELD;")
+	table, _ := setupTestGame(t)
+	results, err := executeCrapsQL(t, table, "SHOW IMPLIED ANY_CRAPS;")
 	if err != nil {
-		t.Fatalf("Failed to place bet for player 2: %v", err)
+		t.Fatalf("SHOW IMPLIED failed: %v", err)
 	}
-
-	// Player 3 places bet
-	_, err = executeCrapsQLForPlayer(t, table, players[2], "PLACE $15 ON ANY_SEVEN;")
-	if err != nil {
-		t.Fatalf("Failed to place bet for player 3: %v", err)
+	expected := "ANY_CRAPS: pays 7:1, implied probability 12.50%, actual 11.11%"
+	if len(results) != 1 || results[0] != expected {
+		t.Errorf("Expected %q, got %v", expected, results)
 	}
+}
 
-	// Verify all bets were recorded correctly
-	verifyBetExists(t, table, players[0], "PASS_LINE", 25.0)
-	verifyBetExists(t, table, players[1], "FIELD", 20.0)
-	verifyBetExists(t, table, players[2], "ANY_SEVEN", 15.0)
-
-	// Verify total bet count
-	if getPlayerBetCount(t, table, players[0]) != 1 {
-		t.Errorf("Expected 1 bet for player 1, got %d", getPlayerBetCount(t, table, players[0]))
-	}
-	if getPlayerBetCount(t, table, players[1]) != 1 {
-		t.Errorf("Expected 1 bet for player 2, got %d", getPlayerBetCount(t, table, players[1]))
+func TestBetVariance(t *testing.T) {
+	pass, ok := crapsgame.BetVariance("PASS_LINE")
+	if !ok {
+		t.Fatal("Expected a variance for PASS_LINE")
 	}
-	if getPlayerBetCount(t, table, players[2]) != 1 {
-		t.Errorf("Expected 1 bet for player 3, got %d", getPlayerBetCount(t, table, players[2]))
+	// Even money: wins or loses the dollar, so the variance is just under 1
+	if pass < 0.999 || pass > 1 {
+		t.Errorf("PASS_LINE: expected variance just under 1, got %.6f", pass)
 	}
-}
-
-// 6.7 Error Handling and Edge Cases
-func TestInvalidGameStateOperations(t *testing.T) {
-	table, players := setupTestGame(t)
-	playerID := players[0]
 
-	// Test 1: Try to place odds bet without point established
-	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_ODDS;")
-	if err == nil {
-		t.Error("Expected error when placing odds bet without point established, got nil")
+	aces, ok := crapsgame.BetVariance("ACES")
+	if !ok {
+		t.Fatal("Expected a variance for ACES")
 	}
-
-	// Test 2: Try to place come bet during come out roll
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON COME;")
-	if err == nil {
-		t.Error("Expected error when placing come bet during come out roll, got nil")
+	// 30:1 on a 1-in-36 shot: 935/36 - (5/36)^2
+	expected := 935.0/36.0 - 25.0/1296.0
+	if diff := aces - expected; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("ACES: expected variance %.6f, got %.6f", expected, aces)
 	}
-
-	// Test 3: Try to remove non-existent bet
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "REMOVE PASS_LINE;")
-	if err == nil {
-		t.Error("Expected error when removing non-existent bet, got nil")
+	if aces < 20*pass {
+		t.Errorf("Expected ACES (%.4f) to swing far more than PASS_LINE (%.4f)", aces, pass)
 	}
 
-	// Test 4: Try to place bet for non-existent player
-	_, err = executeCrapsQLForPlayer(t, table, "nonexistent", "PLACE $25 ON PASS_LINE;")
-	if err == nil {
-		t.Error("Expected error when placing bet for non-existent player, got nil")
+	if _, ok := crapsgame.BetVariance("FIELD"); ok {
+		t.Error("Expected no variance for FIELD, which has more than one payout")
 	}
 
-	// Test 5: Try to place bet with invalid amount
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $0 ON PASS_LINE;")
-	if err == nil {
-		t.Error("Expected error when placing bet with zero amount, got nil")
+	table, _ := setupTestGame(t)
+	results, err := executeCrapsQL(t, table, "SHOW VARIANCE ACES;")
+	if err != nil {
+		t.Fatalf("SHOW VARIANCE failed: %v", err)
 	}
-
-	// Test 6: Try to place bet with negative amount
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $-25 ON PASS_LINE;")
-	if err == nil {
-		t.Error("Expected error when placing bet with negative amount, got nil")
+	want := "ACES: variance 25.9529, standard deviation 5.0944 per $1"
+	if len(results) != 1 || results[0] != want {
+		t.Errorf("Expected %q, got %v", want, results)
 	}
-
-	// Verify game state remains consistent
-	verifyGameState(t, table, crapsgame.StateComeOut, crapsgame.PointOff)
-	verifyPlayerBankroll(t, table, playerID, 1000.0)
 }
 
-func TestEdgeCaseScenarios(t *testing.T) {
-	table, players := setupTestGame(t)
-	playerID := players[0]
+func TestShowBehavior(t *testing.T) {
+	table, _ := setupTestGame(t)
 
-	// Test 1: Player with zero bankroll
-	_, err := executeCrapsQLForPlayer(t, table, playerID, "SET BANKROLL $0;")
-	if err != nil {
-		t.Fatalf("Failed to set bankroll to zero: %v", err)
+	tests := []struct {
+		statement string
+		expected  string
+	}{
+		{"SHOW BEHAVIOR PLACE_6;", "PLACE_6 (Place 6):\n  Come-out: off unless called working\n  Point: working"},
+		{"SHOW BEHAVIOR FIELD;", "FIELD (Field):\n  Come-out: one roll, decided on the next roll\n  Point: one roll, decided on the next roll"},
+		{"SHOW BEHAVIOR PASS_LINE;", "PASS_LINE (Pass Line):\n  Come-out: working\n  Point: working"},
 	}
-
-	// Try to place bet with zero bankroll
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_LINE;")
-	if err == nil {
-		t.Error("Expected error when placing bet with zero bankroll, got nil")
+	for _, tt := range tests {
+		results, err := executeCrapsQL(t, table, tt.statement)
+		if err != nil {
+			t.Fatalf("%s failed: %v", tt.statement, err)
+		}
+		if len(results) != 1 || results[0] != tt.expected {
+			t.Errorf("%s: expected %q, got %v", tt.statement, tt.expected, results)
+		}
 	}
 
-	// Test 2: Very large bet amounts
-	// Reset bankroll
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "SET BANKROLL $1000000;")
+	// The table's working defaults decide the come-out behavior
+	table.WorkingDefaults[crapsgame.PlaceBets] = true
+	results, err := executeCrapsQL(t, table, "SHOW BEHAVIOR PLACE_6;")
 	if err != nil {
-		t.Fatalf("Failed to set large bankroll: %v", err)
+		t.Fatalf("SHOW BEHAVIOR failed: %v", err)
 	}
-
-	// Try to place very large bet
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $999999 ON PASS_LINE;")
-	if err == nil {
-		t.Error("Expected error when placing bet exceeding table maximum, got nil")
+	if len(results) != 1 || !strings.Contains(results[0], "Come-out: working") {
+		t.Errorf("Expected PLACE_6 to work on the come-out, got %v", results)
 	}
+}
 
-	// Test 3: Rapid state transitions
-	// Place bet
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_LINE;")
-	if err != nil {
-		t.Fatalf("Failed to place bet: %v", err)
+func TestShowOdds(t *testing.T) {
+	tests := []struct {
+		die1, die2 int
+		expected   string
+	}{
+		{1, 3, "Pass Odds 2:1, Don't Pass Odds 1:2"},
+		{2, 3, "Pass Odds 3:2, Don't Pass Odds 2:3"},
+		{2, 4, "Pass Odds 6:5, Don't Pass Odds 5:6"},
+		{3, 5, "Pass Odds 6:5, Don't Pass Odds 5:6"},
+		{4, 5, "Pass Odds 3:2, Don't Pass Odds 2:3"},
+		{4, 6, "Pass Odds 2:1, Don't Pass Odds 1:2"},
 	}
 
-	// Rapid rolls
-	for i := 0; i < 10; i++ {
-		simulateDiceRoll(t, table, 3, 4) // 7
-		// Place new bet immediately
-		_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_LINE;")
+	for _, tt := range tests {
+		table, players := setupTestGame(t)
+
+		output, err := executeCrapsQLForPlayer(t, table, players[0], "SHOW ODDS;")
 		if err != nil {
-			t.Fatalf("Failed to place bet after rapid roll %d: %v", i, err)
+			t.Fatalf("Failed to show odds on the come-out: %v", err)
+		}
+		if output[0] != "Odds: no point established" {
+			t.Errorf("Expected no point on the come-out, got %q", output[0])
 		}
-	}
 
-	// Test 4: All players removed during game
-	// Remove all players
-	for _, player := range players {
-		err := table.RemovePlayer(player)
+		simulateDiceRoll(t, table, tt.die1, tt.die2)
+		output, err = executeCrapsQLForPlayer(t, table, players[0], "SHOW ODDS;")
 		if err != nil {
-			t.Fatalf("Failed to remove player %s: %v", player, err)
+			t.Fatalf("Point %d: failed to show odds: %v", tt.die1+tt.die2, err)
+		}
+		if output[0] != tt.expected {
+			t.Errorf("Point %d: expected %q, got %q", tt.die1+tt.die2, tt.expected, output[0])
 		}
 	}
+}
 
-	// Try to place bet with no players
-	_, err = executeCrapsQL(t, table, "PLACE $25 ON PASS_LINE;")
-	if err == nil {
-		t.Error("Expected error when placing bet with no players, got nil")
-	}
+func TestComeBetPoints(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
 
-	// Test 5: Invalid bet types
-	// Add a player back
-	err = table.AddPlayer("newplayer", "New Player", 1000.0)
+	simulateDiceRoll(t, table, 3, 3) // Table point 6
+
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON COME;")
 	if err != nil {
-		t.Fatalf("Failed to add new player: %v", err)
+		t.Fatalf("Failed to place come bet: %v", err)
 	}
 
-	_, err = executeCrapsQLForPlayer(t, table, "newplayer", "PLACE $25 ON INVALID_BET_TYPE;")
-	if err == nil {
-		t.Error("Expected error when placing invalid bet type, got nil")
+	// 5 becomes the come point
+	simulateDiceRoll(t, table, 2, 3)
+	player, _ := table.GetPlayer(playerID)
+	if len(player.Bets) != 1 || len(player.Bets[0].Numbers) != 1 || player.Bets[0].Numbers[0] != 5 {
+		t.Fatalf("Expected come bet to travel to 5, got %+v", player.Bets)
 	}
+
+	// Unrelated numbers leave it alone
+	simulateDiceRoll(t, table, 4, 4)
+	simulateDiceRoll(t, table, 1, 3)
+	verifyBetExists(t, table, playerID, "COME", 10.0)
+
+	// 5 again wins even money
+	simulateDiceRoll(t, table, 1, 4)
+	verifyBetNotExists(t, table, playerID, "COME")
+	verifyPlayerBankroll(t, table, playerID, 1010.0)
+
+	// A come bet with a point loses on the seven-out
+	executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON COME;")
+	simulateDiceRoll(t, table, 4, 5) // Come point 9
+	simulateDiceRoll(t, table, 3, 4) // Seven out
+	verifyBetNotExists(t, table, playerID, "COME")
+	verifyPlayerBankroll(t, table, playerID, 1000.0)
+
+	// Don't come travels behind 4, then wins on 7 before 4
+	simulateDiceRoll(t, table, 4, 4) // Table point 8
+	executeCrapsQLForPlayer(t, table, players[1], "PLACE $10 ON DONT_COME; PLACE $10 ON COME;")
+	simulateDiceRoll(t, table, 1, 3)
+	verifyBetExists(t, table, players[1], "DONT_COME", 10.0)
+	simulateDiceRoll(t, table, 3, 4)
+	verifyBetNotExists(t, table, players[1], "DONT_COME")
+	verifyBetNotExists(t, table, players[1], "COME")
+	verifyPlayerBankroll(t, table, players[1], 1000.0) // Don't come +10, come -10
+
+	// A fresh come bet wins on 7 on its first roll
+	simulateDiceRoll(t, table, 2, 2) // Table point 4
+	executeCrapsQLForPlayer(t, table, players[2], "PLACE $10 ON COME;")
+	simulateDiceRoll(t, table, 3, 4)
+	verifyPlayerBankroll(t, table, players[2], 1010.0)
 }
 
-func TestBetRemovalAndModification(t *testing.T) {
+func TestWorkingNumbers(t *testing.T) {
 	table, players := setupTestGame(t)
 	playerID := players[0]
 
-	// SIMPLIFIED TEST: Focus on core bet placement/resolution mechanics
-	// REMOVE and PRESS commands are advanced language features not implemented yet
+	simulateDiceRoll(t, table, 4, 4) // Table point 8
 
-	// Place multiple bets
-	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_LINE;")
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $12 ON PLACE_6; PLACE $20 ON BUY_4; PLACE $10 ON COME;")
 	if err != nil {
-		t.Fatalf("Failed to place pass line bet: %v", err)
+		t.Fatalf("Failed to place bets: %v", err)
 	}
 
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $20 ON FIELD;")
-	if err != nil {
-		t.Fatalf("Failed to place field bet: %v", err)
+	// The come bet has no number until it travels
+	if got := table.WorkingNumbers(playerID); !reflect.DeepEqual(got, []int{4, 6}) {
+		t.Errorf("Expected working numbers [4 6] before the come bet travels, got %v", got)
 	}
 
-	// Verify both bets exist
-	verifyBetExists(t, table, playerID, "PASS_LINE", 25.0)
-	verifyBetExists(t, table, playerID, "FIELD", 20.0)
-	verifyPlayerBankroll(t, table, playerID, 955.0) // 1000 - 25 - 20
-
-	// Test that bets resolve correctly via dice rolls (core game logic)
-	t.Logf("Before roll: Game state = %v", table.State)
-	simulateDiceRoll(t, table, 1, 1) // 2 (field wins 2:1, pass line LOSES on come out!)
-	t.Logf("After roll: Game state = %v", table.State)
-
-	// Field should be resolved (one-roll bet wins), pass line should be REMOVED (loses on craps 2!)
-	verifyBetNotExists(t, table, playerID, "FIELD")
-	verifyBetNotExists(t, table, playerID, "PASS_LINE") // PASS LINE LOSES ON CRAPS 2!
-
-	// Bankroll: 955 + 20 (field bet back) + 40 (field 2:1 payout) - 0 (pass line lost) = 1015
-	verifyPlayerBankroll(t, table, playerID, 1015.0)
+	simulateDiceRoll(t, table, 4, 5) // Come point 9
+	if got := table.WorkingNumbers(playerID); !reflect.DeepEqual(got, []int{4, 6, 9}) {
+		t.Errorf("Expected working numbers [4 6 9], got %v", got)
+	}
 
-	t.Logf("✅ Core bet placement and resolution verified")
-	t.Logf("⚠️ REMOVE/PRESS commands not implemented yet")
+	if got := table.WorkingNumbers(players[1]); len(got) != 0 {
+		t.Errorf("Expected no working numbers for a player without bets, got %v", got)
+	}
 }
 
-func TestConditionalStatements(t *testing.T) {
+func TestComeOutOnlyPropBet(t *testing.T) {
 	table, players := setupTestGame(t)
 	playerID := players[0]
 
-	// SIMPLIFIED TEST: Focus on core game logic - bankroll validation when placing bets
-	// IF statement functionality is not fully implemented yet (language feature)
-
-	// Test that core bankroll validation works when placing bets
-	// Player has $1000 bankroll
+	def, _ := crapsgame.GetBetDefinition("COME_OUT_HARDWAYS")
+	if !def.RequiresComeOut || !def.OneRoll {
+		t.Fatalf("Expected COME_OUT_HARDWAYS to be a come-out-only one-roll bet, got %+v", def)
+	}
 
-	// Should succeed - bet within bankroll
-	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_LINE;")
+	// Allowed on the come-out; hard 4 pays 7:1 and sets the point
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON COME_OUT_HARDWAYS;")
 	if err != nil {
-		t.Fatalf("Failed to place bet within bankroll: %v", err)
+		t.Fatalf("Failed to place come-out hardways on the come-out: %v", err)
 	}
-	verifyBetExists(t, table, playerID, "PASS_LINE", 25.0)
-	verifyPlayerBankroll(t, table, playerID, 975.0)
+	simulateDiceRoll(t, table, 2, 2)
+	verifyBetNotExists(t, table, playerID, "COME_OUT_HARDWAYS")
+	verifyPlayerBankroll(t, table, playerID, 1070.0)
 
-	// Should fail - bet exceeds bankroll
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $2000 ON FIELD;")
-	if err == nil {
-		t.Error("Expected error when betting more than bankroll, got nil")
-	} else {
-		t.Logf("✅ Bankroll validation correctly rejected excessive bet: %v", err)
+	// Rejected once the point is on
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON COME_OUT_HARDWAYS;")
+	if err == nil || !strings.Contains(err.Error(), "come-out") {
+		t.Errorf("Expected come-out only error during the point phase, got %v", err)
 	}
 
-	// Field bet should not exist (was rejected)
-	verifyBetNotExists(t, table, playerID, "FIELD")
-
-	t.Logf("✅ Core bankroll validation working correctly")
-	t.Logf("⚠️ IF statement syntax not fully implemented yet")
+	// Easy numbers lose
+	simulateDiceRoll(t, table, 1, 3) // Point made, back to come-out
+	executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON COME_OUT_HARDWAYS;")
+	simulateDiceRoll(t, table, 2, 4)
+	verifyBetNotExists(t, table, playerID, "COME_OUT_HARDWAYS")
+	verifyPlayerBankroll(t, table, playerID, 1060.0)
 }
 
-// 6.8 Interpreter Integration Tests
-func TestInterpreterStatementExecution(t *testing.T) {
+func TestPayTableOverrides(t *testing.T) {
 	table, players := setupTestGame(t)
 	playerID := players[0]
 
-	// Test 1: Place bet statement
-	results, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_LINE;")
+	overrides, err := crapsgame.LoadPayTable([]byte(`{
+		"HARD_8": {"payout": "10:1"},
+		"FIELD": {"special_payouts": {"12": "2:1"}}
+	}`))
 	if err != nil {
-		t.Fatalf("Failed to execute bet statement: %v", err)
-	}
-	if len(results) != 1 {
-		t.Errorf("Expected 1 result, got %d", len(results))
+		t.Fatalf("Failed to load pay table: %v", err)
 	}
-	if !strings.Contains(results[0], "✅ Placed $25.00 on PASS_LINE") {
-		t.Errorf("Expected success message, got: %s", results[0])
+	if err := table.ApplyPayTable(overrides); err != nil {
+		t.Fatalf("Failed to apply pay table: %v", err)
 	}
 
-	// Verify bet was placed
-	verifyBetExists(t, table, playerID, "PASS_LINE", 25.0)
-	verifyPlayerBankroll(t, table, playerID, 975.0) // 1000 - 25
+	simulateDiceRoll(t, table, 3, 3) // Point 6
 
-	// Test 2: Show point statement
-	results, err = executeCrapsQL(t, table, "SHOW POINT;")
+	// Hard 8 pays 10:1 instead of 9:1
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON HARD_8;")
 	if err != nil {
-		t.Fatalf("Failed to execute show point statement: %v", err)
-	}
-	if len(results) != 1 {
-		t.Errorf("Expected 1 result, got %d", len(results))
-	}
-	if results[0] != "Point: OFF" {
-		t.Errorf("Expected 'Point: OFF', got: %s", results[0])
+		t.Fatalf("Failed to place hard 8: %v", err)
 	}
+	simulateDiceRoll(t, table, 4, 4)
+	verifyPlayerBankroll(t, table, playerID, 1090.0) // 990 + 100 payout, bet stays up
 
-	// Test 3: Set bankroll statement
-	results, err = executeCrapsQLForPlayer(t, table, playerID, "SET BANKROLL $2000;")
+	// The odds queries report what this table pays: 10:1 is the true odds
+	output, err := executeCrapsQLForPlayer(t, table, playerID, "SHOW VIG HARD_8; SHOW IMPLIED HARD_8;")
 	if err != nil {
-		t.Fatalf("Failed to execute set bankroll statement: %v", err)
+		t.Fatalf("Failed to show the hard 8's odds: %v", err)
 	}
-	if len(results) != 1 {
-		t.Errorf("Expected 1 result, got %d", len(results))
+	if !strings.Contains(output[0], "actual 10:1, gap $0.00 per $1") {
+		t.Errorf("Expected SHOW VIG at the 10:1 override, got %q", output[0])
 	}
-	if !strings.Contains(results[0], "Set bankroll to") {
-		t.Errorf("Expected bankroll update message, got: %s", results[0])
+	if !strings.Contains(output[1], "pays 10:1, implied probability 9.09%") {
+		t.Errorf("Expected SHOW IMPLIED at the 10:1 override, got %q", output[1])
+	}
+	overridden, _ := table.BetVariance("HARD_8")
+	standard, _ := crapsgame.BetVariance("HARD_8")
+	if overridden <= standard {
+		t.Errorf("Expected a 10:1 hard 8 to vary more than 9:1, got %.4f and %.4f", overridden, standard)
 	}
 
-	// Verify bankroll was updated
-	verifyPlayerBankroll(t, table, playerID, 2000.0)
-}
+	// Field 12 pays 2:1 instead of 3:1
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON FIELD;")
+	if err != nil {
+		t.Fatalf("Failed to place field bet: %v", err)
+	}
+	simulateDiceRoll(t, table, 6, 6)
+	verifyPlayerBankroll(t, table, playerID, 1110.0) // 1080 + 10 bet + 20 payout
 
-func TestInterpreterErrorHandling(t *testing.T) {
-	table, players := setupTestGame(t)
-	playerID := players[0]
+	// Tables without the pay table keep the standard field payout
+	defaultTable, defaultPlayers := setupTestGame(t)
+	executeCrapsQLForPlayer(t, defaultTable, defaultPlayers[0], "PLACE $10 ON FIELD;")
+	simulateDiceRoll(t, defaultTable, 6, 6)
+	verifyPlayerBankroll(t, defaultTable, defaultPlayers[0], 1030.0)
 
-	// Test 1: Invalid bet type
-	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON INVALID_BET;")
-	if err == nil {
-		t.Error("Expected error for invalid bet type, got nil")
+	// A barred 12 is still a push under an even money override
+	pushTable, pushPlayers := setupTestGame(t)
+	overrides, err = crapsgame.LoadPayTable([]byte(`{"DONT_PASS": {"payout": "1:1"}}`))
+	if err != nil {
+		t.Fatalf("Failed to load pay table: %v", err)
 	}
-
-	// Test 2: Invalid amount (negative)
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $-25 ON PASS_LINE;")
-	if err == nil {
-		t.Error("Expected error for negative amount, got nil")
+	if err := pushTable.ApplyPayTable(overrides); err != nil {
+		t.Fatalf("Failed to apply pay table: %v", err)
 	}
+	executeCrapsQLForPlayer(t, pushTable, pushPlayers[0], "PLACE $10 ON DONT_PASS;")
+	simulateDiceRoll(t, pushTable, 6, 6)
+	verifyPlayerBankroll(t, pushTable, pushPlayers[0], 1000.0)
 
-	// Test 3: Invalid syntax
-	_, err = executeCrapsQL(t, table, "INVALID STATEMENT;")
-	if err == nil {
-		t.Error("Expected error for invalid syntax, got nil")
+	invalid := []string{
+		`{"HARD_8": {"payout": "0:1"}}`,
+		`{"HARD_8": {"payout": "ten to one"}}`,
+		`{"FIELD": {"special_payouts": {"2": "-2:1"}}}`,
+		`{"NOT_A_BET": {"payout": "2:1"}}`,
+		`not json`,
 	}
-
-	// Test 4: Bet amount exceeds bankroll
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $2000 ON PASS_LINE;")
-	if err == nil {
-		t.Error("Expected error for bet exceeding bankroll, got nil")
+	for _, data := range invalid {
+		if _, err := crapsgame.LoadPayTable([]byte(data)); err == nil {
+			t.Errorf("Expected error loading %s, got nil", data)
+		}
 	}
 
-	// Test 5: Non-existent player
-	_, err = executeCrapsQLForPlayer(t, table, "nonexistent", "PLACE $25 ON PASS_LINE;")
-	if err == nil {
-		t.Error("Expected error for non-existent player, got nil")
+	// Bets with more than one payout can't take a single ratio
+	overrides, err = crapsgame.LoadPayTable([]byte(`{"PASS_ODDS": {"payout": "2:1"}}`))
+	if err != nil {
+		t.Fatalf("Failed to load pay table: %v", err)
+	}
+	if err := table.ApplyPayTable(overrides); err == nil {
+		t.Error("Expected error overriding PASS_ODDS, got nil")
 	}
 }
 
-func TestInterpreterBetPlacement(t *testing.T) {
+func TestBetLifecycleNet(t *testing.T) {
+	tests := []struct {
+		betType string
+		amount  float64
+		outcome string
+		want    float64
+	}{
+		{"PASS_LINE", 25, crapsgame.OutcomeWin, 25},
+		{"PASS_LINE", 25, crapsgame.OutcomeLose, -25},
+		{"DONT_PASS", 25, crapsgame.OutcomePush, 0},
+		{"PLACE_6", 12, crapsgame.OutcomeWin, 14},
+		{"BUY_4", 20, crapsgame.OutcomeWin, 39}, // 2:1 less 5% commission
+		{"NOT_A_BET", 20, crapsgame.OutcomeWin, 0},
+	}
+	for _, tt := range tests {
+		if got := crapsgame.BetLifecycleNet(tt.betType, tt.amount, tt.outcome); got != tt.want {
+			t.Errorf("BetLifecycleNet(%s, %.2f, %s) = %.2f, want %.2f", tt.betType, tt.amount, tt.outcome, got, tt.want)
+		}
+	}
+
+	// The engine agrees: a buy bet that wins and is then taken down nets the same
 	table, players := setupTestGame(t)
 	playerID := players[0]
+	simulateDiceRoll(t, table, 3, 3) // Point 6
+	executeCrapsQLForPlayer(t, table, playerID, "PLACE $20 ON BUY_4;")
+	simulateDiceRoll(t, table, 2, 2)
+	executeCrapsQLForPlayer(t, table, playerID, "REMOVE BUY_4;")
+	verifyPlayerBankroll(t, table, playerID, 1000+crapsgame.BetLifecycleNet("BUY_4", 20, crapsgame.OutcomeWin))
+}
 
-	// Test different bet types
-	betTests := []struct {
-		statement string
-		betType   string
-		amount    float64
-	}{
-		{"PLACE $25 ON PASS_LINE;", "PASS_LINE", 25.0},
-		{"PLACE $10 ON FIELD;", "FIELD", 10.0},
-		{"PLACE $20 ON PLACE_6;", "PLACE_6", 20.0},
-		{"PLACE $15 ON ANY_SEVEN;", "ANY_SEVEN", 15.0},
-	}
+// 6.4 Odds and Modifiers Tests
+func TestPassLineWithOdds(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
 
-	for _, test := range betTests {
-		results, err := executeCrapsQLForPlayer(t, table, playerID, test.statement)
-		if err != nil {
-			t.Fatalf("Failed to place %s bet: %v", test.betType, err)
-		}
+	// Step 1: Place pass line bet
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_LINE;")
+	if err != nil {
+		t.Fatalf("Failed to place pass line bet: %v", err)
+	}
 
-		if len(results) != 1 {
-			t.Errorf("Expected 1 result for %s bet, got %d", test.betType, len(results))
-		}
+	// Step 2: Establish point (6)
+	simulateDiceRoll(t, table, 3, 3) // 6
+	verifyGameState(t, table, crapsgame.StatePoint, crapsgame.Point6)
 
-		verifyBetExists(t, table, playerID, test.betType, test.amount)
+	// Step 3: Add odds bet (3X max = $75)
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $75 ON PASS_ODDS;")
+	if err != nil {
+		t.Fatalf("Failed to place odds bet: %v", err)
 	}
 
-	// Verify total bankroll deduction
-	expectedBankroll := 1000.0 - 25.0 - 10.0 - 20.0 - 15.0
-	verifyPlayerBankroll(t, table, playerID, expectedBankroll)
-}
+	verifyBetExists(t, table, playerID, "PASS_ODDS", 75.0)
+	verifyPlayerBankroll(t, table, playerID, 900.0) // 1000 - 25 - 75
 
-func TestInterpreterQueryStatements(t *testing.T) {
-	table, players := setupTestGame(t)
-	playerID := players[0]
+	// Step 4: Point hits - verify odds payout (true odds for 6 = 6:5)
+	simulateDiceRoll(t, table, 2, 4) // 6
+	verifyGameState(t, table, crapsgame.StateComeOut, crapsgame.PointOff)
+	verifyBetNotExists(t, table, playerID, "PASS_LINE")
+	verifyBetNotExists(t, table, playerID, "PASS_ODDS")
 
-	// Test SHOW POINT
-	results, err := executeCrapsQL(t, table, "SHOW POINT;")
+	// Pass line wins 1:1 (25 + 25 = 50), odds win 6:5 (75 + 90 = 165)
+	// Total: 900 + 50 + 165 = 1115
+	verifyPlayerBankroll(t, table, playerID, 1115.0)
+
+	// Test 2: Seven out - odds bet loses
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_LINE;")
 	if err != nil {
-		t.Fatalf("Failed to execute SHOW POINT: %v", err)
+		t.Fatalf("Failed to place second pass line bet: %v", err)
 	}
-	if len(results) != 1 || results[0] != "Point: OFF" {
-		t.Errorf("Expected 'Point: OFF', got: %v", results)
+
+	simulateDiceRoll(t, table, 3, 3) // 6
+	verifyGameState(t, table, crapsgame.StatePoint, crapsgame.Point6)
+
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $75 ON PASS_ODDS;")
+	if err != nil {
+		t.Fatalf("Failed to place second odds bet: %v", err)
 	}
 
-	// Test SHOW BETS
-	results, err = executeCrapsQL(t, table, "SHOW BETS;")
+	// Seven out
+	simulateDiceRoll(t, table, 3, 4) // 7
+	verifyGameState(t, table, crapsgame.StateComeOut, crapsgame.PointOff)
+	verifyBetNotExists(t, table, playerID, "PASS_LINE")
+	verifyBetNotExists(t, table, playerID, "PASS_ODDS")
+
+	// Both bets lose - bankroll should be 1115 - 25 - 75 = 1015
+	verifyPlayerBankroll(t, table, playerID, 1015.0)
+}
+
+func TestOddsRequireBaseBet(t *testing.T) {
+	table, players := setupTestGame(t)
+	withLine, withoutLine := players[0], players[1]
+
+	_, err := executeCrapsQLForPlayer(t, table, withLine, "PLACE $25 ON PASS_LINE;")
 	if err != nil {
-		t.Fatalf("Failed to execute SHOW BETS: %v", err)
+		t.Fatalf("Failed to place pass line bet: %v", err)
 	}
-	if len(results) != 1 {
-		t.Errorf("Expected 1 result, got %d", len(results))
+	simulateDiceRoll(t, table, 3, 3) // Point 6
+
+	// No pass line bet to back
+	_, err = executeCrapsQLForPlayer(t, table, withoutLine, "PLACE $25 ON PASS_ODDS;")
+	if err == nil {
+		t.Fatal("Expected error placing pass odds without a pass line bet, got nil")
 	}
-	if !strings.Contains(results[0], "AVAILABLE BET TYPES") {
-		t.Errorf("Expected bet types list, got: %s", results[0])
+	if !strings.Contains(err.Error(), "PASS_ODDS requires a working PASS_LINE bet") {
+		t.Errorf("Expected error naming the missing PASS_LINE bet, got %v", err)
 	}
+	verifyPlayerBankroll(t, table, withoutLine, 1000.0)
 
-	// Test SHOW BANKROLL for specific player
-	results, err = executeCrapsQLForPlayer(t, table, playerID, "SHOW BANKROLL;")
+	// Backed by a pass line bet
+	_, err = executeCrapsQLForPlayer(t, table, withLine, "PLACE $25 ON PASS_ODDS;")
 	if err != nil {
-		t.Fatalf("Failed to execute SHOW BANKROLL: %v", err)
-	}
-	if len(results) != 1 {
-		t.Errorf("Expected 1 result, got %d", len(results))
-	}
-	if !strings.Contains(results[0], "Player player1 Bankroll: $1000.00") {
-		t.Errorf("Expected bankroll info, got: %s", results[0])
+		t.Fatalf("Expected pass odds behind a pass line bet to be accepted, got %v", err)
 	}
+	verifyBetExists(t, table, withLine, "PASS_ODDS", 25.0)
 }
 
-func TestInterpreterManagementStatements(t *testing.T) {
+func TestFullAndDoubleOdds(t *testing.T) {
 	table, players := setupTestGame(t)
-	playerID := players[0]
 
-	// Test SET BANKROLL
-	_, err := executeCrapsQLForPlayer(t, table, playerID, "SET BANKROLL $1500;")
-	if err != nil {
-		t.Fatalf("Failed to execute SET BANKROLL: %v", err)
+	// Modifiers parse to the odds shorthand types
+	parser := NewParser(NewLexer("PLACE $10 ON PASS_LINE WITH FULL ODDS; PLACE $10 ON DONT_PASS WITH DOUBLE ODDS;"))
+	program := parser.ParseProgram()
+	if len(parser.Errors()) > 0 {
+		t.Fatalf("Unexpected parser errors: %v", parser.Errors())
+	}
+	expectedMods := []ModifierType{ModFullOdds, ModDoubleOdds}
+	for i, stmt := range program.Statements {
+		betStmt := stmt.(*BetStatement)
+		if len(betStmt.Modifiers) != 1 || betStmt.Modifiers[0].Type != expectedMods[i] {
+			t.Errorf("Statement %d: expected modifier %v, got %v", i, expectedMods[i], betStmt.Modifiers)
+		}
 	}
-	verifyPlayerBankroll(t, table, playerID, 1500.0)
 
-	// Test SET MAX_BET
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "SET MAX_BET $500;")
+	// Odds need a point
+	_, err := executeCrapsQLForPlayer(t, table, players[0], "PLACE $10 ON PASS_LINE WITH FULL ODDS;")
+	if err == nil {
+		t.Error("Expected error taking odds on the come-out, got nil")
+	}
+	verifyBetNotExists(t, table, players[0], "PASS_LINE")
+
+	simulateDiceRoll(t, table, 3, 3) // Point 6
+
+	// FULL ODDS takes the table maximum (3x)
+	_, err = executeCrapsQLForPlayer(t, table, players[0], "PLACE $10 ON PASS_LINE WITH FULL ODDS;")
 	if err != nil {
-		t.Fatalf("Failed to execute SET MAX_BET: %v", err)
+		t.Fatalf("Failed to place pass line with full odds: %v", err)
 	}
+	verifyBetExists(t, table, players[0], "PASS_LINE", 10.0)
+	verifyBetExists(t, table, players[0], "PASS_ODDS", 10.0*float64(table.MaxOdds))
 
-	player, err := table.GetPlayer(playerID)
+	// DOUBLE ODDS takes 2x the line
+	_, err = executeCrapsQLForPlayer(t, table, players[1], "PLACE $10 ON PASS_LINE WITH DOUBLE ODDS;")
 	if err != nil {
-		t.Fatalf("Failed to get player: %v", err)
+		t.Fatalf("Failed to place pass line with double odds: %v", err)
 	}
-	if player.MaxBet != 500.0 {
-		t.Errorf("Expected MaxBet to be $500.00, got $%.2f", player.MaxBet)
+	verifyBetExists(t, table, players[1], "PASS_ODDS", 20.0)
+
+	if _, err := executeCrapsQLForPlayer(t, table, players[2], "PLACE $10 ON FIELD WITH FULL ODDS;"); err == nil {
+		t.Error("Expected error using FULL ODDS on a field bet, got nil")
 	}
 
-	// Test SET MIN_BET
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "SET MIN_BET $10;")
+	// A second line bet gets its own odds, not more behind the first
+	_, err = executeCrapsQLForPlayer(t, table, players[0], "PLACE $15 ON PASS_LINE WITH FULL ODDS;")
 	if err != nil {
-		t.Fatalf("Failed to execute SET MIN_BET: %v", err)
+		t.Fatalf("Failed to place a second pass line with full odds: %v", err)
+	}
+	player, _ := table.GetPlayer(players[0])
+	lines := make(map[string]float64)
+	for _, bet := range player.Bets {
+		if bet.Type == "PASS_LINE" {
+			lines[bet.ID] = bet.Amount
+		}
+	}
+	for _, bet := range player.Bets {
+		if bet.Type == "PASS_ODDS" && bet.Amount != lines[bet.ParentBetID]*float64(table.MaxOdds) {
+			t.Errorf("Expected $%.2f odds behind the $%.2f line, got $%.2f", lines[bet.ParentBetID]*float64(table.MaxOdds), lines[bet.ParentBetID], bet.Amount)
+		}
 	}
 
-	if player.MinBet != 10.0 {
-		t.Errorf("Expected MinBet to be $10.00, got $%.2f", player.MinBet)
+	// If the odds are rejected the line bet doesn't go up alone
+	if _, err := executeCrapsQLForPlayer(t, table, players[2], "SET MAX_BET $20; PLACE $10 ON PASS_LINE WITH FULL ODDS;"); err == nil {
+		t.Error("Expected error taking $30 odds over a $20 max bet, got nil")
 	}
+	verifyBetNotExists(t, table, players[2], "PASS_LINE")
+	verifyPlayerBankroll(t, table, players[2], 1000.0)
 }
 
-func TestInterpreterTurnStatements(t *testing.T) {
+func TestParlayOneRollBet(t *testing.T) {
 	table, players := setupTestGame(t)
 	playerID := players[0]
+	table.MaxBet = 10000 // room for the parlay to compound
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "SET MAX_BET $10000;"); err != nil {
+		t.Fatalf("Failed to raise the player's max bet: %v", err)
+	}
 
-	// First, place a bet to turn on/off
-	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PLACE_6;")
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON PASS_LINE WITH PARLAY;"); err == nil {
+		t.Error("Expected error parlaying a pass line bet, got nil")
+	}
+	verifyBetNotExists(t, table, playerID, "PASS_LINE")
+
+	output, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $5 ON HOP_HARD_6 WITH PARLAY;")
 	if err != nil {
-		t.Fatalf("Failed to place PLACE_6 bet: %v", err)
+		t.Fatalf("Failed to place parlayed hop: %v", err)
+	}
+	if !strings.Contains(output[0], "Parlaying HOP_HARD_6") {
+		t.Errorf("Expected parlay confirmation, got %q", output[0])
 	}
 
-	// Verify bet exists and is working by default
-	verifyBetExists(t, table, playerID, "PLACE_6", 25.0)
+	table.SetDiceSource(newScriptedDice([2]int{3, 3}, [2]int{3, 3}, [2]int{2, 5}))
 
-	// Get the bet and verify it's working
-	player, err := table.GetPlayer(playerID)
-	if err != nil {
-		t.Fatalf("Failed to get player: %v", err)
+	// Each win puts the whole 30:1 return back up on the hop
+	table.RollDiceAndResolve()
+	verifyBetExists(t, table, playerID, "HOP_HARD_6", 155.0)
+	verifyPlayerBankroll(t, table, playerID, 995.0)
+
+	table.RollDiceAndResolve()
+	verifyBetExists(t, table, playerID, "HOP_HARD_6", 4805.0)
+	verifyPlayerBankroll(t, table, playerID, 995.0)
+
+	// The loss takes the whole parlay, leaving the player down the original $5
+	table.RollDiceAndResolve()
+	verifyBetNotExists(t, table, playerID, "HOP_HARD_6")
+	verifyPlayerBankroll(t, table, playerID, 995.0)
+
+	player, _ := table.GetPlayer(playerID)
+	if player.Stats.TotalWon != 4800.0 || player.Stats.TotalLost != 4805.0 {
+		t.Errorf("Expected $4800.00 won and $4805.00 lost, got $%.2f and $%.2f", player.Stats.TotalWon, player.Stats.TotalLost)
 	}
 
-	var place6Bet *crapsgame.Bet
-	for _, bet := range player.Bets {
-		if bet.Type == "PLACE_6" {
-			place6Bet = bet
-			break
+	// PARLAY applies to the bet being placed, not the player's others
+	other := players[1]
+	if _, err := executeCrapsQLForPlayer(t, table, other, "PLACE $5 ON FIELD; PLACE $5 ON FIELD WITH PARLAY;"); err != nil {
+		t.Fatalf("Failed to place field bets: %v", err)
+	}
+	parlayed := 0
+	otherPlayer, _ := table.GetPlayer(other)
+	for _, bet := range otherPlayer.Bets {
+		if bet.Parlay {
+			parlayed++
 		}
 	}
-
-	if place6Bet == nil {
-		t.Fatalf("Could not find PLACE_6 bet")
+	if parlayed != 1 {
+		t.Errorf("Expected 1 parlayed field bet, got %d", parlayed)
 	}
+}
 
-	if !place6Bet.Working {
-		t.Errorf("Expected PLACE_6 bet to be working by default, but it's not")
-	}
+func TestParlayRideLimits(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
 
-	// Test TURN OFF command
-	results, err := executeCrapsQLForPlayer(t, table, playerID, "TURN OFF PLACE_6;")
-	if err != nil {
-		t.Fatalf("Failed to execute TURN OFF: %v", err)
+	// The player's $100 maximum caps the ride; the rest is paid out
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "SET MAX_BET $100; PLACE $5 ON HOP_HARD_6 WITH PARLAY;"); err != nil {
+		t.Fatalf("Failed to place parlayed hop: %v", err)
 	}
+	simulateDiceRoll(t, table, 3, 3)
+	verifyBetExists(t, table, playerID, "HOP_HARD_6", 100.0)
+	verifyPlayerBankroll(t, table, playerID, 995.0+150.0-95.0)
 
-	if len(results) != 1 {
-		t.Errorf("Expected 1 result, got %d", len(results))
+	// The exposure cap holds the ride to what's left under it
+	table, players = setupTestGame(t)
+	playerID = players[0]
+	table.MaxExposure = 60
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON PASS_LINE; PLACE $5 ON HOP_HARD_6 WITH PARLAY;"); err != nil {
+		t.Fatalf("Failed to place bets: %v", err)
 	}
+	simulateDiceRoll(t, table, 3, 3)
+	verifyBetExists(t, table, playerID, "HOP_HARD_6", 50.0)
+	verifyPlayerBankroll(t, table, playerID, 985.0+150.0-45.0)
+}
 
-	if !strings.Contains(results[0], "Turned PLACE_6 bet off") {
-		t.Errorf("Expected success message for TURN OFF, got: %s", results[0])
-	}
+func TestKeepOneRollBetOnLoss(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
 
-	// Verify bet is now not working
-	if place6Bet.Working {
-		t.Errorf("Expected PLACE_6 bet to be turned off, but it's still working")
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON PLACE_6 WITH KEEP;"); err == nil {
+		t.Error("Expected error keeping up a place bet, got nil")
 	}
+	verifyBetNotExists(t, table, playerID, "PLACE_6")
 
-	// Test TURN ON command
-	results, err = executeCrapsQLForPlayer(t, table, playerID, "TURN ON PLACE_6;")
+	output, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON ANY_SEVEN WITH KEEP;")
 	if err != nil {
-		t.Fatalf("Failed to execute TURN ON: %v", err)
+		t.Fatalf("Failed to place kept any seven: %v", err)
+	}
+	if !strings.Contains(output[0], "Keeping ANY_SEVEN up") {
+		t.Errorf("Expected keep confirmation, got %q", output[0])
 	}
+	verifyPlayerBankroll(t, table, playerID, 990.0)
 
-	if len(results) != 1 {
-		t.Errorf("Expected 1 result, got %d", len(results))
+	// Each loss takes the $10 and puts a new $10 up
+	simulateDiceRoll(t, table, 2, 2)
+	verifyBetExists(t, table, playerID, "ANY_SEVEN", 10.0)
+	verifyPlayerBankroll(t, table, playerID, 980.0)
+
+	simulateDiceRoll(t, table, 3, 5)
+	verifyBetExists(t, table, playerID, "ANY_SEVEN", 10.0)
+	verifyPlayerBankroll(t, table, playerID, 970.0)
+
+	player, _ := table.GetPlayer(playerID)
+	if player.Stats.BetsPlaced != 3 || player.Stats.TotalLost != 20.0 {
+		t.Errorf("Expected 3 bets placed and $20.00 lost, got %d and $%.2f", player.Stats.BetsPlaced, player.Stats.TotalLost)
+	}
+
+	// Without the bankroll to cover it, a lost bet comes down
+	player.Bankroll = 5.0
+	simulateDiceRoll(t, table, 4, 5)
+	verifyBetNotExists(t, table, playerID, "ANY_SEVEN")
+	verifyPlayerBankroll(t, table, playerID, 5.0)
+
+	// KEEP applies to the bet it is placed with, and a kept bet stops going
+	// back up once the player reaches their loss limit
+	other := players[1]
+	if _, err := executeCrapsQLForPlayer(t, table, other, "SET LOSS_LIMIT $35; PLACE $10 ON ANY_SEVEN; PLACE $10 ON ANY_SEVEN WITH KEEP;"); err != nil {
+		t.Fatalf("Failed to place any seven bets: %v", err)
+	}
+	simulateDiceRoll(t, table, 2, 2)
+	if count := getPlayerBetCount(t, table, other); count != 1 {
+		t.Errorf("Expected only the kept bet back up, got %d bets", count)
+	}
+	verifyPlayerBankroll(t, table, other, 970.0)
+	simulateDiceRoll(t, table, 2, 2)
+	verifyBetExists(t, table, other, "ANY_SEVEN", 10.0)
+	verifyPlayerBankroll(t, table, other, 960.0)
+	simulateDiceRoll(t, table, 2, 2) // Down $40, past the $35 limit
+	verifyBetNotExists(t, table, other, "ANY_SEVEN")
+	verifyPlayerBankroll(t, table, other, 960.0)
+}
+
+func TestWorkingVsNonWorkingBets(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	// SIMPLIFIED TEST: Focus on core craps behavior - field bets are always working (one-roll)
+	// Advanced WORKING/TURN syntax is not implemented yet (parser limitation)
+
+	// Step 1: Place field bet
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON FIELD;")
+	if err != nil {
+		t.Fatalf("Failed to place field bet: %v", err)
 	}
 
-	if !strings.Contains(results[0], "Turned PLACE_6 bet on") {
-		t.Errorf("Expected success message for TURN ON, got: %s", results[0])
+	verifyBetExists(t, table, playerID, "FIELD", 10.0)
+	verifyPlayerBankroll(t, table, playerID, 990.0) // 1000 - 10
+
+	// Step 2: Roll dice - field bet should be resolved (one-roll bet)
+	simulateDiceRoll(t, table, 1, 1) // 2 (field wins 2:1)
+
+	// Field bet should be resolved and removed (won)
+	verifyBetNotExists(t, table, playerID, "FIELD")
+
+	// Bankroll: 990 + 10 (bet) + 20 (2:1 payout) = 1020
+	verifyPlayerBankroll(t, table, playerID, 1020.0)
+
+	t.Logf("✅ Core field bet working behavior verified")
+	t.Logf("⚠️ Advanced WORKING/TURN syntax not implemented yet")
+}
+
+func TestComeOddsUseComePoint(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	simulateDiceRoll(t, table, 3, 3) // Table point 6
+
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON COME;")
+	if err != nil {
+		t.Fatalf("Failed to place come bet: %v", err)
 	}
 
-	// Verify bet is now working again
-	if !place6Bet.Working {
-		t.Errorf("Expected PLACE_6 bet to be turned on, but it's not working")
+	// Odds can't go behind a come bet that hasn't traveled yet
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $20 ON COME_ODDS;"); err == nil {
+		t.Error("Expected error taking come odds before the come point, got nil")
 	}
 
-	// Test turning off/on a different bet type
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON HARD_8;")
+	simulateDiceRoll(t, table, 4, 5) // Come point 9
+
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $20 ON COME_ODDS;")
 	if err != nil {
-		t.Fatalf("Failed to place HARD_8 bet: %v", err)
+		t.Fatalf("Failed to place come odds: %v", err)
 	}
 
-	results, err = executeCrapsQLForPlayer(t, table, playerID, "TURN OFF HARD_8;")
-	if err != nil {
-		t.Fatalf("Failed to turn off HARD_8 bet: %v", err)
+	player, _ := table.GetPlayer(playerID)
+	var come, odds *crapsgame.Bet
+	for _, bet := range player.Bets {
+		switch bet.Type {
+		case "COME":
+			come = bet
+		case "COME_ODDS":
+			odds = bet
+		}
+	}
+	if come == nil || odds == nil {
+		t.Fatalf("Expected come and come odds bets, got %+v", player.Bets)
+	}
+	if odds.ParentBetID != come.ID {
+		t.Errorf("Expected come odds to back %s, got %q", come.ID, odds.ParentBetID)
+	}
+	if len(odds.Numbers) != 1 || odds.Numbers[0] != 9 {
+		t.Errorf("Expected come odds on 9, got %v", odds.Numbers)
 	}
 
-	if !strings.Contains(results[0], "Turned HARD_8 bet off") {
-		t.Errorf("Expected success message for HARD_8 TURN OFF, got: %s", results[0])
+	// The table point (6) doesn't decide the come odds
+	simulateDiceRoll(t, table, 2, 4)
+	verifyBetExists(t, table, playerID, "COME_ODDS", 20.0)
+
+	// 9 pays the come bet even money and the odds 3:2; the point was made, so
+	// the odds are called on for the come-out
+	if err := table.SetCallOddsOnComeOut(playerID, true); err != nil {
+		t.Fatalf("Failed to call the odds on: %v", err)
 	}
+	simulateDiceRoll(t, table, 3, 6)
+	verifyBetNotExists(t, table, playerID, "COME")
+	verifyBetNotExists(t, table, playerID, "COME_ODDS")
+	verifyPlayerBankroll(t, table, playerID, 1040.0) // 970 + 20 (come) + 50 (odds)
 
-	// Test error case - trying to turn non-existent bet
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "TURN ON PLACE_4;")
-	if err == nil {
-		t.Error("Expected error when turning on non-existent bet, got nil")
+	// Don't come odds lay 2:1 against a come point of 4
+	simulateDiceRoll(t, table, 4, 4) // Table point 8
+	executeCrapsQLForPlayer(t, table, players[1], "PLACE $10 ON DONT_COME;")
+	simulateDiceRoll(t, table, 1, 3)
+	_, err = executeCrapsQLForPlayer(t, table, players[1], "PLACE $20 ON DONT_COME_ODDS;")
+	if err != nil {
+		t.Fatalf("Failed to place don't come odds: %v", err)
 	}
+	simulateDiceRoll(t, table, 3, 4)
+	verifyPlayerBankroll(t, table, players[1], 1020.0) // 970 + 20 (don't come) + 30 (odds)
 }
 
-// 6.9 Validation Tests
-func TestBetValidationRules(t *testing.T) {
+func TestOddsIncrementMode(t *testing.T) {
 	table, players := setupTestGame(t)
 	playerID := players[0]
+	table.OddsIncrementMode = crapsgame.OddsStrict
 
-	// Test 1: Validate bet amounts against limits
-	// Try to place bet below minimum
-	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $1 ON PASS_LINE;")
-	if err == nil {
-		t.Error("Expected error when betting below minimum, got nil")
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON PASS_LINE;"); err != nil {
+		t.Fatalf("Failed to place pass line: %v", err)
 	}
+	simulateDiceRoll(t, table, 2, 3) // point 5
 
-	// Try to place bet above maximum
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $2000 ON PASS_LINE;")
+	// $5 behind the 5 would pay $7.50 at 3:2
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $5 ON PASS_ODDS;")
 	if err == nil {
-		t.Error("Expected error when betting above maximum, got nil")
+		t.Fatal("Expected strict mode to reject $5 odds on a 5, got nil")
 	}
+	if !strings.Contains(err.Error(), "would pay $7.50 at 3:2; bet a multiple of $2") {
+		t.Errorf("Expected the breakage to be explained, got %v", err)
+	}
+	verifyBetNotExists(t, table, playerID, "PASS_ODDS")
 
-	// Test 2: Validate bet types against game state
-	// Try to place odds bet without point established
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_ODDS;")
+	// $10 pays a clean $15
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON PASS_ODDS;"); err != nil {
+		t.Fatalf("Failed to place $10 odds: %v", err)
+	}
+	simulateDiceRoll(t, table, 1, 4) // make the 5
+	verifyPlayerBankroll(t, table, playerID, 1000.0+10.0+15.0)
+
+	// Round mode takes $7 down to $6
+	table.OddsIncrementMode = crapsgame.OddsRound
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON PASS_LINE;"); err != nil {
+		t.Fatalf("Failed to place pass line: %v", err)
+	}
+	simulateDiceRoll(t, table, 4, 5) // point 9
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $7 ON PASS_ODDS;"); err != nil {
+		t.Fatalf("Failed to place rounded odds: %v", err)
+	}
+	verifyBetExists(t, table, playerID, "PASS_ODDS", 6.0)
+	verifyPlayerBankroll(t, table, playerID, 1025.0-10.0-6.0)
+}
+
+func TestMaxOddsEnforcement(t *testing.T) {
+	table, players := setupTestGame(t) // 3x odds table
+	playerID := players[0]
+
+	executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_LINE;")
+	simulateDiceRoll(t, table, 3, 3) // Point 6
+
+	// No backing line bet
+	_, err := executeCrapsQLForPlayer(t, table, players[2], "PLACE $30 ON PASS_ODDS;")
+	if err == nil || !strings.Contains(err.Error(), "requires a working PASS_LINE") {
+		t.Errorf("Expected missing line bet error, got %v", err)
+	}
+
+	// One dollar over 3x
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $76 ON PASS_ODDS;")
+	if err == nil || !strings.Contains(err.Error(), "3x odds") {
+		t.Errorf("Expected max odds error for $76 behind $25, got %v", err)
+	}
+
+	// Exactly 3x
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $75 ON PASS_ODDS;")
+	if err != nil {
+		t.Fatalf("Expected $75 odds behind $25 to be accepted, got %v", err)
+	}
+	verifyBetExists(t, table, playerID, "PASS_ODDS", 75.0)
+
+	// Further odds count against the same limit
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $5 ON PASS_ODDS;"); err == nil {
+		t.Error("Expected error adding odds past the limit, got nil")
+	}
+
+	// Come odds are limited by their own come bet
+	executeCrapsQLForPlayer(t, table, players[1], "PLACE $10 ON COME;")
+	simulateDiceRoll(t, table, 4, 5) // Come point 9
+	if _, err := executeCrapsQLForPlayer(t, table, players[1], "PLACE $31 ON COME_ODDS;"); err == nil {
+		t.Error("Expected error for $31 come odds behind $10, got nil")
+	}
+	if _, err := executeCrapsQLForPlayer(t, table, players[1], "PLACE $30 ON COME_ODDS;"); err != nil {
+		t.Errorf("Expected $30 come odds behind $10 to be accepted, got %v", err)
+	}
+}
+
+// 6.5 Bankroll and Limits Tests
+func TestBankrollManagement(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	// Test 1: Bet exceeding bankroll
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $2000 ON PASS_LINE;")
 	if err == nil {
-		t.Error("Expected error when placing odds bet without point, got nil")
+		t.Error("Expected error when betting more than bankroll, got nil")
 	}
 
-	// Test 3: Validate player permissions
-	// Try to place bet for non-existent player
-	_, err = executeCrapsQLForPlayer(t, table, "nonexistent", "PLACE $25 ON PASS_LINE;")
+	// Verify bankroll unchanged
+	verifyPlayerBankroll(t, table, playerID, 1000.0)
+
+	// Test 2: Multiple bets totaling more than bankroll
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $600 ON PASS_LINE;")
+	if err != nil {
+		t.Fatalf("Failed to place first bet: %v", err)
+	}
+
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $600 ON FIELD;")
 	if err == nil {
-		t.Error("Expected error when placing bet for non-existent player, got nil")
+		t.Error("Expected error when second bet would exceed bankroll, got nil")
 	}
 
-	// Test 4: Validate bet combinations
-	// Place valid bet
+	// Verify only first bet was placed
+	verifyBetExists(t, table, playerID, "PASS_LINE", 600.0)
+	verifyBetNotExists(t, table, playerID, "FIELD")
+	verifyPlayerBankroll(t, table, playerID, 400.0) // 1000 - 600
+
+	// Test 3: Win/lose scenarios
+	// Win the pass line bet
+	simulateDiceRoll(t, table, 3, 4) // 7
+	verifyBetNotExists(t, table, playerID, "PASS_LINE")
+	verifyPlayerBankroll(t, table, playerID, 1600.0) // 400 + 600 (bet returned) + 600 (win)
+
+	// Test 4: Lose a bet
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $100 ON PASS_LINE;")
+	if err != nil {
+		t.Fatalf("Failed to place bet for loss test: %v", err)
+	}
+
+	simulateDiceRoll(t, table, 1, 1) // 2 (craps)
+	verifyBetNotExists(t, table, playerID, "PASS_LINE")
+	verifyPlayerBankroll(t, table, playerID, 1500.0) // 1600 - 100 (bet lost)
+}
+
+func TestBetLimitsEnforcement(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	// Test 1: Bet below minimum
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $1 ON PASS_LINE;")
+	if err == nil {
+		t.Error("Expected error when betting below minimum, got nil")
+	} else {
+		t.Logf("✅ Below minimum correctly rejected: %v", err)
+	}
+
+	// Test 2: Bet above maximum
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $2000 ON PASS_LINE;")
+	if err == nil {
+		t.Error("Expected error when betting above maximum, got nil")
+	} else {
+		t.Logf("✅ Above maximum correctly rejected: %v", err)
+	}
+
+	// Test 3: Valid bet within limits
 	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_LINE;")
 	if err != nil {
 		t.Fatalf("Failed to place valid bet: %v", err)
+	} else {
+		t.Logf("✅ Valid bet correctly accepted")
 	}
 
-	// Try to place same bet type again (should be allowed in some cases)
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_LINE;")
+	// Test 4: Player-specific limits are set through SET
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "SET MAX_BET $100; SET MIN_BET $10;")
 	if err != nil {
-		t.Logf("Note: Placing duplicate bet types is not allowed: %v", err)
+		t.Fatalf("Failed to set player limits: %v", err)
+	}
+	player, _ := table.GetPlayer(playerID)
+	if player.MaxBet != 100.0 || player.MinBet != 10.0 {
+		t.Errorf("Expected player limits $10-$100, got $%.2f-$%.2f", player.MinBet, player.MaxBet)
 	}
+}
 
-	// Test 5: Validate bet amounts against bankroll
-	// Set bankroll to small amount
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "SET BANKROLL $50;")
+func TestPlayerBetLimitsEnforcement(t *testing.T) {
+	table, players := setupTestGame(t) // $5-$1000 table
+	playerID := players[0]
+
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "SET MAX_BET $50; SET MIN_BET $10;")
 	if err != nil {
-		t.Fatalf("Failed to set bankroll: %v", err)
+		t.Fatalf("Failed to set player limits: %v", err)
 	}
 
-	// Try to place bet exceeding bankroll
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $100 ON FIELD;")
-	if err == nil {
-		t.Error("Expected error when betting more than bankroll, got nil")
+	// Within the table max but above the player's own max
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $100 ON PASS_LINE;")
+	if err == nil || !strings.Contains(err.Error(), "player maximum") {
+		t.Errorf("Expected player maximum error, got %v", err)
+	}
+
+	// Above the table min but below the player's own min
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $5 ON PASS_LINE;")
+	if err == nil || !strings.Contains(err.Error(), "player minimum") {
+		t.Errorf("Expected player minimum error, got %v", err)
+	}
+
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $50 ON PASS_LINE;")
+	if err != nil {
+		t.Errorf("Expected bet at the player max to be accepted, got %v", err)
+	}
+	verifyBetExists(t, table, playerID, "PASS_LINE", 50.0)
+
+	// A player max above the table max doesn't loosen the table limit
+	executeCrapsQLForPlayer(t, table, players[1], "SET MAX_BET $5000;")
+	if _, err := executeCrapsQLForPlayer(t, table, players[1], "PLACE $2000 ON FIELD;"); err == nil {
+		t.Error("Expected table maximum to still apply, got nil")
+	}
+
+	// Other players keep the table limits
+	if _, err := executeCrapsQLForPlayer(t, table, players[2], "PLACE $100 ON PASS_LINE;"); err != nil {
+		t.Errorf("Expected other player's $100 bet to be accepted, got %v", err)
 	}
 }
 
-func TestGameStateValidation(t *testing.T) {
+func TestGetBetByID(t *testing.T) {
+	table, players := setupTestGame(t)
+
+	placed, err := table.PlaceBet(players[1], "FIELD", 10.0, nil)
+	if err != nil {
+		t.Fatalf("Failed to place field bet: %v", err)
+	}
+
+	bet, player, err := table.GetBet(placed.ID)
+	if err != nil {
+		t.Fatalf("Expected to find bet %s, got %v", placed.ID, err)
+	}
+	if bet != placed {
+		t.Errorf("Expected the placed bet, got %+v", bet)
+	}
+	if player.ID != players[1] {
+		t.Errorf("Expected bet owner %s, got %s", players[1], player.ID)
+	}
+
+	if _, _, err := table.GetBet("bet_UNKNOWN"); err == nil {
+		t.Error("Expected error for unknown bet ID, got nil")
+	}
+}
+
+func TestReduceBet(t *testing.T) {
 	table, players := setupTestGame(t)
 	playerID := players[0]
 
-	// Test 1: Validate state transitions
-	// Start in come out state
-	verifyGameState(t, table, crapsgame.StateComeOut, crapsgame.PointOff)
+	if _, err := table.PlaceBet(playerID, "PLACE_6", 24.0, []int{6}); err != nil {
+		t.Fatalf("Failed to place bet on 6: %v", err)
+	}
+
+	if err := table.ReduceBet(playerID, "PLACE_6", 12.0); err != nil {
+		t.Fatalf("Failed to reduce place 6: %v", err)
+	}
+	verifyBetExists(t, table, playerID, "PLACE_6", 12.0)
+	verifyPlayerBankroll(t, table, playerID, 988.0)
+
+	// $12 - $10 would leave $2, below the $5 table minimum
+	if err := table.ReduceBet(playerID, "PLACE_6", 10.0); err == nil {
+		t.Error("Expected error reducing below the table minimum, got nil")
+	}
+	if err := table.ReduceBet(playerID, "PLACE_6", 20.0); err == nil {
+		t.Error("Expected error reducing by more than the bet, got nil")
+	}
+	if err := table.ReduceBet(playerID, "PLACE_8", 5.0); err == nil {
+		t.Error("Expected error reducing a bet that doesn't exist, got nil")
+	}
+	verifyBetExists(t, table, playerID, "PLACE_6", 12.0)
+	verifyPlayerBankroll(t, table, playerID, 988.0)
+
+	// The player's own minimum applies to what's left up
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "SET MIN_BET $10;"); err != nil {
+		t.Fatalf("Failed to set min bet: %v", err)
+	}
+	if err := table.ReduceBet(playerID, "PLACE_6", 6.0); err == nil {
+		t.Error("Expected error reducing below the player minimum, got nil")
+	}
+
+	// A pass line bet is a contract once the point is on
+	_, err := executeCrapsQLForPlayer(t, table, players[1], "PLACE $20 ON PASS_LINE; PLACE $20 ON DONT_PASS;")
+	if err != nil {
+		t.Fatalf("Failed to place line bets: %v", err)
+	}
+	simulateDiceRoll(t, table, 2, 2) // Point 4
+	if err := table.ReduceBet(players[1], "PASS_LINE", 10.0); err == nil {
+		t.Error("Expected error reducing a pass line bet on a point, got nil")
+	}
+
+	// The don't pass can come down, but not below what its odds need
+	if _, err := executeCrapsQLForPlayer(t, table, players[1], "PLACE $60 ON DONT_PASS_ODDS;"); err != nil {
+		t.Fatalf("Failed to lay odds: %v", err)
+	}
+	if err := table.ReduceBet(players[1], "DONT_PASS", 10.0); err == nil {
+		t.Error("Expected error leaving $60 odds behind a $10 don't pass, got nil")
+	}
+	verifyBetExists(t, table, players[1], "PASS_LINE", 20.0)
+	verifyBetExists(t, table, players[1], "DONT_PASS", 20.0)
+	verifyPlayerBankroll(t, table, players[1], 900.0)
+}
+
+func TestMaxComeBets(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+	table.MaxComeBets = 2
 
-	// Place pass line bet and establish point
 	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_LINE;")
 	if err != nil {
 		t.Fatalf("Failed to place pass line bet: %v", err)
 	}
+	simulateDiceRoll(t, table, 3, 3) // Point 6
 
-	simulateDiceRoll(t, table, 3, 3) // 6
-	verifyGameState(t, table, crapsgame.StatePoint, crapsgame.Point6)
+	for i := 0; i < 2; i++ {
+		if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON COME;"); err != nil {
+			t.Fatalf("Expected come bet %d to be accepted, got %v", i+1, err)
+		}
+	}
 
-	// Test 2: Validate point establishment
-	if table.GetPointNumber() != 6 {
-		t.Errorf("Expected point number 6, got %d", table.GetPointNumber())
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON DONT_COME;")
+	if err == nil {
+		t.Fatal("Expected third come bet to be rejected, got nil")
+	}
+	if !strings.Contains(err.Error(), "maximum of 2 come bets") {
+		t.Errorf("Expected come bet limit error, got %v", err)
 	}
+	verifyPlayerBankroll(t, table, playerID, 1000.0-25.0-20.0)
 
-	// Test 3: Validate bet resolution timing
-	// Pass line bet should remain active during point phase
-	verifyBetExists(t, table, playerID, "PASS_LINE", 25.0)
+	// The cap is per player
+	if _, err := executeCrapsQLForPlayer(t, table, players[1], "PLACE $10 ON COME;"); err != nil {
+		t.Errorf("Expected another player's come bet to be accepted, got %v", err)
+	}
+}
 
-	// Resolve point
-	simulateDiceRoll(t, table, 2, 4) // 6
-	verifyGameState(t, table, crapsgame.StateComeOut, crapsgame.PointOff)
-	verifyBetNotExists(t, table, playerID, "PASS_LINE")
+func TestRemainingAction(t *testing.T) {
+	table, players := setupTestGame(t) // $5-$1000 table, $1000 bankrolls
+	playerID := players[0]
 
-	// Test 4: Validate player state consistency
-	player, err := table.GetPlayer(playerID)
+	// Bankroll and table max both allow $1000
+	if remaining := table.RemainingAction(playerID); remaining != 1000.0 {
+		t.Errorf("Expected $1000 remaining, got $%.2f", remaining)
+	}
+
+	// Bankroll becomes the limit once money is on the layout
+	executeCrapsQLForPlayer(t, table, playerID, "PLACE $100 ON PASS_LINE;")
+	if remaining := table.RemainingAction(playerID); remaining != 900.0 {
+		t.Errorf("Expected $900 remaining, got $%.2f", remaining)
+	}
+
+	// A player max below the table max is the tighter limit
+	executeCrapsQLForPlayer(t, table, playerID, "SET MAX_BET $250;")
+	if remaining := table.RemainingAction(playerID); remaining != 250.0 {
+		t.Errorf("Expected $250 remaining with player max, got $%.2f", remaining)
+	}
+
+	// Exposure cap counts what is already on the layout
+	table.MaxExposure = 300
+	if remaining := table.RemainingAction(playerID); remaining != 200.0 {
+		t.Errorf("Expected $200 remaining under the exposure cap, got $%.2f", remaining)
+	}
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $250 ON FIELD;"); err == nil {
+		t.Error("Expected error exceeding the exposure cap, got nil")
+	}
+	executeCrapsQLForPlayer(t, table, playerID, "PLACE $200 ON FIELD;")
+	if remaining := table.RemainingAction(playerID); remaining != 0 {
+		t.Errorf("Expected $0 remaining at the exposure cap, got $%.2f", remaining)
+	}
+
+	output, err := executeCrapsQLForPlayer(t, table, players[1], "SHOW REMAINING ACTION;")
 	if err != nil {
-		t.Fatalf("Failed to get player: %v", err)
+		t.Fatalf("SHOW REMAINING ACTION failed: %v", err)
+	}
+	if len(output) != 1 || !strings.Contains(output[0], "Remaining Action: $300.00") {
+		t.Errorf("Unexpected SHOW REMAINING ACTION output: %v", output)
+	}
+
+	if remaining := table.RemainingAction("nobody"); remaining != 0 {
+		t.Errorf("Expected $0 for unknown player, got $%.2f", remaining)
+	}
+}
+
+func TestWarnHighEdge(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	// Off by default
+	bet, err := table.PlaceBet(playerID, "ANY_SEVEN", 10.0, nil)
+	if err != nil {
+		t.Fatalf("Failed to place bet: %v", err)
+	}
+	if bet.Advisory != "" {
+		t.Errorf("Expected no advisory with warnings off, got %q", bet.Advisory)
+	}
+
+	table.WarnHighEdge = 5.0
+
+	// A high-edge bet is still placed, with an advisory
+	output, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON ANY_SEVEN;")
+	if err != nil {
+		t.Fatalf("Expected high-edge bet to be placed, got %v", err)
+	}
+	result := strings.Join(output, "\n")
+	if !strings.Contains(result, "dealer suggests: ANY_SEVEN has a 16.67% house edge") {
+		t.Errorf("Expected advisory in output, got %q", result)
+	}
+	verifyPlayerBankroll(t, table, playerID, 980.0)
+
+	// A low-edge bet carries no advisory
+	bet, err = table.PlaceBet(playerID, "PASS_LINE", 10.0, nil)
+	if err != nil {
+		t.Fatalf("Failed to place bet: %v", err)
+	}
+	if bet.Advisory != "" {
+		t.Errorf("Expected no advisory on PASS_LINE, got %q", bet.Advisory)
+	}
+}
+
+func TestTotalWorkingWager(t *testing.T) {
+	table, players := setupTestGame(t)
+
+	if _, err := executeCrapsQLForPlayer(t, table, players[0], "PLACE $10 ON PASS_LINE;"); err != nil {
+		t.Fatalf("Failed to place pass line: %v", err)
+	}
+	simulateDiceRoll(t, table, 2, 2) // Point 4
+
+	bets := []struct {
+		playerID  string
+		statement string
+	}{
+		{players[0], "PLACE $12 ON PLACE_6;"},
+		{players[1], "PLACE $18 ON PLACE_8;"},
+		{players[2], "PLACE $5 ON FIELD;"},
+	}
+	for _, b := range bets {
+		if _, err := executeCrapsQLForPlayer(t, table, b.playerID, b.statement); err != nil {
+			t.Fatalf("Failed to execute %q: %v", b.statement, err)
+		}
+	}
+
+	// The second player's place bet is off and doesn't count
+	if err := table.TurnBet(players[1], "PLACE_8", false); err != nil {
+		t.Fatalf("Failed to turn off place 8: %v", err)
+	}
+
+	if total := table.TotalWorkingWager(); total != 27.0 {
+		t.Errorf("Expected $27.00 working, got $%.2f", total)
+	}
+
+	output, err := executeCrapsQLForPlayer(t, table, players[0], "SHOW TABLE TOTAL;")
+	if err != nil {
+		t.Fatalf("Failed to show table total: %v", err)
+	}
+	if output[0] != "Table Total Working: $27.00" {
+		t.Errorf("Expected table total output, got %q", output[0])
 	}
+}
+
+func TestBankrollSnapshotDelta(t *testing.T) {
+	table, players := setupTestGame(t)
+
+	executeCrapsQLForPlayer(t, table, players[0], "PLACE $25 ON PASS_LINE;")
+	executeCrapsQLForPlayer(t, table, players[1], "PLACE $10 ON DONT_PASS;")
+
+	before := table.BankrollSnapshot()
+	expectedBefore := map[string]float64{players[0]: 975.0, players[1]: 990.0, players[2]: 1000.0}
+	if !reflect.DeepEqual(before, expectedBefore) {
+		t.Fatalf("Expected snapshot %v, got %v", expectedBefore, before)
+	}
+
+	simulateDiceRoll(t, table, 3, 4) // Natural: pass wins, don't pass loses
+
+	delta := crapsgame.BankrollDelta(before, table.BankrollSnapshot())
+	expected := map[string]float64{players[0]: 50.0, players[1]: 0.0, players[2]: 0.0}
+	if !reflect.DeepEqual(delta, expected) {
+		t.Errorf("Expected delta %v, got %v", expected, delta)
+	}
+
+	// A player who leaves shows their whole bankroll going
+	before = table.BankrollSnapshot()
+	table.RemovePlayer(players[2])
+	delta = crapsgame.BankrollDelta(before, table.BankrollSnapshot())
+	if delta[players[2]] != -1000.0 {
+		t.Errorf("Expected departed player's delta to be -1000, got %.2f", delta[players[2]])
+	}
+}
+
+func TestBankrollWholeCents(t *testing.T) {
+	table, players := setupTestGame(t)
+	table.MaxBet = 100000.0
+
+	simulateDiceRoll(t, table, 4, 4) // Point 8
+	executeCrapsQLForPlayer(t, table, players[0], "PLACE $15 ON PLACE_6;")
+	executeCrapsQLForPlayer(t, table, players[1], "PLACE $10 ON PLACE_6;")
+
+	// $15 at 7:6 pays $17.50; $10 pays $11.66 with the fraction of a cent kept by the house
+	for n := 0; n < 1000; n++ {
+		simulateDiceRoll(t, table, 3, 3)
+	}
+
+	player1, _ := table.GetPlayer(players[0])
+	if player1.Bankroll != 18485.00 {
+		t.Errorf("Expected exactly $18485.00 after 1000 place 6 wins, got %.10f", player1.Bankroll)
+	}
+	player2, _ := table.GetPlayer(players[1])
+	if player2.Bankroll != 12650.00 {
+		t.Errorf("Expected exactly $12650.00 after 1000 place 6 wins, got %.10f", player2.Bankroll)
+	}
+	if player1.Stats.TotalWon != 17500.00 {
+		t.Errorf("Expected exactly $17500.00 won, got %.10f", player1.Stats.TotalWon)
+	}
+
+	ten := crapsgame.ToMoney(10.0)
+	if got := ten.MulRatio(7, 6); got != 1166 || got.String() != "$11.66" {
+		t.Errorf("Expected $10 at 7:6 to pay $11.66, got %s", got)
+	}
+	if got := crapsgame.ToMoney(25.50).Sub(crapsgame.ToMoney(30)); got.String() != "-$4.50" {
+		t.Errorf("Expected -$4.50, got %s", got)
+	}
+}
+
+// 6.6 Multiple Player Scenarios
+func TestMultiplePlayerGameplay(t *testing.T) {
+	table, players := setupTestGame(t)
+
+	// Each player places different types of bets
+	_, err := executeCrapsQLForPlayer(t, table, players[0], "PLACE $25 ON PASS_LINE;")
+	if err != nil {
+		t.Fatalf("Failed to place bet for player 1: %v", err)
+	}
+
+	_, err = executeCrapsQLForPlayer(t, table, players[1], "PLACE $20 ON DONT_PASS;")
+	if err != nil {
+		t.Fatalf("Failed to place bet for player 2: %v", err)
+	}
+
+	_, err = executeCrapsQLForPlayer(t, table, players[2], "PLACE $15 ON FIELD;")
+	if err != nil {
+		t.Fatalf("Failed to place bet for player 3: %v", err)
+	}
+
+	// Verify all bets were placed
+	verifyBetExists(t, table, players[0], "PASS_LINE", 25.0)
+	verifyBetExists(t, table, players[1], "DONT_PASS", 20.0)
+	verifyBetExists(t, table, players[2], "FIELD", 15.0)
+
+	// Verify bankrolls were deducted
+	verifyPlayerBankroll(t, table, players[0], 975.0) // 1000 - 25
+	verifyPlayerBankroll(t, table, players[1], 980.0) // 1000 - 20
+	verifyPlayerBankroll(t, table, players[2], 985.0) // 1000 - 15
+
+	// Roll dice and verify bet resolution
+	simulateDiceRoll(t, table, 3, 4) // 7
+
+	// Verify all bets were resolved
+	verifyBetNotExists(t, table, players[0], "PASS_LINE")
+	verifyBetNotExists(t, table, players[1], "DONT_PASS")
+	verifyBetNotExists(t, table, players[2], "FIELD")
+
+	// Verify bankroll updates (pass line wins, don't pass loses, field loses)
+	verifyPlayerBankroll(t, table, players[0], 1025.0) // 975 + 25 (bet returned) + 25 (win)
+	verifyPlayerBankroll(t, table, players[1], 980.0)  // 980 - 20 (bet lost)
+	verifyPlayerBankroll(t, table, players[2], 985.0)  // 985 - 15 (bet lost)
+}
+
+func TestConcurrentBetPlacement(t *testing.T) {
+	table, players := setupTestGame(t)
+
+	// Test that multiple players can place bets without interference
+	// This is a basic test - in a real concurrent environment, you'd use goroutines
+
+	// Player 1 places bet
+	_, err := executeCrapsQLForPlayer(t, table, players[0], "PLACE $25 ON PASS_LINE;")
+	if err != nil {
+		t.Fatalf("Failed to place bet for player 1: %v", err)
+	}
+
+	// Player 2 places bet
+	_, err = executeCrapsQLForPlayer(t, table, players[1], "PLACE $20 ON FIELD;")
+	if err != nil {
+		t.Fatalf("Failed to place bet for player 2: %v", err)
+	}
+
+	// Player 3 places bet
+	_, err = executeCrapsQLForPlayer(t, table, players[2], "PLACE $15 ON ANY_SEVEN;")
+	if err != nil {
+		t.Fatalf("Failed to place bet for player 3: %v", err)
+	}
+
+	// Verify all bets were recorded correctly
+	verifyBetExists(t, table, players[0], "PASS_LINE", 25.0)
+	verifyBetExists(t, table, players[1], "FIELD", 20.0)
+	verifyBetExists(t, table, players[2], "ANY_SEVEN", 15.0)
+
+	// Verify total bet count
+	if getPlayerBetCount(t, table, players[0]) != 1 {
+		t.Errorf("Expected 1 bet for player 1, got %d", getPlayerBetCount(t, table, players[0]))
+	}
+	if getPlayerBetCount(t, table, players[1]) != 1 {
+		t.Errorf("Expected 1 bet for player 2, got %d", getPlayerBetCount(t, table, players[1]))
+	}
+	if getPlayerBetCount(t, table, players[2]) != 1 {
+		t.Errorf("Expected 1 bet for player 3, got %d", getPlayerBetCount(t, table, players[2]))
+	}
+}
+
+// 6.7 Error Handling and Edge Cases
+func TestInvalidGameStateOperations(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	// Test 1: Try to place odds bet without point established
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_ODDS;")
+	if err == nil {
+		t.Error("Expected error when placing odds bet without point established, got nil")
+	}
+
+	// Test 2: Try to place come bet during come out roll
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON COME;")
+	if err == nil {
+		t.Error("Expected error when placing come bet during come out roll, got nil")
+	}
+
+	// Test 3: Try to remove non-existent bet
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "REMOVE PASS_LINE;")
+	if err == nil {
+		t.Error("Expected error when removing non-existent bet, got nil")
+	}
+
+	// Test 4: Try to place bet for non-existent player
+	_, err = executeCrapsQLForPlayer(t, table, "nonexistent", "PLACE $25 ON PASS_LINE;")
+	if err == nil {
+		t.Error("Expected error when placing bet for non-existent player, got nil")
+	}
+
+	// Test 5: Try to place bet with invalid amount
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $0 ON PASS_LINE;")
+	if err == nil {
+		t.Error("Expected error when placing bet with zero amount, got nil")
+	}
+
+	// Test 6: Try to place bet with negative amount
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $-25 ON PASS_LINE;")
+	if err == nil {
+		t.Error("Expected error when placing bet with negative amount, got nil")
+	} else if !strings.Contains(err.Error(), "bet amount must be positive") {
+		t.Errorf("Expected a positive amount error, got %v", err)
+	}
+
+	// Verify game state remains consistent
+	verifyGameState(t, table, crapsgame.StateComeOut, crapsgame.PointOff)
+	verifyPlayerBankroll(t, table, playerID, 1000.0)
+}
+
+func TestEdgeCaseScenarios(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	// Test 1: Player with zero bankroll
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "SET BANKROLL $0;")
+	if err != nil {
+		t.Fatalf("Failed to set bankroll to zero: %v", err)
+	}
+
+	// Try to place bet with zero bankroll
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_LINE;")
+	if err == nil {
+		t.Error("Expected error when placing bet with zero bankroll, got nil")
+	}
+
+	// Test 2: Very large bet amounts
+	// Reset bankroll
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "SET BANKROLL $1000000;")
+	if err != nil {
+		t.Fatalf("Failed to set large bankroll: %v", err)
+	}
+
+	// Try to place very large bet
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $999999 ON PASS_LINE;")
+	if err == nil {
+		t.Error("Expected error when placing bet exceeding table maximum, got nil")
+	}
+
+	// Test 3: Rapid state transitions
+	// Place bet
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_LINE;")
+	if err != nil {
+		t.Fatalf("Failed to place bet: %v", err)
+	}
+
+	// Rapid rolls
+	for i := 0; i < 10; i++ {
+		simulateDiceRoll(t, table, 3, 4) // 7
+		// Place new bet immediately
+		_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_LINE;")
+		if err != nil {
+			t.Fatalf("Failed to place bet after rapid roll %d: %v", i, err)
+		}
+	}
+
+	// Test 4: All players removed during game
+	// Remove all players
+	for _, player := range players {
+		err := table.RemovePlayer(player)
+		if err != nil {
+			t.Fatalf("Failed to remove player %s: %v", player, err)
+		}
+	}
+
+	// Try to place bet with no players
+	_, err = executeCrapsQL(t, table, "PLACE $25 ON PASS_LINE;")
+	if err == nil {
+		t.Error("Expected error when placing bet with no players, got nil")
+	}
+
+	// Test 5: Invalid bet types
+	// Add a player back
+	err = table.AddPlayer("newplayer", "New Player", 1000.0)
+	if err != nil {
+		t.Fatalf("Failed to add new player: %v", err)
+	}
+
+	_, err = executeCrapsQLForPlayer(t, table, "newplayer", "PLACE $25 ON INVALID_BET_TYPE;")
+	if err == nil {
+		t.Error("Expected error when placing invalid bet type, got nil")
+	}
+}
+
+func TestBetRemovalAndModification(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	// SIMPLIFIED TEST: Focus on core bet placement/resolution mechanics
+	// REMOVE and PRESS commands are advanced language features not implemented yet
+
+	// Place multiple bets
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_LINE;")
+	if err != nil {
+		t.Fatalf("Failed to place pass line bet: %v", err)
+	}
+
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $20 ON FIELD;")
+	if err != nil {
+		t.Fatalf("Failed to place field bet: %v", err)
+	}
+
+	// Verify both bets exist
+	verifyBetExists(t, table, playerID, "PASS_LINE", 25.0)
+	verifyBetExists(t, table, playerID, "FIELD", 20.0)
+	verifyPlayerBankroll(t, table, playerID, 955.0) // 1000 - 25 - 20
+
+	// Test that bets resolve correctly via dice rolls (core game logic)
+	t.Logf("Before roll: Game state = %v", table.State)
+	simulateDiceRoll(t, table, 1, 1) // 2 (field wins 2:1, pass line LOSES on come out!)
+	t.Logf("After roll: Game state = %v", table.State)
+
+	// Field should be resolved (one-roll bet wins), pass line should be REMOVED (loses on craps 2!)
+	verifyBetNotExists(t, table, playerID, "FIELD")
+	verifyBetNotExists(t, table, playerID, "PASS_LINE") // PASS LINE LOSES ON CRAPS 2!
+
+	// Bankroll: 955 + 20 (field bet back) + 40 (field 2:1 payout) - 0 (pass line lost) = 1015
+	verifyPlayerBankroll(t, table, playerID, 1015.0)
+
+	t.Logf("✅ Core bet placement and resolution verified")
+	t.Logf("⚠️ REMOVE/PRESS commands not implemented yet")
+}
+
+func TestPressToAndFullPress(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $12 ON PLACE_6;"); err != nil {
+		t.Fatalf("Failed to place 6: %v", err)
+	}
+
+	// BY adds a flat amount
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PRESS PLACE_6 BY $6;"); err != nil {
+		t.Fatalf("PRESS BY failed: %v", err)
+	}
+	verifyBetExists(t, table, playerID, "PLACE_6", 18.0)
+	verifyPlayerBankroll(t, table, playerID, 1000.0-18.0)
+
+	// TO draws only the difference
+	results, err := executeCrapsQLForPlayer(t, table, playerID, "PRESS PLACE_6 TO $30;")
+	if err != nil {
+		t.Fatalf("PRESS TO failed: %v", err)
+	}
+	if len(results) != 1 || results[0] != "✅ Pressed PLACE_6 bet to $30.00" {
+		t.Errorf("Unexpected PRESS TO output: %v", results)
+	}
+	verifyBetExists(t, table, playerID, "PLACE_6", 30.0)
+	verifyPlayerBankroll(t, table, playerID, 1000.0-30.0)
+
+	// FULL doubles the bet
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PRESS PLACE_6 FULL;"); err != nil {
+		t.Fatalf("PRESS FULL failed: %v", err)
+	}
+	verifyBetExists(t, table, playerID, "PLACE_6", 60.0)
+	verifyPlayerBankroll(t, table, playerID, 1000.0-60.0)
+
+	// A TO press the bankroll can't cover leaves the bet alone
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "SET BANKROLL $100; PRESS PLACE_6 TO $300;"); err == nil {
+		t.Error("Expected error pressing beyond the bankroll, got nil")
+	}
+	verifyBetExists(t, table, playerID, "PLACE_6", 60.0)
+	verifyPlayerBankroll(t, table, playerID, 100.0)
+
+	// TO can't take a bet down
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PRESS PLACE_6 TO $30;"); err == nil {
+		t.Error("Expected error pressing to less than the bet, got nil")
+	}
+
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PRESS PLACE_6 UP $30;"); err == nil {
+		t.Error("Expected parse error for an unknown press form, got nil")
+	}
+
+	// A press is held to the same limits as a new bet: odds stay within 3x
+	// and a bet can't go past the player's own maximum
+	other := players[1]
+	_, err = executeCrapsQLForPlayer(t, table, other, "PLACE $10 ON PASS_LINE;")
+	if err != nil {
+		t.Fatalf("Failed to place pass line: %v", err)
+	}
+	simulateDiceRoll(t, table, 2, 2) // Point 4
+	_, err = executeCrapsQLForPlayer(t, table, other, "PLACE $20 ON PASS_ODDS; SET MAX_BET $50; PLACE $30 ON PLACE_8;")
+	if err != nil {
+		t.Fatalf("Failed to place bets: %v", err)
+	}
+	if _, err := executeCrapsQLForPlayer(t, table, other, "PRESS PASS_ODDS FULL;"); err == nil {
+		t.Error("Expected error pressing odds past 3x, got nil")
+	}
+	if _, err := executeCrapsQLForPlayer(t, table, other, "PRESS PLACE_8 TO $60;"); err == nil {
+		t.Error("Expected error pressing past the player's max bet, got nil")
+	}
+	verifyBetExists(t, table, other, "PASS_ODDS", 20.0)
+	verifyBetExists(t, table, other, "PLACE_8", 30.0)
+	verifyPlayerBankroll(t, table, other, 1000.0-60.0)
+}
+
+func TestConditionalStatements(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	// SIMPLIFIED TEST: Focus on core game logic - bankroll validation when placing bets
+	// IF statement functionality is not fully implemented yet (language feature)
+
+	// Test that core bankroll validation works when placing bets
+	// Player has $1000 bankroll
+
+	// Should succeed - bet within bankroll
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_LINE;")
+	if err != nil {
+		t.Fatalf("Failed to place bet within bankroll: %v", err)
+	}
+	verifyBetExists(t, table, playerID, "PASS_LINE", 25.0)
+	verifyPlayerBankroll(t, table, playerID, 975.0)
+
+	// Should fail - bet exceeds bankroll
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $2000 ON FIELD;")
+	if err == nil {
+		t.Error("Expected error when betting more than bankroll, got nil")
+	} else {
+		t.Logf("✅ Bankroll validation correctly rejected excessive bet: %v", err)
+	}
+
+	// Field bet should not exist (was rejected)
+	verifyBetNotExists(t, table, playerID, "FIELD")
+
+	t.Logf("✅ Core bankroll validation working correctly")
+	t.Logf("⚠️ IF statement syntax not fully implemented yet")
+}
+
+// 6.8 Interpreter Integration Tests
+func TestInterpreterStatementExecution(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	// Test 1: Place bet statement
+	results, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_LINE;")
+	if err != nil {
+		t.Fatalf("Failed to execute bet statement: %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("Expected 1 result, got %d", len(results))
+	}
+	if !strings.Contains(results[0], "✅ Placed $25.00 on PASS_LINE") {
+		t.Errorf("Expected success message, got: %s", results[0])
+	}
+
+	// Verify bet was placed
+	verifyBetExists(t, table, playerID, "PASS_LINE", 25.0)
+	verifyPlayerBankroll(t, table, playerID, 975.0) // 1000 - 25
+
+	// Test 2: Show point statement
+	results, err = executeCrapsQL(t, table, "SHOW POINT;")
+	if err != nil {
+		t.Fatalf("Failed to execute show point statement: %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("Expected 1 result, got %d", len(results))
+	}
+	if results[0] != "Point: OFF" {
+		t.Errorf("Expected 'Point: OFF', got: %s", results[0])
+	}
+
+	// Test 3: Set bankroll statement
+	results, err = executeCrapsQLForPlayer(t, table, playerID, "SET BANKROLL $2000;")
+	if err != nil {
+		t.Fatalf("Failed to execute set bankroll statement: %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("Expected 1 result, got %d", len(results))
+	}
+	if !strings.Contains(results[0], "Set bankroll to") {
+		t.Errorf("Expected bankroll update message, got: %s", results[0])
+	}
+
+	// Verify bankroll was updated
+	verifyPlayerBankroll(t, table, playerID, 2000.0)
+}
+
+func TestInterpreterErrorHandling(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	// Test 1: Invalid bet type
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON INVALID_BET;")
+	if err == nil {
+		t.Error("Expected error for invalid bet type, got nil")
+	}
+
+	// Test 2: Invalid amount (negative)
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $-25 ON PASS_LINE;")
+	if err == nil {
+		t.Error("Expected error for negative amount, got nil")
+	}
+
+	// Test 3: Invalid syntax
+	_, err = executeCrapsQL(t, table, "INVALID STATEMENT;")
+	if err == nil {
+		t.Error("Expected error for invalid syntax, got nil")
+	}
+
+	// Test 4: Bet amount exceeds bankroll
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $2000 ON PASS_LINE;")
+	if err == nil {
+		t.Error("Expected error for bet exceeding bankroll, got nil")
+	}
+
+	// Test 5: Non-existent player
+	_, err = executeCrapsQLForPlayer(t, table, "nonexistent", "PLACE $25 ON PASS_LINE;")
+	if err == nil {
+		t.Error("Expected error for non-existent player, got nil")
+	}
+}
+
+func TestInterpreterBetPlacement(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	// Test different bet types
+	betTests := []struct {
+		statement string
+		betType   string
+		amount    float64
+	}{
+		{"PLACE $25 ON PASS_LINE;", "PASS_LINE", 25.0},
+		{"PLACE $10 ON FIELD;", "FIELD", 10.0},
+		{"PLACE $20 ON PLACE_6;", "PLACE_6", 20.0},
+		{"PLACE $15 ON ANY_SEVEN;", "ANY_SEVEN", 15.0},
+	}
+
+	for _, test := range betTests {
+		results, err := executeCrapsQLForPlayer(t, table, playerID, test.statement)
+		if err != nil {
+			t.Fatalf("Failed to place %s bet: %v", test.betType, err)
+		}
+
+		if len(results) != 1 {
+			t.Errorf("Expected 1 result for %s bet, got %d", test.betType, len(results))
+		}
+
+		verifyBetExists(t, table, playerID, test.betType, test.amount)
+	}
+
+	// Verify total bankroll deduction
+	expectedBankroll := 1000.0 - 25.0 - 10.0 - 20.0 - 15.0
+	verifyPlayerBankroll(t, table, playerID, expectedBankroll)
+}
+
+func TestInterpreterQueryStatements(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	// Test SHOW POINT
+	results, err := executeCrapsQL(t, table, "SHOW POINT;")
+	if err != nil {
+		t.Fatalf("Failed to execute SHOW POINT: %v", err)
+	}
+	if len(results) != 1 || results[0] != "Point: OFF" {
+		t.Errorf("Expected 'Point: OFF', got: %v", results)
+	}
+
+	// Test SHOW BET TYPES
+	results, err = executeCrapsQL(t, table, "SHOW BET TYPES;")
+	if err != nil {
+		t.Fatalf("Failed to execute SHOW BET TYPES: %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("Expected 1 result, got %d", len(results))
+	}
+	if !strings.Contains(results[0], "AVAILABLE BET TYPES") {
+		t.Errorf("Expected bet types list, got: %s", results[0])
+	}
+
+	// Test SHOW BANKROLL for specific player
+	results, err = executeCrapsQLForPlayer(t, table, playerID, "SHOW BANKROLL;")
+	if err != nil {
+		t.Fatalf("Failed to execute SHOW BANKROLL: %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("Expected 1 result, got %d", len(results))
+	}
+	if !strings.Contains(results[0], "Player player1 Bankroll: $1000.00") {
+		t.Errorf("Expected bankroll info, got: %s", results[0])
+	}
+}
+
+func TestInterpreterManagementStatements(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	// Test SET BANKROLL
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "SET BANKROLL $1500;")
+	if err != nil {
+		t.Fatalf("Failed to execute SET BANKROLL: %v", err)
+	}
+	verifyPlayerBankroll(t, table, playerID, 1500.0)
+
+	// Test SET MAX_BET
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "SET MAX_BET $500;")
+	if err != nil {
+		t.Fatalf("Failed to execute SET MAX_BET: %v", err)
+	}
+
+	player, err := table.GetPlayer(playerID)
+	if err != nil {
+		t.Fatalf("Failed to get player: %v", err)
+	}
+	if player.MaxBet != 500.0 {
+		t.Errorf("Expected MaxBet to be $500.00, got $%.2f", player.MaxBet)
+	}
+
+	// Test SET MIN_BET
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "SET MIN_BET $10;")
+	if err != nil {
+		t.Fatalf("Failed to execute SET MIN_BET: %v", err)
+	}
+
+	if player.MinBet != 10.0 {
+		t.Errorf("Expected MinBet to be $10.00, got $%.2f", player.MinBet)
+	}
+}
+
+func TestInterpreterTurnStatements(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	// First, place a bet to turn on/off
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PLACE_6;")
+	if err != nil {
+		t.Fatalf("Failed to place PLACE_6 bet: %v", err)
+	}
+
+	// Verify bet exists; place bets are off on the come-out until a point is set
+	verifyBetExists(t, table, playerID, "PLACE_6", 25.0)
+
+	// Get the bet and verify it's off for the come-out but on for the player
+	player, err := table.GetPlayer(playerID)
+	if err != nil {
+		t.Fatalf("Failed to get player: %v", err)
+	}
+
+	var place6Bet *crapsgame.Bet
+	for _, bet := range player.Bets {
+		if bet.Type == "PLACE_6" {
+			place6Bet = bet
+			break
+		}
+	}
+
+	if place6Bet == nil {
+		t.Fatalf("Could not find PLACE_6 bet")
+	}
+
+	if place6Bet.Working {
+		t.Errorf("Expected PLACE_6 bet to be off on the come-out, but it's working")
+	}
+	if !place6Bet.PlayerWorking {
+		t.Errorf("Expected PLACE_6 bet to be on for the player by default, but it's not")
+	}
+
+	// Test TURN OFF command
+	results, err := executeCrapsQLForPlayer(t, table, playerID, "TURN OFF PLACE_6;")
+	if err != nil {
+		t.Fatalf("Failed to execute TURN OFF: %v", err)
+	}
+
+	if len(results) != 1 {
+		t.Errorf("Expected 1 result, got %d", len(results))
+	}
+
+	if !strings.Contains(results[0], "Turned PLACE_6 bet off") {
+		t.Errorf("Expected success message for TURN OFF, got: %s", results[0])
+	}
+
+	// Verify bet is now not working
+	if place6Bet.Working {
+		t.Errorf("Expected PLACE_6 bet to be turned off, but it's still working")
+	}
+
+	// Test TURN ON command
+	results, err = executeCrapsQLForPlayer(t, table, playerID, "TURN ON PLACE_6;")
+	if err != nil {
+		t.Fatalf("Failed to execute TURN ON: %v", err)
+	}
+
+	if len(results) != 1 {
+		t.Errorf("Expected 1 result, got %d", len(results))
+	}
+
+	if !strings.Contains(results[0], "Turned PLACE_6 bet on") {
+		t.Errorf("Expected success message for TURN ON, got: %s", results[0])
+	}
+
+	// Verify bet is now working again
+	if !place6Bet.Working {
+		t.Errorf("Expected PLACE_6 bet to be turned on, but it's not working")
+	}
+
+	// Test turning off/on a different bet type
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON HARD_8;")
+	if err != nil {
+		t.Fatalf("Failed to place HARD_8 bet: %v", err)
+	}
+
+	results, err = executeCrapsQLForPlayer(t, table, playerID, "TURN OFF HARD_8;")
+	if err != nil {
+		t.Fatalf("Failed to turn off HARD_8 bet: %v", err)
+	}
+
+	if !strings.Contains(results[0], "Turned HARD_8 bet off") {
+		t.Errorf("Expected success message for HARD_8 TURN OFF, got: %s", results[0])
+	}
+
+	// Test error case - trying to turn non-existent bet
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "TURN ON PLACE_4;")
+	if err == nil {
+		t.Error("Expected error when turning on non-existent bet, got nil")
+	}
+}
+
+func TestConditionExpressionsOnBothSides(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	tests := []struct {
+		condition string
+		expected  bool
+	}{
+		{"BANKROLL - $500 > PROFIT * 2 + 100", true}, // 500 > 100
+		{"BANKROLL / 4 > PROFIT + 300", false},       // 250 > 300
+		{"BANKROLL < 100 + 300 * 3", false},          // 1000 < 1000
+		{"BANKROLL * 2 > 1500 + PROFIT", true},       // 2000 > 1500
+	}
+
+	for _, tt := range tests {
+		interpreter := NewInterpreter(table)
+		output, err := interpreter.ExecuteStringForPlayer("IF "+tt.condition+" THEN SHOW POINT; END;", playerID)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.condition, err)
+			continue
+		}
+		if ran := len(output) > 0; ran != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.condition, tt.expected, ran)
+		}
+	}
+
+	// PROFIT follows decided bets
+	executeCrapsQLForPlayer(t, table, playerID, "PLACE $100 ON FIELD;")
+	simulateDiceRoll(t, table, 2, 2) // Field wins $100
+	output, err := executeCrapsQLForPlayer(t, table, playerID, "IF PROFIT * 10 > BANKROLL - 200 THEN SHOW POINT; END;")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(output) == 0 {
+		t.Error("Expected PROFIT * 10 (1000) > BANKROLL - 200 (900) after the field win")
+	}
+
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "IF BANKROLL / 0 > 1 THEN SHOW POINT; END;"); err == nil {
+		t.Error("Expected division by zero error, got nil")
+	}
+}
+
+func TestWhileLoopExecution(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	// Each pass puts $5 on the table until the bankroll reaches $950
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "WHILE BANKROLL > $950 DO PLACE $5 ON HARD_4; END;")
+	if err != nil {
+		t.Fatalf("WHILE loop failed: %v", err)
+	}
+	verifyPlayerBankroll(t, table, playerID, 950.0)
+
+	player, _ := table.GetPlayer(playerID)
+	if len(player.Bets) != 10 {
+		t.Errorf("Expected the body to run 10 times, got %d bets", len(player.Bets))
+	}
+
+	// A false condition skips the body entirely
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "WHILE BANKROLL > $5000 DO PLACE $5 ON HARD_4; END;")
+	if err != nil {
+		t.Fatalf("WHILE loop failed: %v", err)
+	}
+	if len(player.Bets) != 10 {
+		t.Errorf("Expected no iterations, got %d bets", len(player.Bets))
+	}
+
+	// A condition that never changes trips the iteration guard
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "WHILE BANKROLL > $0 DO SHOW POINT; END;")
+	if err == nil || !strings.Contains(err.Error(), "exceeded") {
+		t.Errorf("Expected iteration guard error, got %v", err)
+	}
+}
+
+func TestRepeatLoopExecution(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+	table.SetDiceSource(newScriptedDice([2]int{1, 2}, [2]int{2, 3}, [2]int{4, 5}))
+
+	// The field wins on the 3, loses on the 5 and wins on the 9
+	output, err := executeCrapsQLForPlayer(t, table, playerID, "REPEAT 3 TIMES PLACE $10 ON FIELD; ROLL DICE; END;")
+	if err != nil {
+		t.Fatalf("REPEAT loop failed: %v", err)
+	}
+	if len(output) != 1 || strings.Count(output[0], "✅ Placed $10.00 on FIELD") != 3 {
+		t.Errorf("Expected the body to run 3 times, got %v", output)
+	}
+	if rolls := len(table.GetRollHistory()); rolls != 3 {
+		t.Errorf("Expected 3 rolls, got %d", rolls)
+	}
+	verifyPlayerBankroll(t, table, playerID, 1010.0)
+	verifyBetNotExists(t, table, playerID, "FIELD")
+
+	for _, statement := range []string{
+		"REPEAT 0 TIMES SHOW POINT; END;",
+		"REPEAT 2.5 TIMES SHOW POINT; END;",
+		"REPEAT 20000 TIMES SHOW POINT; END;",
+	} {
+		if _, err := executeCrapsQLForPlayer(t, table, playerID, statement); err == nil {
+			t.Errorf("%s: expected an error for the count, got nil", statement)
+		}
+	}
+}
+
+func TestSetDiceAndShowShooter(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	output, err := executeCrapsQLForPlayer(t, table, playerID, "SHOW SHOOTER;")
+	if err != nil {
+		t.Fatalf("SHOW SHOOTER failed: %v", err)
+	}
+	if len(output) != 1 || output[0] != "Shooter: "+table.Shooter {
+		t.Errorf("Unexpected SHOW SHOOTER output: %v", output)
+	}
+
+	_, err = executeCrapsQLForPlayer(t, table, playerID, `SET DICE "hard_ways"; PLACE $10 ON PASS_LINE;`)
+	if err != nil {
+		t.Fatalf("SET DICE failed: %v", err)
+	}
+	if table.DiceSetting != "hard_ways" {
+		t.Errorf("Expected dice setting hard_ways, got %q", table.DiceSetting)
+	}
+	if len(table.Events) != 1 || table.Events[0].Type != crapsgame.EventDiceSet {
+		t.Errorf("Expected one DICE_SET event, got %v", table.Events)
+	}
+
+	output, _ = executeCrapsQLForPlayer(t, table, playerID, "SHOW SHOOTER;")
+	if len(output) != 1 || !strings.Contains(output[0], "dice set: hard_ways") {
+		t.Errorf("Expected dice set in SHOW SHOOTER, got %v", output)
+	}
+
+	// The label belongs to the shooter and goes away when the dice pass
+	simulateDiceRoll(t, table, 3, 3)
+	simulateDiceRoll(t, table, 3, 4)
+	if table.DiceSetting != "" {
+		t.Errorf("Expected dice setting to clear on seven out, got %q", table.DiceSetting)
+	}
+
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "SET DICE 5;"); err == nil {
+		t.Error("Expected error for SET DICE without a quoted label, got nil")
+	}
+}
+
+func TestLetVariables(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+	interpreter := NewInterpreter(table)
+
+	output, err := interpreter.ExecuteStringForPlayer("LET unit = $25; PLACE unit ON PASS_LINE; PLACE unit ON FIELD;", playerID)
+	if err != nil {
+		t.Fatalf("Failed to place bets with a variable: %v", err)
+	}
+	if output[0] != "✅ Set unit to $25.00" {
+		t.Errorf("Expected LET confirmation, got %q", output[0])
+	}
+	verifyBetExists(t, table, playerID, "PASS_LINE", 25.0)
+	verifyBetExists(t, table, playerID, "FIELD", 25.0)
+
+	// Reassigning applies to the statements that follow
+	if _, err := interpreter.ExecuteStringForPlayer("LET unit = $10; PLACE unit ON ANY_CRAPS;", playerID); err != nil {
+		t.Fatalf("Failed to place bet after reassigning: %v", err)
+	}
+	verifyBetExists(t, table, playerID, "ANY_CRAPS", 10.0)
+	verifyBetExists(t, table, playerID, "PASS_LINE", 25.0)
+
+	_, err = interpreter.ExecuteStringForPlayer("PLACE units ON ANY_SEVEN;", playerID)
+	if err == nil || !strings.Contains(err.Error(), "undefined variable units at line 1, column 7") {
+		t.Errorf("Expected undefined variable error, got %v", err)
+	}
+	verifyBetNotExists(t, table, playerID, "ANY_SEVEN")
+	verifyPlayerBankroll(t, table, playerID, 940.0)
+}
+
+func TestArithmeticAmounts(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+	interpreter := NewInterpreter(table)
+
+	statements := []struct {
+		statement string
+		betType   string
+		amount    float64
+	}{
+		{"PLACE $10 + $5 * 2 ON PASS_LINE;", "PASS_LINE", 20.0},
+		{"PLACE ($10 + $5) * 2 ON FIELD;", "FIELD", 30.0},
+		{"LET unit = $5; PLACE unit * 3 ON ANY_CRAPS;", "ANY_CRAPS", 15.0},
+	}
+	for _, s := range statements {
+		if _, err := interpreter.ExecuteStringForPlayer(s.statement, playerID); err != nil {
+			t.Fatalf("Failed to execute %q: %v", s.statement, err)
+		}
+		verifyBetExists(t, table, playerID, s.betType, s.amount)
+	}
+
+	if _, err := interpreter.ExecuteStringForPlayer("SET BANKROLL $1000 + $500;", playerID); err != nil {
+		t.Fatalf("Failed to set bankroll: %v", err)
+	}
+	verifyPlayerBankroll(t, table, playerID, 1500.0)
+
+	// Bad arithmetic is rejected before anything is placed
+	_, err := interpreter.ExecuteStringForPlayer("PLACE $25 / 0 ON ANY_SEVEN;", playerID)
+	if err == nil || !strings.Contains(err.Error(), "division by zero") {
+		t.Errorf("Expected division by zero error, got %v", err)
+	}
+	_, err = interpreter.ExecuteStringForPlayer("PLACE $10 - $20 ON ANY_SEVEN;", playerID)
+	if err == nil || !strings.Contains(err.Error(), "bet amount must be positive, got $-10.00") {
+		t.Errorf("Expected positive amount error, got %v", err)
+	}
+	verifyBetNotExists(t, table, playerID, "ANY_SEVEN")
+	verifyPlayerBankroll(t, table, playerID, 1500.0)
+}
+
+func TestElseIfBranches(t *testing.T) {
+	strategy := `IF BANKROLL > 1000 THEN
+		PLACE $25 ON FIELD;
+	ELSE IF BANKROLL > 500 THEN
+		PLACE $10 ON FIELD;
+	ELSE
+		PLACE $5 ON FIELD;
+	END;`
+
+	tests := []struct {
+		bankroll float64
+		amount   float64
+	}{
+		{1500.0, 25.0},
+		{800.0, 10.0},
+		{200.0, 5.0},
+		{1000.0, 10.0}, // not above 1000, so the second branch
+	}
+	for _, tt := range tests {
+		table, players := setupTestGame(t)
+		playerID := players[0]
+		player, _ := table.GetPlayer(playerID)
+		player.Bankroll = tt.bankroll
+
+		output, err := executeCrapsQLForPlayer(t, table, playerID, strategy)
+		if err != nil {
+			t.Fatalf("Bankroll $%.2f: strategy failed: %v", tt.bankroll, err)
+		}
+		// Exactly one branch ran
+		if len(output) != 1 || getPlayerBetCount(t, table, playerID) != 1 {
+			t.Errorf("Bankroll $%.2f: expected one bet placed, got %q", tt.bankroll, output)
+		}
+		verifyBetExists(t, table, playerID, "FIELD", tt.amount)
+	}
+}
+
+func TestAlwaysBets(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+	interpreter := NewInterpreter(table)
+	table.SetDiceSource(newScriptedDice([2]int{3, 4}, [2]int{2, 2}, [2]int{3, 3}, [2]int{3, 4}))
+
+	output, err := interpreter.ExecuteStringForPlayer("ALWAYS PLACE $10 ON FIELD;", playerID)
+	if err != nil {
+		t.Fatalf("Failed to set always bet: %v", err)
+	}
+	if !strings.Contains(strings.Join(output, "\n"), "Always $10.00 on FIELD") {
+		t.Errorf("Expected always confirmation, got %v", output)
+	}
+	verifyBetExists(t, table, playerID, "FIELD", 10.0)
+	verifyPlayerBankroll(t, table, playerID, 990.0)
+
+	// 7 loses, 4 wins even money, 6 loses; the field goes back up every time
+	expectedBankrolls := []float64{980.0, 990.0, 980.0}
+	for roll, expected := range expectedBankrolls {
+		output, err := interpreter.ExecuteStringForPlayer("ROLL DICE;", playerID)
+		if err != nil {
+			t.Fatalf("Roll %d failed: %v", roll+1, err)
+		}
+		if !strings.Contains(strings.Join(output, "\n"), "Re-placed $10.00 on FIELD for "+playerID) {
+			t.Errorf("Roll %d: expected field to be re-placed, got %v", roll+1, output)
+		}
+		verifyBetExists(t, table, playerID, "FIELD", 10.0)
+		verifyPlayerBankroll(t, table, playerID, expected)
+	}
+
+	// Cancelling leaves the current bet up but stops re-placing it
+	if _, err := interpreter.ExecuteStringForPlayer("CANCEL ALWAYS FIELD;", playerID); err != nil {
+		t.Fatalf("Failed to cancel always bet: %v", err)
+	}
+	verifyBetExists(t, table, playerID, "FIELD", 10.0)
+	interpreter.ExecuteStringForPlayer("ROLL DICE;", playerID) // 7 loses
+	verifyBetNotExists(t, table, playerID, "FIELD")
+	verifyPlayerBankroll(t, table, playerID, 980.0)
+
+	if _, err := interpreter.ExecuteStringForPlayer("CANCEL ALWAYS FIELD;", playerID); err == nil {
+		t.Error("Expected error cancelling an always bet that isn't set")
+	}
+}
+
+func TestMartingaleStrategy(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+	table.MaxBet = 100
+	interpreter := NewInterpreter(table)
+
+	// Six craps on the come-out, then a natural
+	rolls := make([][2]int, 0, 7)
+	for n := 0; n < 6; n++ {
+		rolls = append(rolls, [2]int{1, 2})
+	}
+	table.SetDiceSource(newScriptedDice(append(rolls, [2]int{3, 4})...))
+
+	output, err := interpreter.ExecuteStringForPlayer(`RUN STRATEGY "martingale" ON PASS_LINE BASE $5;`, playerID)
+	if err != nil {
+		t.Fatalf("RUN STRATEGY failed: %v", err)
+	}
+	if !strings.Contains(strings.Join(output, "\n"), "Running martingale on PASS_LINE from $5.00") {
+		t.Errorf("Expected the strategy to start, got %v", output)
+	}
+	verifyBetExists(t, table, playerID, "PASS_LINE", 5.0)
+
+	// Each loss doubles the bet until the $100 table maximum caps it
+	bankroll := 1000.0 - 5.0
+	lastBet := 5.0
+	for n, expected := range []float64{10, 20, 40, 80, 100, 100} {
+		if _, err := interpreter.ExecuteStringForPlayer("ROLL DICE;", playerID); err != nil {
+			t.Fatalf("Roll %d failed: %v", n+1, err)
+		}
+		bankroll -= expected
+		verifyBetExists(t, table, playerID, "PASS_LINE", expected)
+		verifyPlayerBankroll(t, table, playerID, bankroll)
+		lastBet = expected
+	}
+
+	// A win goes back to the base bet
+	if _, err := interpreter.ExecuteStringForPlayer("ROLL DICE;", playerID); err != nil {
+		t.Fatalf("Winning roll failed: %v", err)
+	}
+	verifyBetExists(t, table, playerID, "PASS_LINE", 5.0)
+	verifyPlayerBankroll(t, table, playerID, bankroll+2*lastBet-5.0)
+
+	if _, err := interpreter.ExecuteStringForPlayer(`RUN STRATEGY "paroli" ON PASS_LINE BASE $5;`, playerID); err == nil {
+		t.Error("Expected error for an unknown strategy, got nil")
+	}
+
+	// A place bet stays up when it wins, so a strategy can't follow it
+	if _, err := interpreter.ExecuteStringForPlayer(`RUN STRATEGY "martingale" ON PLACE_6 BASE $6;`, playerID); err == nil {
+		t.Error("Expected error running a strategy on a place bet, got nil")
+	}
+	verifyBetNotExists(t, table, playerID, "PLACE_6")
+
+	// The player's own maximum caps the bet below the table's
+	table, players = setupTestGame(t)
+	playerID = players[0]
+	interpreter = NewInterpreter(table)
+	table.SetDiceSource(newScriptedDice([2]int{1, 2}, [2]int{1, 2}, [2]int{1, 2}, [2]int{1, 2}))
+	_, err = interpreter.ExecuteStringForPlayer(`SET MAX_BET $30; RUN STRATEGY "martingale" ON PASS_LINE BASE $5;`, playerID)
+	if err != nil {
+		t.Fatalf("RUN STRATEGY failed: %v", err)
+	}
+	bankroll = 1000.0 - 5.0
+	for n, expected := range []float64{10, 20, 30, 30} {
+		if _, err := interpreter.ExecuteStringForPlayer("ROLL DICE;", playerID); err != nil {
+			t.Fatalf("Roll %d failed: %v", n+1, err)
+		}
+		bankroll -= expected
+		verifyBetExists(t, table, playerID, "PASS_LINE", expected)
+		verifyPlayerBankroll(t, table, playerID, bankroll)
+	}
+}
+
+func TestShowHistory(t *testing.T) {
+	table, players := setupTestGame(t)
+	interpreter := NewInterpreter(table)
+	table.SetDiceSource(newScriptedDice([2]int{3, 4}, [2]int{2, 2}, [2]int{3, 3}, [2]int{1, 3}))
+
+	output, err := interpreter.ExecuteStringForPlayer("SHOW HISTORY;", players[0])
+	if err != nil || output[0] != "No rolls yet" {
+		t.Fatalf("Expected empty history, got %v (%v)", output, err)
+	}
+
+	for n := 0; n < 4; n++ {
+		if _, err := interpreter.ExecuteStringForPlayer("ROLL DICE;", players[0]); err != nil {
+			t.Fatalf("Roll %d failed: %v", n+1, err)
+		}
+	}
+
+	output, err = interpreter.ExecuteStringForPlayer("SHOW HISTORY;", players[0])
+	if err != nil {
+		t.Fatalf("Failed to show history: %v", err)
+	}
+	expected := []string{
+		"Roll History (last 4):",
+		"  4 (1 + 3) on point 4",
+		"  6 (3 + 3) on point 4",
+		"  4 (2 + 2) on come out",
+		"  7 (3 + 4) on come out",
+	}
+	if got := strings.Split(output[0], "\n"); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected history %q, got %q", expected, got)
+	}
+}
+
+func TestRollHistoryAggregates(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+	interpreter := NewInterpreter(table)
+
+	aggregates := func() (float64, float64) {
+		t.Helper()
+		sinceSeven, err := interpreter.evaluateExpressionForPlayer(&IdentifierExpression{Value: "ROLLS_SINCE_SEVEN"}, playerID)
+		if err != nil {
+			t.Fatalf("Failed to evaluate ROLLS_SINCE_SEVEN: %v", err)
+		}
+		sevens, err := interpreter.evaluateExpressionForPlayer(&IdentifierExpression{Value: "SEVENS_COUNT"}, playerID)
+		if err != nil {
+			t.Fatalf("Failed to evaluate SEVENS_COUNT: %v", err)
+		}
+		return sinceSeven, sevens
+	}
+
+	if sinceSeven, sevens := aggregates(); sinceSeven != 0 || sevens != 0 {
+		t.Errorf("Expected 0 and 0 with no history, got %v and %v", sinceSeven, sevens)
+	}
+
+	table.SetDiceSource(newScriptedDice([2]int{3, 4}, [2]int{2, 2}, [2]int{5, 6}, [2]int{2, 5}, [2]int{3, 3}, [2]int{1, 2}))
+
+	strategy := "IF ROLLS_SINCE_SEVEN > 2 THEN PLACE $10 ON FIELD; END;"
+	expected := []struct {
+		sinceSeven, sevens float64
+	}{
+		{0, 1}, // 7
+		{1, 1}, // 4
+		{2, 1}, // 11
+		{0, 2}, // 7
+		{1, 2}, // 6
+		{2, 2}, // 3
+	}
+	for n, want := range expected {
+		table.RollDiceAndResolve()
+		sinceSeven, sevens := aggregates()
+		if sinceSeven != want.sinceSeven || sevens != want.sevens {
+			t.Errorf("Roll %d: expected %v rolls since seven and %v sevens, got %v and %v", n+1, want.sinceSeven, want.sevens, sinceSeven, sevens)
+		}
+		if _, err := interpreter.ExecuteStringForPlayer(strategy, playerID); err != nil {
+			t.Fatalf("Roll %d: strategy failed: %v", n+1, err)
+		}
+		verifyBetNotExists(t, table, playerID, "FIELD")
+	}
+
+	// The third roll without a seven starts the betting
+	table.SetDiceSource(newScriptedDice([2]int{4, 4}))
+	table.RollDiceAndResolve()
+	if _, err := interpreter.ExecuteStringForPlayer(strategy, playerID); err != nil {
+		t.Fatalf("Strategy failed: %v", err)
+	}
+	verifyBetExists(t, table, playerID, "FIELD", 10.0)
+}
+
+func TestRollHistoryRingBuffer(t *testing.T) {
+	table, _ := setupTestGame(t)
+
+	// Cycle through every total so each roll's position in the history is known
+	faces := [][2]int{{1, 1}, {1, 2}, {2, 2}, {2, 3}, {3, 3}, {3, 4}, {4, 4}, {4, 5}, {5, 5}, {5, 6}, {6, 6}}
+	table.SetDiceSource(newScriptedDice(faces...))
+
+	const rolls = 1500
+	for n := 0; n < rolls; n++ {
+		table.RollDiceAndResolve()
+	}
+
+	history := table.GetRollHistory()
+	if len(history) != crapsgame.DefaultRollHistorySize {
+		t.Fatalf("Expected history capped at %d rolls, got %d", crapsgame.DefaultRollHistorySize, len(history))
+	}
+	first := rolls - len(history)
+	for n, record := range history {
+		want := faces[(first+n)%len(faces)]
+		if record.Roll.Die1 != want[0] || record.Roll.Die2 != want[1] {
+			t.Fatalf("Expected roll %d to be %d + %d, got %d + %d", n, want[0], want[1], record.Roll.Die1, record.Roll.Die2)
+		}
+	}
+	if history[len(history)-1].Roll != table.CurrentRoll {
+		t.Errorf("Expected the newest roll last")
+	}
+
+	// Shrinking the history keeps the most recent rolls
+	table.RollHistorySize = 50
+	table.RollDiceAndResolve()
+	history = table.GetRollHistory()
+	if len(history) != 50 {
+		t.Fatalf("Expected history capped at 50 rolls, got %d", len(history))
+	}
+	first = rolls + 1 - len(history)
+	for n, record := range history {
+		want := faces[(first+n)%len(faces)]
+		if record.Roll.Die1 != want[0] || record.Roll.Die2 != want[1] {
+			t.Fatalf("Expected roll %d to be %d + %d after resizing, got %d + %d", n, want[0], want[1], record.Roll.Die1, record.Roll.Die2)
+		}
+	}
+}
+
+func TestShowWhy(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+	interpreter := NewInterpreter(table)
+
+	showWhy := func() []string {
+		t.Helper()
+		output, err := interpreter.ExecuteStringForPlayer("SHOW WHY;", playerID)
+		if err != nil {
+			t.Fatalf("Failed to show why: %v", err)
+		}
+		return strings.Split(output[0], "\n")
+	}
+
+	if got := showWhy(); got[0] != "No bets have been rejected" {
+		t.Errorf("Expected no rejections yet, got %q", got)
+	}
+
+	tests := []struct {
+		statement string
+		headline  string
+		explained string
+	}{
+		{"PLACE $5000 ON PASS_LINE;", "Rejected $5000.00 on PASS_LINE (LIMIT)",
+			"The amount is outside the table limits, your own limits, the odds allowed or the exposure cap."},
+		{"PLACE $10 ON PASS_ODDS;", "Rejected $10.00 on PASS_ODDS (STATE)",
+			"The bet isn't allowed at this point in the game."},
+		{"SET BANKROLL $20; PLACE $25 ON FIELD;", "Rejected $25.00 on FIELD (BANKROLL)",
+			"Your bankroll doesn't cover the bet."},
+	}
+
+	checkWhy := func(name string, err error, headline, explained string) {
+		t.Helper()
+		if err == nil {
+			t.Fatalf("%s: expected the bet to be rejected", name)
+		}
+		got := showWhy()
+		if len(got) != 3 || got[0] != headline || got[1] != "  "+explained {
+			t.Errorf("%s: expected %q / %q, got %q", name, headline, explained, got)
+			return
+		}
+		// The last line carries the table's own validation message
+		if !strings.HasSuffix(err.Error(), strings.TrimSpace(got[2])) {
+			t.Errorf("%s: expected details %q to match the error %q", name, got[2], err)
+		}
+	}
+
+	for _, tt := range tests {
+		_, err := interpreter.ExecuteStringForPlayer(tt.statement, playerID)
+		checkWhy(tt.statement, err, tt.headline, tt.explained)
+	}
+
+	// The parser only produces known bet types, so build the statement directly
+	stmt := &BetStatement{Amount: &AmountExpression{Value: 10}, BetType: &BetTypeExpression{Type: BetType(9999)}}
+	_, err := interpreter.executeBetStatementForPlayer(stmt, playerID)
+	checkWhy("unknown bet type", err, "Rejected $10.00 on UNKNOWN_BET_TYPE_9999 (TYPE)", "The table doesn't offer that bet.")
+}
+
+// 6.9 Validation Tests
+func TestBetValidationRules(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	// Test 1: Validate bet amounts against limits
+	// Try to place bet below minimum
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $1 ON PASS_LINE;")
+	if err == nil {
+		t.Error("Expected error when betting below minimum, got nil")
+	}
+
+	// Try to place bet above maximum
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $2000 ON PASS_LINE;")
+	if err == nil {
+		t.Error("Expected error when betting above maximum, got nil")
+	}
+
+	// Test 2: Validate bet types against game state
+	// Try to place odds bet without point established
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_ODDS;")
+	if err == nil {
+		t.Error("Expected error when placing odds bet without point, got nil")
+	}
+
+	// Test 3: Validate player permissions
+	// Try to place bet for non-existent player
+	_, err = executeCrapsQLForPlayer(t, table, "nonexistent", "PLACE $25 ON PASS_LINE;")
+	if err == nil {
+		t.Error("Expected error when placing bet for non-existent player, got nil")
+	}
+
+	// Test 4: Validate bet combinations
+	// Place valid bet
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_LINE;")
+	if err != nil {
+		t.Fatalf("Failed to place valid bet: %v", err)
+	}
+
+	// Try to place same bet type again (should be allowed in some cases)
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_LINE;")
+	if err != nil {
+		t.Logf("Note: Placing duplicate bet types is not allowed: %v", err)
+	}
+
+	// Test 5: Validate bet amounts against bankroll
+	// Set bankroll to small amount
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "SET BANKROLL $50;")
+	if err != nil {
+		t.Fatalf("Failed to set bankroll: %v", err)
+	}
+
+	// Try to place bet exceeding bankroll
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $100 ON FIELD;")
+	if err == nil {
+		t.Error("Expected error when betting more than bankroll, got nil")
+	}
+}
+
+func TestGameStateValidation(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	// Test 1: Validate state transitions
+	// Start in come out state
+	verifyGameState(t, table, crapsgame.StateComeOut, crapsgame.PointOff)
+
+	// Place pass line bet and establish point
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_LINE;")
+	if err != nil {
+		t.Fatalf("Failed to place pass line bet: %v", err)
+	}
+
+	simulateDiceRoll(t, table, 3, 3) // 6
+	verifyGameState(t, table, crapsgame.StatePoint, crapsgame.Point6)
+
+	// Test 2: Validate point establishment
+	if table.GetPointNumber() != 6 {
+		t.Errorf("Expected point number 6, got %d", table.GetPointNumber())
+	}
+
+	// Test 3: Validate bet resolution timing
+	// Pass line bet should remain active during point phase
+	verifyBetExists(t, table, playerID, "PASS_LINE", 25.0)
+
+	// Resolve point
+	simulateDiceRoll(t, table, 2, 4) // 6
+	verifyGameState(t, table, crapsgame.StateComeOut, crapsgame.PointOff)
+	verifyBetNotExists(t, table, playerID, "PASS_LINE")
+
+	// Test 4: Validate player state consistency
+	player, err := table.GetPlayer(playerID)
+	if err != nil {
+		t.Fatalf("Failed to get player: %v", err)
+	}
+
+	// Verify player's bet list is empty after resolution
+	if len(player.Bets) != 0 {
+		t.Errorf("Expected empty bet list after resolution, got %d bets", len(player.Bets))
+	}
+
+	// Test 5: Validate invalid states are detected
+	// Try to place odds bet in come out state
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_ODDS;")
+	if err == nil {
+		t.Error("Expected error when placing odds bet in come out state, got nil")
+	}
+
+	// Test 6: Validate shooter assignment
+	if table.Shooter == "" {
+		t.Error("Expected shooter to be assigned")
+	}
+
+	// Remove all players and verify shooter is cleared
+	for _, player := range players {
+		err := table.RemovePlayer(player)
+		if err != nil {
+			t.Fatalf("Failed to remove player %s: %v", player, err)
+		}
+	}
+
+	if table.Shooter != "" {
+		t.Errorf("Expected empty shooter when no players, got %s", table.Shooter)
+	}
+}
+
+// 6.10 Performance and Stress Tests
+func TestLargeGameScenarios(t *testing.T) {
+	// Test with many players
+	table := crapsgame.NewTable(5.0, 1000.0, 3)
+
+	// Add 10 players
+	players := make([]string, 10)
+	for i := 0; i < 10; i++ {
+		playerID := fmt.Sprintf("player%d", i+1)
+		err := table.AddPlayer(playerID, fmt.Sprintf("Player %d", i+1), 1000.0)
+		if err != nil {
+			t.Fatalf("Failed to add player %s: %v", playerID, err)
+		}
+		players[i] = playerID
+	}
+
+	// Each player places multiple bets
+	interpreter := NewInterpreter(table)
+	for _, playerID := range players {
+		script := fmt.Sprintf(`
+			PLACE $25 ON PASS_LINE;
+			PLACE $10 ON FIELD;
+			PLACE $15 ON ANY_SEVEN;
+		`)
+
+		_, err := interpreter.ExecuteStringForPlayer(script, playerID)
+		if err != nil {
+			t.Fatalf("Failed to place bets for player %s: %v", playerID, err)
+		}
+	}
+
+	// Verify all bets were placed
+	for _, playerID := range players {
+		player, err := table.GetPlayer(playerID)
+		if err != nil {
+			t.Fatalf("Failed to get player %s: %v", playerID, err)
+		}
+
+		if len(player.Bets) != 3 {
+			t.Errorf("Expected 3 bets for player %s, got %d", playerID, len(player.Bets))
+		}
+	}
+
+	// Test rapid bet placement and resolution (simplified)
+	for i := 0; i < 3; i++ {
+		// Roll dice
+		simulateDiceRoll(t, table, 3, 4) // 7
+
+		// Just verify game state is consistent
+		if table.State != crapsgame.StateComeOut {
+			t.Errorf("Expected come out state after 7, got %v", table.State)
+		}
+	}
+}
+
+func TestConcurrentOperations(t *testing.T) {
+	table, players := setupTestGame(t)
+
+	// Test basic concurrent-like operations (sequential but simulating concurrent access)
+	interpreter := NewInterpreter(table)
+
+	// Simulate multiple players placing bets "simultaneously"
+	results := make(chan error, len(players))
+
+	for _, playerID := range players {
+		go func(pid string) {
+			script := "PLACE $25 ON PASS_LINE;"
+			_, err := interpreter.ExecuteStringForPlayer(script, pid)
+			results <- err
+		}(playerID)
+	}
+
+	// Collect results
+	for i := 0; i < len(players); i++ {
+		err := <-results
+		if err != nil {
+			t.Errorf("Concurrent bet placement failed: %v", err)
+		}
+	}
+
+	// Verify all bets were placed correctly
+	for _, playerID := range players {
+		verifyBetExists(t, table, playerID, "PASS_LINE", 25.0)
+	}
+
+	// Test concurrent state queries
+	queryResults := make(chan string, len(players))
+
+	for _, playerID := range players {
+		go func(pid string) {
+			script := "SHOW BANKROLL;"
+			results, err := interpreter.ExecuteStringForPlayer(script, pid)
+			if err != nil {
+				queryResults <- fmt.Sprintf("ERROR: %v", err)
+			} else {
+				queryResults <- results[0]
+			}
+		}(playerID)
+	}
+
+	// Collect query results
+	for i := 0; i < len(players); i++ {
+		result := <-queryResults
+		if strings.Contains(result, "ERROR") {
+			t.Errorf("Concurrent query failed: %s", result)
+		}
+	}
+}
+
+func TestConcurrentTableAccess(t *testing.T) {
+	// Run with -race: many players bet at once while others read the table
+	table := crapsgame.NewTable(5.0, 1000.0, 3)
+	interpreter := NewInterpreter(table)
+
+	const playerCount = 20
+	const betsPerPlayer = 10
+	for i := 0; i < playerCount; i++ {
+		if err := table.AddPlayer(fmt.Sprintf("p%d", i), fmt.Sprintf("Player %d", i), 1000.0); err != nil {
+			t.Fatalf("Failed to add player: %v", err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, playerCount*betsPerPlayer)
+	for i := 0; i < playerCount; i++ {
+		playerID := fmt.Sprintf("p%d", i)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for b := 0; b < betsPerPlayer; b++ {
+				if b%2 == 0 {
+					_, err := table.PlaceBet(playerID, "FIELD", 10.0, nil)
+					errs <- err
+				} else {
+					_, err := interpreter.ExecuteStringForPlayer("PLACE $10 ON ANY_SEVEN;", playerID)
+					errs <- err
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for b := 0; b < betsPerPlayer; b++ {
+				table.BankrollSnapshot()
+				table.PlayerExposure(playerID)
+				table.GetState()
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Concurrent bet placement failed: %v", err)
+		}
+	}
+
+	// Every dollar is either in a bankroll or on the layout
+	total := 0.0
+	for id, bankroll := range table.BankrollSnapshot() {
+		if bankroll != 1000.0-10.0*betsPerPlayer {
+			t.Errorf("Expected %s to have $%.2f left, got $%.2f", id, 1000.0-10.0*betsPerPlayer, bankroll)
+		}
+		total += bankroll + table.PlayerExposure(id)
+	}
+	if total != 1000.0*playerCount {
+		t.Errorf("Expected bankrolls and exposure to total $%.2f, got $%.2f", 1000.0*playerCount, total)
+	}
+	if got := len(table.Transactions); got != playerCount*betsPerPlayer {
+		t.Errorf("Expected %d ledger entries, got %d", playerCount*betsPerPlayer, got)
+	}
+}
+
+func TestMemoryAndResourceUsage(t *testing.T) {
+	// Test memory usage with large number of operations
+	table := crapsgame.NewTable(5.0, 1000.0, 3)
+
+	// Add player
+	err := table.AddPlayer("player1", "Test Player", 10000.0)
+	if err != nil {
+		t.Fatalf("Failed to add player: %v", err)
+	}
+
+	interpreter := NewInterpreter(table)
+
+	// Perform a few operations (simplified)
+	for i := 0; i < 5; i++ {
+		script := "PLACE $10 ON FIELD;"
+
+		_, err := interpreter.ExecuteStringForPlayer(script, "player1")
+		if err != nil {
+			t.Logf("Operation %d failed: %v", i, err)
+			break
+		}
+
+		// Simulate a simple roll to clear the field bet
+		simulateDiceRoll(t, table, 3, 4) // 7 - field loses
+	}
+
+	// Verify system is still functional
+	player, err := table.GetPlayer("player1")
+	if err != nil {
+		t.Fatalf("Failed to get player after stress test: %v", err)
+	}
+
+	if player.Bankroll <= 0 {
+		t.Logf("Player bankroll depleted after stress test: $%.2f", player.Bankroll)
+	}
+}
+
+func TestBetTypeCoverage(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	// Test all major bet types
+	betTypes := []string{
+		"PASS_LINE", "DONT_PASS", "FIELD", "ANY_SEVEN", "ANY_CRAPS",
+		"PLACE_4", "PLACE_5", "PLACE_6", "PLACE_8", "PLACE_9", "PLACE_10",
+		"HARD_4", "HARD_6", "HARD_8", "HARD_10",
+		"BUY_4", "BUY_10", "LAY_4", "LAY_10",
+	}
+
+	interpreter := NewInterpreter(table)
+
+	for _, betType := range betTypes {
+		script := fmt.Sprintf("PLACE $10 ON %s;", betType)
+		_, err := interpreter.ExecuteStringForPlayer(script, playerID)
+		if err != nil {
+			t.Logf("Bet type %s failed: %v", betType, err)
+		} else {
+			t.Logf("Bet type %s succeeded", betType)
+		}
+	}
+
+	// Verify some bets were placed successfully
+	player, err := table.GetPlayer(playerID)
+	if err != nil {
+		t.Fatalf("Failed to get player: %v", err)
+	}
+
+	if len(player.Bets) == 0 {
+		t.Error("No bets were placed successfully")
+	} else {
+		t.Logf("Successfully placed %d bets", len(player.Bets))
+	}
+}
+
+// 6.11 Session Reporting Tests
+func TestAverageBetSize(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	if avg := table.AverageBetSize(playerID); avg != 0 {
+		t.Errorf("Expected average 0 before any bets, got %.2f", avg)
+	}
+
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON FIELD; PLACE $25 ON PASS_LINE; PLACE $12 ON PLACE_6; PLACE $5 ON ANY_SEVEN;")
+	if err != nil {
+		t.Fatalf("Failed to place bets: %v", err)
+	}
+
+	// (10 + 25 + 12 + 5) / 4
+	if avg := table.AverageBetSize(playerID); avg != 13.0 {
+		t.Errorf("Expected average bet $13.00, got $%.2f", avg)
+	}
+
+	results, err := executeCrapsQLForPlayer(t, table, playerID, "SHOW AVG BET;")
+	if err != nil {
+		t.Fatalf("SHOW AVG BET failed: %v", err)
+	}
+	if len(results) != 1 || results[0] != "Player player1 Average Bet: $13.00 (4 bets)" {
+		t.Errorf("Unexpected SHOW AVG BET output: %v", results)
+	}
+}
+
+func TestExpectedLossPerHour(t *testing.T) {
+	// $25 on the pass line (1.41%) for 100 rolls
+	got := crapsgame.ExpectedLossPerHour(25, 1.41, 100)
+	if diff := got - 35.25; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("Expected $35.25 per hour, got $%.4f", got)
+	}
+
+	table, players := setupTestGame(t)
+	playerID := players[0]
+	table.RollsPerHour = 60
+
+	results, err := executeCrapsQLForPlayer(t, table, playerID, "SHOW COST;")
+	if err != nil || len(results) != 1 || results[0] != "Player player1 has no bets to estimate a cost from" {
+		t.Errorf("Expected no estimate before any bets, got %v (%v)", results, err)
+	}
+
+	// $12 on the 6 and 8, both 1.52%
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $12 ON PLACE_6; PLACE $12 ON PLACE_8;"); err != nil {
+		t.Fatalf("Failed to place bets: %v", err)
+	}
+	results, err = executeCrapsQLForPlayer(t, table, playerID, "SHOW COST;")
+	if err != nil {
+		t.Fatalf("SHOW COST failed: %v", err)
+	}
+	expected := "Player player1 Expected Cost: $10.94 per hour ($12.00 average bet, 1.52% house edge, 60 rolls per hour)"
+	if len(results) != 1 || results[0] != expected {
+		t.Errorf("Expected %q, got %v", expected, results)
+	}
+}
+
+func TestStreaks(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	field := func(die1, die2 int) {
+		t.Helper()
+		if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON FIELD;"); err != nil {
+			t.Fatalf("Failed to place field: %v", err)
+		}
+		simulateDiceRoll(t, table, die1, die2)
+	}
+
+	field(1, 2) // win
+	field(1, 2) // win
+
+	// A barred 12 pushes the don't pass, which leaves the streak alone
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON DONT_PASS;"); err != nil {
+		t.Fatalf("Failed to place don't pass: %v", err)
+	}
+	simulateDiceRoll(t, table, 6, 6)
+
+	field(2, 3) // loss
+	field(2, 2) // win
+
+	player, _ := table.GetPlayer(playerID)
+	if player.Stats.LongestWinStreak != 2 || player.Stats.LongestLossStreak != 1 || player.Stats.CurrentStreak != 1 {
+		t.Errorf("Expected longest win 2, longest loss 1, current 1, got %+v", player.Stats)
+	}
+
+	results, err := executeCrapsQLForPlayer(t, table, playerID, "SHOW STREAK;")
+	if err != nil {
+		t.Fatalf("SHOW STREAK failed: %v", err)
+	}
+	expected := "Player player1 Streak: current 1 win(s), longest win streak 2, longest loss streak 1"
+	if len(results) != 1 || results[0] != expected {
+		t.Errorf("Expected %q, got %v", expected, results)
+	}
+}
+
+func TestShowStats(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	rolls := []struct {
+		bet        string
+		die1, die2 int
+	}{
+		{"PLACE $10 ON FIELD;", 1, 2}, // wins $10
+		{"PLACE $10 ON FIELD;", 6, 6}, // wins $30 on the 12
+		{"PLACE $20 ON FIELD;", 2, 3}, // loses $20
+		{"", 3, 3},                    // no action, so not survived
+	}
+	for _, roll := range rolls {
+		if roll.bet != "" {
+			if _, err := executeCrapsQLForPlayer(t, table, playerID, roll.bet); err != nil {
+				t.Fatalf("Failed to place %q: %v", roll.bet, err)
+			}
+		}
+		simulateDiceRoll(t, table, roll.die1, roll.die2)
+	}
+
+	interpreter := NewInterpreter(table)
+	show := func() string {
+		t.Helper()
+		results, err := interpreter.ExecuteStringForPlayer("SHOW STATS;", playerID)
+		if err != nil || len(results) != 1 {
+			t.Fatalf("SHOW STATS failed: %v (%v)", results, err)
+		}
+		return results[0]
+	}
+
+	expected := "Player player1 Stats: wagered $40.00, won $40.00, lost $20.00, biggest win $30.00, rolls survived 3"
+	if got := show(); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	// Setting the bankroll keeps the stats unless asked to reset them
+	if _, err := interpreter.ExecuteStringForPlayer("SET BANKROLL $500;", playerID); err != nil {
+		t.Fatalf("SET BANKROLL failed: %v", err)
+	}
+	if got := show(); got != expected {
+		t.Errorf("Expected stats kept after SET BANKROLL, got %q", got)
+	}
+
+	if _, err := interpreter.ExecuteStringForPlayer("SET BANKROLL $1000 RESET STATS;", playerID); err != nil {
+		t.Fatalf("SET BANKROLL RESET STATS failed: %v", err)
+	}
+	expected = "Player player1 Stats: wagered $0.00, won $0.00, lost $0.00, biggest win $0.00, rolls survived 0"
+	if got := show(); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+	verifyPlayerBankroll(t, table, playerID, 1000.0)
+}
+
+func TestExportSession(t *testing.T) {
+	table, players := setupTestGame(t)
+
+	// player1 wins a pass line bet and player2 loses a field bet on a natural 7
+	if _, err := executeCrapsQLForPlayer(t, table, players[0], "PLACE $25 ON PASS_LINE;"); err != nil {
+		t.Fatalf("Failed to place pass line bet: %v", err)
+	}
+	if _, err := executeCrapsQLForPlayer(t, table, players[1], "PLACE $10 ON FIELD;"); err != nil {
+		t.Fatalf("Failed to place field bet: %v", err)
+	}
+	simulateDiceRoll(t, table, 3, 4)
+
+	interpreter := NewInterpreter(table)
+
+	// CSV: transactions section, blank line, player summary section
+	data, err := interpreter.ExportSession("csv")
+	if err != nil {
+		t.Fatalf("CSV export failed: %v", err)
+	}
+	reader := csv.NewReader(strings.NewReader(string(data)))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to read exported CSV: %v", err)
+	}
+
+	expectedHeader := "time,player,bet_id,bet_type,type,amount,bankroll"
+	if strings.Join(records[0], ",") != expectedHeader {
+		t.Errorf("Expected transaction header %q, got %q", expectedHeader, strings.Join(records[0], ","))
+	}
+
+	// player, bet_type, type, amount, bankroll for each transaction
+	expectedTransactions := [][]string{
+		{"player1", "PASS_LINE", "BET", "25.00", "975.00"},
+		{"player2", "FIELD", "BET", "10.00", "990.00"},
+		{"player1", "PASS_LINE", "WIN", "50.00", "1025.00"},
+		{"player2", "FIELD", "LOSS", "10.00", "990.00"},
+	}
+	// Resolution order across players is not fixed, so match rows by player and type
+	for _, expected := range expectedTransactions {
+		found := false
+		for _, record := range records[1 : 1+len(expectedTransactions)] {
+			got := []string{record[1], record[3], record[4], record[5], record[6]}
+			if strings.Join(got, ",") == strings.Join(expected, ",") {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("Expected transaction row %v in export", expected)
+		}
+	}
+
+	summary := records[1+len(expectedTransactions):]
+	expectedSummary := [][]string{
+		{"player", "name", "bankroll", "bets_placed", "total_wagered", "total_won", "total_lost", "commission_paid", "net"},
+		{"player1", "Player 1", "1025.00", "1", "25.00", "25.00", "0.00", "0.00", "25.00"},
+		{"player2", "Player 2", "990.00", "1", "10.00", "0.00", "10.00", "0.00", "-10.00"},
+		{"player3", "Player 3", "1000.00", "0", "0.00", "0.00", "0.00", "0.00", "0.00"},
+	}
+	if len(summary) != len(expectedSummary) {
+		t.Fatalf("Expected %d summary rows, got %d: %v", len(expectedSummary), len(summary), summary)
+	}
+	for i, expected := range expectedSummary {
+		if strings.Join(summary[i], ",") != strings.Join(expected, ",") {
+			t.Errorf("Summary row %d: expected %v, got %v", i, expected, summary[i])
+		}
+	}
+
+	// JSON: transactions and players arrays with the same content
+	data, err = interpreter.ExportSession("json")
+	if err != nil {
+		t.Fatalf("JSON export failed: %v", err)
+	}
+	var report struct {
+		Transactions []struct {
+			Player   string  `json:"player"`
+			BetType  string  `json:"bet_type"`
+			Type     string  `json:"type"`
+			Amount   float64 `json:"amount"`
+			Bankroll float64 `json:"bankroll"`
+		} `json:"transactions"`
+		Players []struct {
+			ID           string  `json:"id"`
+			Bankroll     float64 `json:"bankroll"`
+			BetsPlaced   int     `json:"bets_placed"`
+			TotalWagered float64 `json:"total_wagered"`
+			Net          float64 `json:"net"`
+		} `json:"players"`
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("Failed to decode exported JSON: %v", err)
+	}
+	if len(report.Transactions) != 4 {
+		t.Errorf("Expected 4 transactions in JSON export, got %d", len(report.Transactions))
+	}
+	if len(report.Players) != 3 {
+		t.Fatalf("Expected 3 players in JSON export, got %d", len(report.Players))
+	}
+	if report.Players[0].ID != "player1" || report.Players[0].Bankroll != 1025.0 || report.Players[0].Net != 25.0 {
+		t.Errorf("Unexpected player1 summary: %+v", report.Players[0])
+	}
+	if report.Players[1].BetsPlaced != 1 || report.Players[1].TotalWagered != 10.0 || report.Players[1].Net != -10.0 {
+		t.Errorf("Unexpected player2 summary: %+v", report.Players[1])
+	}
+
+	if _, err := interpreter.ExportSession("xml"); err == nil {
+		t.Error("Expected error for unsupported export format, got nil")
+	}
+}
+
+func TestOutcomeHistogram(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	output, err := executeCrapsQLForPlayer(t, table, playerID, "SHOW OUTCOME HISTOGRAM;")
+	if err != nil {
+		t.Fatalf("SHOW OUTCOME HISTOGRAM failed: %v", err)
+	}
+	if !strings.Contains(strings.Join(output, "\n"), "no decided bets") {
+		t.Errorf("Expected empty histogram message, got: %v", output)
+	}
+
+	// Field loses on 6 while pass line establishes the point
+	executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON FIELD; PLACE $10 ON PASS_LINE;")
+	simulateDiceRoll(t, table, 3, 3)
+
+	// Field wins on 4
+	executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON FIELD; PLACE $12 ON PLACE_6;")
+	simulateDiceRoll(t, table, 2, 2)
+
+	// Point made: pass line and place 6 both win
+	simulateDiceRoll(t, table, 2, 4)
+
+	// Another player's decisions are not counted
+	executeCrapsQLForPlayer(t, table, players[1], "PLACE $10 ON FIELD;")
+	simulateDiceRoll(t, table, 3, 4)
+
+	// Bar 12: the don't pass pushes, which is neither a win nor a loss
+	executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON DONT_PASS;")
+	simulateDiceRoll(t, table, 6, 6)
+
+	histogram := table.OutcomeHistogram(playerID)
+	expected := map[string]crapsgame.OutcomeCount{
+		"DONT_PASS": {Pushes: 1},
+		"FIELD":     {Wins: 1, Losses: 1},
+		"PASS_LINE": {Wins: 1},
+		"PLACE_6":   {Wins: 1},
+	}
+	if len(histogram) != len(expected) {
+		t.Errorf("Expected %d bet types, got %v", len(expected), histogram)
+	}
+	for betType, want := range expected {
+		if got := histogram[betType]; got != want {
+			t.Errorf("%s: expected %+v, got %+v", betType, want, got)
+		}
+	}
+
+	output, err = executeCrapsQLForPlayer(t, table, playerID, "SHOW OUTCOME HISTOGRAM;")
+	if err != nil {
+		t.Fatalf("SHOW OUTCOME HISTOGRAM failed: %v", err)
+	}
+	if !strings.Contains(strings.Join(output, "\n"), "FIELD: 1 W / 1 L") || !strings.Contains(strings.Join(output, "\n"), "PLACE_6: 1 W / 0 L") ||
+		!strings.Contains(strings.Join(output, "\n"), "DONT_PASS: 0 W / 0 L / 1 P") {
+		t.Errorf("Unexpected histogram output: %v", output)
+	}
+}
+
+func TestTableJSONRoundTrip(t *testing.T) {
+	table, players := setupTestGame(t)
+	table.MaxComeBets = 2
+	table.MaxExposure = 800.0
+
+	executeCrapsQLForPlayer(t, table, players[0], "PLACE $25 ON PASS_LINE;")
+	simulateDiceRoll(t, table, 4, 4) // Point 8
+	executeCrapsQLForPlayer(t, table, players[0], "PLACE $50 ON PASS_ODDS; PLACE $10 ON COME;")
+	executeCrapsQLForPlayer(t, table, players[1], "PLACE $12 ON PLACE_6; PLACE $5 ON HARD_8; TURN OFF PLACE_6;")
+	simulateDiceRoll(t, table, 2, 3) // Come point 5
+	if err := table.SetDiceSetting("hard_ways"); err != nil {
+		t.Fatalf("Failed to set dice: %v", err)
+	}
+
+	data, err := json.Marshal(table)
+	if err != nil {
+		t.Fatalf("Failed to marshal table: %v", err)
+	}
+	restored, err := crapsgame.LoadTable(data)
+	if err != nil {
+		t.Fatalf("Failed to load table: %v", err)
+	}
+
+	// Everything saved comes back unchanged
+	again, err := json.Marshal(restored)
+	if err != nil {
+		t.Fatalf("Failed to marshal restored table: %v", err)
+	}
+	if string(again) != string(data) {
+		t.Errorf("Round trip changed the table:\n%s\n%s", data, again)
+	}
+
+	verifyGameState(t, restored, crapsgame.StatePoint, crapsgame.Point8)
+	if restored.Shooter != table.Shooter || !reflect.DeepEqual(restored.Seats, table.Seats) {
+		t.Errorf("Expected shooter %s and seats %v, got %s and %v", table.Shooter, table.Seats, restored.Shooter, restored.Seats)
+	}
+	if !restored.CurrentRoll.Time.Equal(table.CurrentRoll.Time) || !restored.LastRoll.Equal(table.LastRoll) {
+		t.Errorf("Expected roll time %v, got %v", table.CurrentRoll.Time, restored.CurrentRoll.Time)
+	}
+	if restored.LastDecision() != table.LastDecision() || restored.MaxExposure != 800.0 || restored.MaxComeBets != 2 {
+		t.Errorf("Expected table settings to survive the round trip")
+	}
+	if !reflect.DeepEqual(restored.BankrollSnapshot(), table.BankrollSnapshot()) {
+		t.Errorf("Expected bankrolls %v, got %v", table.BankrollSnapshot(), restored.BankrollSnapshot())
+	}
+	for _, playerID := range players {
+		original, _ := table.GetPlayer(playerID)
+		loaded, _ := restored.GetPlayer(playerID)
+		if len(loaded.Bets) != len(original.Bets) {
+			t.Fatalf("Expected %d bets for %s, got %d", len(original.Bets), playerID, len(loaded.Bets))
+		}
+		for i, bet := range original.Bets {
+			got := loaded.Bets[i]
+			if got.ID != bet.ID || got.Type != bet.Type || got.Amount != bet.Amount ||
+				!reflect.DeepEqual(got.Numbers, bet.Numbers) || got.Working != bet.Working ||
+				got.PlayerWorking != bet.PlayerWorking || got.ParentBetID != bet.ParentBetID ||
+				!got.PlacedAt.Equal(bet.PlacedAt) {
+				t.Errorf("Bet %d for %s: expected %+v, got %+v", i, playerID, bet, got)
+			}
+		}
+	}
+
+	// Both tables play on identically
+	simulateDiceRoll(t, table, 4, 4)
+	simulateDiceRoll(t, restored, 4, 4)
+	if !reflect.DeepEqual(restored.BankrollSnapshot(), table.BankrollSnapshot()) {
+		t.Errorf("Expected the same payouts after the point, got %v and %v", table.BankrollSnapshot(), restored.BankrollSnapshot())
+	}
+
+	if _, err := crapsgame.LoadTable([]byte("{not json")); err == nil {
+		t.Error("Expected error loading invalid JSON")
+	}
+}
+
+func TestPlayerValidate(t *testing.T) {
+	table, players := setupTestGame(t)
+	executeCrapsQLForPlayer(t, table, players[0], "PLACE $25 ON PASS_LINE; PLACE $10 ON FIELD;")
+
+	player, _ := table.GetPlayer(players[0])
+	if err := player.Validate(); err != nil {
+		t.Fatalf("Expected a valid player, got %v", err)
+	}
+
+	duplicate := &crapsgame.Player{ID: "dup", MinBet: 5, MaxBet: 100, Bets: []*crapsgame.Bet{
+		{ID: "bet_1", Type: "FIELD", Amount: 10, Player: "dup"},
+		{ID: "bet_1", Type: "PASS_LINE", Amount: 10, Player: "dup"},
+	}}
+	if err := duplicate.Validate(); err == nil || !strings.Contains(err.Error(), "duplicate bet ID bet_1") {
+		t.Errorf("Expected duplicate bet ID error, got %v", err)
+	}
+
+	mismatched := &crapsgame.Player{ID: "alice", Bets: []*crapsgame.Bet{
+		{ID: "bet_2", Type: "FIELD", Amount: 10, Player: "bob"},
+	}}
+	if err := mismatched.Validate(); err == nil || !strings.Contains(err.Error(), "belonging to bob") {
+		t.Errorf("Expected mismatched owner error, got %v", err)
+	}
+
+	negative := &crapsgame.Player{ID: "neg", Bets: []*crapsgame.Bet{
+		{ID: "bet_3", Type: "FIELD", Amount: -10, Player: "neg"},
+	}}
+	if err := negative.Validate(); err == nil || !strings.Contains(err.Error(), "negative amount") {
+		t.Errorf("Expected negative amount error, got %v", err)
+	}
+
+	limits := &crapsgame.Player{ID: "limits", MinBet: 50, MaxBet: 25}
+	if err := limits.Validate(); err == nil || !strings.Contains(err.Error(), "exceeds maximum bet") {
+		t.Errorf("Expected min/max error, got %v", err)
+	}
+
+	// A saved table with a broken player is rejected on load
+	player.Bets[1].ID = player.Bets[0].ID
+	data, err := json.Marshal(table)
+	if err != nil {
+		t.Fatalf("Failed to marshal table: %v", err)
+	}
+	if _, err := crapsgame.LoadTable(data); err == nil || !strings.Contains(err.Error(), "duplicate bet ID") {
+		t.Errorf("Expected LoadTable to reject a duplicate bet ID, got %v", err)
+	}
+}
+
+// 6.12 Auto-Advance Tests
+func TestRollUntilResolved(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	// Come-out 7 (place bet off), point of 4, a 3, then the 6 hits
+	table.SetDiceSource(newScriptedDice([2]int{3, 4}, [2]int{2, 2}, [2]int{1, 2}, [2]int{3, 3}))
+
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $12 ON PLACE_6;")
+	if err != nil {
+		t.Fatalf("Failed to place bet on 6: %v", err)
+	}
+
+	results, err := executeCrapsQLForPlayer(t, table, playerID, "ROLL UNTIL RESOLVED;")
+	if err != nil {
+		t.Fatalf("ROLL UNTIL RESOLVED failed: %v", err)
+	}
+	if len(results) != 1 || !strings.Contains(results[0], "resolved after 4 roll(s)") {
+		t.Errorf("Expected resolution after 4 rolls, got %v", results)
+	}
+
+	verifyGameState(t, table, crapsgame.StatePoint, crapsgame.Point4)
+	verifyBetExists(t, table, playerID, "PLACE_6", 12.0)
+	verifyPlayerBankroll(t, table, playerID, 1000.0-12.0+14.0)
+
+	// Without any bets there is nothing to wait for
+	if _, err := executeCrapsQLForPlayer(t, table, players[1], "ROLL UNTIL RESOLVED;"); err == nil {
+		t.Error("Expected error rolling until resolved with no bets, got nil")
+	}
+}
+
+// 6.13 Commission Accounting Tests
+func TestBuyCommissionPaidUpFront(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+	table.BuyCommissionMode = crapsgame.CommissionOnPlacement
+
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $20 ON BUY_4;")
+	if err != nil {
+		t.Fatalf("Failed to place buy bet: %v", err)
+	}
+
+	// Stake and 5% vig both leave the bankroll, but only the stake is on the bet
+	verifyBetExists(t, table, playerID, "BUY_4", 20.0)
+	verifyPlayerBankroll(t, table, playerID, 979.0)
+	player, _ := table.GetPlayer(playerID)
+	if player.Bets[0].CommissionPaid != 1.0 {
+		t.Errorf("Expected CommissionPaid 1.00, got %.2f", player.Bets[0].CommissionPaid)
+	}
+
+	transactions := table.GetTransactions(playerID)
+	if len(transactions) != 2 {
+		t.Fatalf("Expected BET and COMMISSION transactions, got %v", transactions)
+	}
+	if transactions[0].Type != crapsgame.TransactionBet || transactions[0].Amount != 20.0 {
+		t.Errorf("Expected BET of $20.00, got %s of $%.2f", transactions[0].Type, transactions[0].Amount)
+	}
+	if transactions[1].Type != crapsgame.TransactionCommission || transactions[1].Amount != 1.0 {
+		t.Errorf("Expected COMMISSION of $1.00, got %s of $%.2f", transactions[1].Type, transactions[1].Amount)
+	}
+
+	results, err := executeCrapsQLForPlayer(t, table, playerID, "SHOW MY BETS;")
+	if err != nil {
+		t.Fatalf("SHOW MY BETS failed: %v", err)
+	}
+	if len(results) != 1 || !strings.Contains(results[0], "BUY_4: $20.00 (commission paid: $1.00)") {
+		t.Errorf("Expected SHOW MY BETS to list the commission, got %v", results)
+	}
+
+	// Establish the 4, then hit it: full 2:1 with no further vig
+	simulateDiceRoll(t, table, 2, 2)
+	simulateDiceRoll(t, table, 1, 3)
+	verifyBetExists(t, table, playerID, "BUY_4", 20.0)
+	verifyPlayerBankroll(t, table, playerID, 1019.0)
+
+	if player.Stats.TotalWon != 40.0 {
+		t.Errorf("Expected total won $40.00, got $%.2f", player.Stats.TotalWon)
+	}
+	if player.Stats.CommissionPaid != 1.0 {
+		t.Errorf("Expected commission paid $1.00, got $%.2f", player.Stats.CommissionPaid)
+	}
+	if player.Stats.Net() != 39.0 {
+		t.Errorf("Expected net $39.00 after commission, got $%.2f", player.Stats.Net())
+	}
+}
+
+func TestBuyCommissionModesNetTheSame(t *testing.T) {
+	testCases := []struct {
+		name       string
+		mode       crapsgame.BuyCommissionMode
+		afterPlace float64
+		won        float64
+	}{
+		// $1 vig comes out of the $40 win
+		{"on win", crapsgame.CommissionOnWin, 980.0, 39.0},
+		// $1 vig is paid with the bet and the win pays full 2:1
+		{"on placement", crapsgame.CommissionOnPlacement, 979.0, 40.0},
+	}
+
+	for _, tc := range testCases {
+		table, players := setupTestGame(t)
+		playerID := players[0]
+		table.BuyCommissionMode = tc.mode
+
+		if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $20 ON BUY_4;"); err != nil {
+			t.Fatalf("%s: failed to place buy bet: %v", tc.name, err)
+		}
+		player, _ := table.GetPlayer(playerID)
+		if player.Bankroll != tc.afterPlace {
+			t.Errorf("%s: expected bankroll $%.2f after placing, got $%.2f", tc.name, tc.afterPlace, player.Bankroll)
+		}
+
+		simulateDiceRoll(t, table, 2, 2) // point is 4, buy bet works
+		simulateDiceRoll(t, table, 1, 3)
+		if player.Stats.TotalWon != tc.won {
+			t.Errorf("%s: expected total won $%.2f, got $%.2f", tc.name, tc.won, player.Stats.TotalWon)
+		}
+
+		// Either way the player is up $39 with the $20 bet still working
+		verifyBetExists(t, table, playerID, "BUY_4", 20.0)
+		verifyPlayerBankroll(t, table, playerID, 1019.0)
+	}
+}
+
+// 6.14 Player Bet Query Tests
+func TestShowIfRoll(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON PASS_LINE;"); err != nil {
+		t.Fatalf("Failed to place pass line: %v", err)
+	}
+	simulateDiceRoll(t, table, 2, 2)
+	_, err := executeCrapsQLForPlayer(t, table, playerID,
+		"PLACE $12 ON PLACE_6; PLACE $10 ON FIELD; PLACE $10 ON HARD_6; PLACE $5 ON ANY_SEVEN;")
+	if err != nil {
+		t.Fatalf("Failed to place bets: %v", err)
+	}
+
+	testCases := []struct {
+		query    string
+		expected string
+	}{
+		{"SHOW IF ROLL 7;", "If 7 (1+6) rolls for player1:\n" +
+			"  PASS_LINE: loses $10.00\n" +
+			"  PLACE_6: loses $12.00\n" +
+			"  FIELD: loses $10.00\n" +
+			"  HARD_6: loses $10.00\n" +
+			"  ANY_SEVEN: wins $20.00"},
+		{"SHOW IF ROLL 6;", "If 6 (1+5) rolls for player1:\n" +
+			"  PASS_LINE: no decision\n" +
+			"  PLACE_6: wins $14.00\n" +
+			"  FIELD: loses $10.00\n" +
+			"  HARD_6: loses $10.00\n" +
+			"  ANY_SEVEN: loses $5.00"},
+		{"SHOW IF ROLL 3 3;", "If 6 (3+3) rolls for player1:\n" +
+			"  PASS_LINE: no decision\n" +
+			"  PLACE_6: wins $14.00\n" +
+			"  FIELD: loses $10.00\n" +
+			"  HARD_6: wins $90.00\n" +
+			"  ANY_SEVEN: loses $5.00"},
+	}
+	for _, tc := range testCases {
+		results, err := executeCrapsQLForPlayer(t, table, playerID, tc.query)
+		if err != nil {
+			t.Fatalf("%s failed: %v", tc.query, err)
+		}
+		if len(results) != 1 || results[0] != tc.expected {
+			t.Errorf("%s: expected %q, got %v", tc.query, tc.expected, results)
+		}
+	}
+
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "SHOW IF ROLL 7 1;"); err == nil {
+		t.Error("Expected error previewing a 7 on one die, got nil")
+	}
+
+	// Previews leave the table as it was
+	verifyGameState(t, table, crapsgame.StatePoint, crapsgame.Point4)
+	verifyPlayerBankroll(t, table, playerID, 1000.0-10.0-12.0-10.0-10.0-5.0)
+	if count := getPlayerBetCount(t, table, playerID); count != 5 {
+		t.Errorf("Expected 5 bets after previewing, got %d", count)
+	}
+}
+
+func TestShowBetsListsPlayerBets(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	// Set the point at 4 so the pass line has a point and the place 6 works
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON PASS_LINE;"); err != nil {
+		t.Fatalf("Failed to place pass line: %v", err)
+	}
+	simulateDiceRoll(t, table, 2, 2)
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $12 ON PLACE_6; TURN OFF PLACE_6; PLACE $5 ON HARD_8;")
+	if err != nil {
+		t.Fatalf("Failed to place bets: %v", err)
+	}
+
+	results, err := executeCrapsQLForPlayer(t, table, playerID, "SHOW BETS;")
+	if err != nil {
+		t.Fatalf("SHOW BETS failed: %v", err)
+	}
+	output := strings.Join(results, "\n")
+	for _, expected := range []string{
+		"Player player1 Bets:",
+		"PASS_LINE: $10.00 (point 4) [WORKING]",
+		"PLACE_6: $12.00 [OFF]",
+		"HARD_8: $5.00 [WORKING]",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected %q in SHOW BETS, got %q", expected, output)
+		}
+	}
+	if strings.Contains(output, "AVAILABLE BET TYPES") {
+		t.Errorf("Expected SHOW BETS to list the player's bets, not the catalog, got %q", output)
+	}
+}
+
+func TestShowMyBetsRollFilters(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON FIELD; PLACE $12 ON PLACE_6;")
+	if err != nil {
+		t.Fatalf("Failed to place bets: %v", err)
+	}
+
+	testCases := []struct {
+		query    string
+		included []string
+		excluded []string
+	}{
+		{"SHOW MY BETS;", []string{"FIELD", "PLACE_6"}, nil},
+		{"SHOW MY BETS ONE_ROLL;", []string{"FIELD"}, []string{"PLACE_6"}},
+		{"SHOW MY BETS MULTI_ROLL;", []string{"PLACE_6"}, []string{"FIELD"}},
+	}
+
+	for _, tc := range testCases {
+		results, err := executeCrapsQLForPlayer(t, table, playerID, tc.query)
+		if err != nil {
+			t.Fatalf("%s failed: %v", tc.query, err)
+		}
+		output := strings.Join(results, "\n")
+		for _, betType := range tc.included {
+			if !strings.Contains(output, betType+":") {
+				t.Errorf("%s: expected %s in output, got %q", tc.query, betType, output)
+			}
+		}
+		for _, betType := range tc.excluded {
+			if strings.Contains(output, betType+":") {
+				t.Errorf("%s: expected %s to be filtered out, got %q", tc.query, betType, output)
+			}
+		}
+	}
+}
+
+// ============================================================================
+// 7. Helper Functions for Integration Tests
+// ============================================================================
+
+// setupTestGame creates a standard test game setup
+func setupTestGame(t *testing.T) (*crapsgame.Table, []string) {
+	table := crapsgame.NewTable(5.0, 1000.0, 3)
+
+	// Add test players
+	players := []string{"player1", "player2", "player3"}
+	for i, playerID := range players {
+		err := table.AddPlayer(playerID, fmt.Sprintf("Player %d", i+1), 1000.0)
+		if err != nil {
+			t.Fatalf("Failed to add player %s: %v", playerID, err)
+		}
+	}
+
+	return table, players
+}
+
+// executeCrapsQL executes a CrapsQL statement and returns results
+func executeCrapsQL(t *testing.T, table *crapsgame.Table, statement string) ([]string, error) {
+	interpreter := NewInterpreter(table)
+	return interpreter.ExecuteString(statement)
+}
+
+// executeCrapsQLForPlayer executes a CrapsQL statement for a specific player
+func executeCrapsQLForPlayer(t *testing.T, table *crapsgame.Table, playerID string, statement string) ([]string, error) {
+	interpreter := NewInterpreter(table)
+	return interpreter.ExecuteStringForPlayer(statement, playerID)
+}
+
+// verifyGameState verifies the game is in expected state
+func verifyGameState(t *testing.T, table *crapsgame.Table, expectedState crapsgame.GameState, expectedPoint crapsgame.Point) {
+	if table.State != expectedState {
+		t.Errorf("Expected game state %v, got %v", expectedState, table.State)
+	}
+
+	if table.Point != expectedPoint {
+		t.Errorf("Expected point %v, got %v", expectedPoint, table.Point)
+	}
+}
+
+// verifyPlayerBankroll verifies player bankroll is expected amount
+func verifyPlayerBankroll(t *testing.T, table *crapsgame.Table, playerID string, expectedBankroll float64) {
+	player, err := table.GetPlayer(playerID)
+	if err != nil {
+		t.Fatalf("Failed to get player %s: %v", playerID, err)
+	}
+
+	if player.Bankroll != expectedBankroll {
+		t.Errorf("Expected player %s bankroll to be $%.2f, got $%.2f", playerID, expectedBankroll, player.Bankroll)
+	}
+}
+
+// verifyBetExists verifies a specific bet exists for a player
+func verifyBetExists(t *testing.T, table *crapsgame.Table, playerID string, betType string, amount float64) {
+	player, err := table.GetPlayer(playerID)
+	if err != nil {
+		t.Fatalf("Failed to get player %s: %v", playerID, err)
+	}
+
+	found := false
+	for _, bet := range player.Bets {
+		if bet.Type == betType && bet.Amount == amount {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		t.Errorf("Expected bet %s with amount $%.2f for player %s, not found", betType, amount, playerID)
+	}
+}
+
+// verifyBetNotExists verifies a specific bet does NOT exist for a player
+func verifyBetNotExists(t *testing.T, table *crapsgame.Table, playerID string, betType string) {
+	player, err := table.GetPlayer(playerID)
+	if err != nil {
+		t.Fatalf("Failed to get player %s: %v", playerID, err)
+	}
+
+	for _, bet := range player.Bets {
+		if bet.Type == betType {
+			t.Errorf("Expected bet %s to NOT exist for player %s, but found bet with amount $%.2f", betType, playerID, bet.Amount)
+			return
+		}
+	}
+}
+
+// simulateDiceRoll simulates a dice roll with specific outcome
+// playSequence plays scripted rolls through Table.PlaySequence, failing the
+// test on an invalid sequence
+func playSequence(t *testing.T, table *crapsgame.Table, dice [][2]int) [][]crapsgame.BetResult {
+	t.Helper()
+	results, err := table.PlaySequence(dice)
+	if err != nil {
+		t.Fatalf("PlaySequence failed: %v", err)
+	}
+	return results
+}
+
+func simulateDiceRoll(t *testing.T, table *crapsgame.Table, dice1, dice2 int) (*crapsgame.Roll, []string) {
+	// Create a roll with the specified values
+	roll := &crapsgame.Roll{
+		Die1:   dice1,
+		Die2:   dice2,
+		Total:  dice1 + dice2,
+		IsHard: dice1 == dice2,
+		Time:   time.Now(),
+	}
+
+	// Set the roll as current
+	table.CurrentRoll = roll
+
+	// Resolve all bets FIRST (before game state changes)
+	results := table.ResolveAllBets(roll)
+
+	// THEN update game state
+	table.UpdateGameState(roll)
+
+	return roll, results
+}
+
+// scriptedDice is a DiceSource that replays a fixed sequence of rolls, cycling when exhausted
+type scriptedDice struct {
+	rolls [][2]int
+	next  int
+}
+
+func newScriptedDice(rolls ...[2]int) *scriptedDice {
+	return &scriptedDice{rolls: rolls}
+}
+
+func (d *scriptedDice) Roll() (int, int) {
+	roll := d.rolls[d.next%len(d.rolls)]
+	d.next++
+	return roll[0], roll[1]
+}
+
+// getPlayerBetCount returns the number of bets a player has
+func getPlayerBetCount(t *testing.T, table *crapsgame.Table, playerID string) int {
+	player, err := table.GetPlayer(playerID)
+	if err != nil {
+		t.Fatalf("Failed to get player %s: %v", playerID, err)
+	}
+	return len(player.Bets)
+}
+
+func TestSessionLimitsStopBetting(t *testing.T) {
+	table, players := setupTestGame(t)
+	winner, loser := players[0], players[1]
+	interpreter := NewInterpreter(table)
+
+	if _, err := interpreter.ExecuteStringForPlayer("SET WIN_GOAL $50; PLACE $50 ON FIELD;", winner); err != nil {
+		t.Fatalf("Failed to set up winner: %v", err)
+	}
+	if _, err := interpreter.ExecuteStringForPlayer("SET LOSS_LIMIT $30; PLACE $10 ON PASS_LINE; PLACE $25 ON ANY_SEVEN;", loser); err != nil {
+		t.Fatalf("Money on the layout shouldn't count as lost: %v", err)
+	}
+
+	// The field wins $50 for the winner, reaching the goal
+	simulateDiceRoll(t, table, 1, 2)
+	_, err := interpreter.ExecuteStringForPlayer("PLACE $10 ON FIELD;", winner)
+	if err == nil || !strings.Contains(err.Error(), "win goal reached, stop betting") {
+		t.Errorf("Expected the win goal to stop betting, got %v", err)
+	}
+	results, _ := interpreter.ExecuteStringForPlayer("SHOW WHY;", winner)
+	if len(results) != 1 || !strings.Contains(results[0], "win goal or loss limit") {
+		t.Errorf("Expected SHOW WHY to explain the session limit, got %v", results)
+	}
+
+	// The 3 takes the loser's pass line and any seven; down $35
+	_, err = interpreter.ExecuteStringForPlayer("PLACE $10 ON FIELD;", loser)
+	if err == nil || !strings.Contains(err.Error(), "loss limit reached, stop betting") {
+		t.Errorf("Expected the loss limit to stop betting, got %v", err)
+	}
+	verifyPlayerBankroll(t, table, loser, 965.0)
+
+	// A new bankroll starts a new session
+	if _, err := interpreter.ExecuteStringForPlayer("SET BANKROLL $1000; PLACE $10 ON FIELD;", winner); err != nil {
+		t.Errorf("Expected betting to resume after SET BANKROLL, got %v", err)
+	}
+}
+
+func TestDontPassOdds(t *testing.T) {
+	testCases := []struct {
+		name       string
+		point      [2]int
+		decision   [2]int
+		bankroll   float64
+		betsRemain bool
+	}{
+		// $20 back on the line and $15 for laying $30 at 1:2 against the 4
+		{"seven out", [2]int{2, 2}, [2]int{3, 4}, 950.0 + 40.0 + 45.0, false},
+		// The shooter makes the 6, taking the line bet and the odds
+		{"point made", [2]int{3, 3}, [2]int{4, 2}, 950.0, false},
+		// Any other number leaves both up
+		{"no decision", [2]int{3, 3}, [2]int{4, 4}, 950.0, true},
+	}
+
+	for _, tc := range testCases {
+		table, players := setupTestGame(t)
+		playerID := players[0]
+
+		if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $20 ON DONT_PASS;"); err != nil {
+			t.Fatalf("%s: failed to place don't pass: %v", tc.name, err)
+		}
+		simulateDiceRoll(t, table, tc.point[0], tc.point[1])
+
+		// Odds laid right after the point is set are decided with the line bet
+		if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $30 ON DONT_PASS_ODDS;"); err != nil {
+			t.Fatalf("%s: failed to lay odds: %v", tc.name, err)
+		}
+		results := playSequence(t, table, [][2]int{tc.decision})
+		for _, result := range results[0] {
+			if result.Message == "" {
+				t.Errorf("%s: expected a message for %s, got none", tc.name, result.BetType)
+			}
+		}
+
+		verifyPlayerBankroll(t, table, playerID, tc.bankroll)
+		if tc.betsRemain {
+			verifyBetExists(t, table, playerID, "DONT_PASS", 20.0)
+			verifyBetExists(t, table, playerID, "DONT_PASS_ODDS", 30.0)
+		} else {
+			verifyBetNotExists(t, table, playerID, "DONT_PASS")
+			verifyBetNotExists(t, table, playerID, "DONT_PASS_ODDS")
+		}
+	}
+}
+
+func TestOddsComeDownWithTheirLineBet(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON PASS_LINE;"); err != nil {
+		t.Fatalf("Failed to place pass line: %v", err)
+	}
+	simulateDiceRoll(t, table, 3, 3) // point is 6
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $20 ON PASS_ODDS;"); err != nil {
+		t.Fatalf("Failed to place odds: %v", err)
+	}
+
+	// Making the point pays the line bet even money and the odds 6:5 together
+	results := playSequence(t, table, [][2]int{{4, 2}})[0]
+	decided := make(map[string]string)
+	for _, result := range results {
+		decided[result.BetType] = result.Outcome
+	}
+	if decided["PASS_LINE"] != crapsgame.OutcomeWin || decided["PASS_ODDS"] != crapsgame.OutcomeWin {
+		t.Errorf("Expected the line bet and odds to win on the same roll, got %v", decided)
+	}
+	verifyBetNotExists(t, table, playerID, "PASS_LINE")
+	verifyBetNotExists(t, table, playerID, "PASS_ODDS")
+	verifyPlayerBankroll(t, table, playerID, 1000.0+10.0+24.0)
+
+	// Odds turned off aren't decided, but still come down with the line bet
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON PASS_LINE;"); err != nil {
+		t.Fatalf("Failed to place pass line: %v", err)
+	}
+	simulateDiceRoll(t, table, 2, 2) // point is 4
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $20 ON PASS_ODDS; TURN OFF PASS_ODDS;"); err != nil {
+		t.Fatalf("Failed to place odds and turn them off: %v", err)
+	}
+	results = playSequence(t, table, [][2]int{{3, 4}})[0]
+	returned := false
+	for _, result := range results {
+		if result.BetType == "PASS_ODDS" && result.Outcome == crapsgame.OutcomePush {
+			returned = true
+		}
+	}
+	if !returned {
+		t.Errorf("Expected the odds to be returned when the line bet lost, got %v", results)
+	}
+	verifyBetNotExists(t, table, playerID, "PASS_ODDS")
+	verifyPlayerBankroll(t, table, playerID, 1034.0-10.0)
+}
+
+func TestRemovePlayerRefundsOdds(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON PASS_LINE;"); err != nil {
+		t.Fatalf("Failed to place pass line: %v", err)
+	}
+	simulateDiceRoll(t, table, 3, 3) // point is 6
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $20 ON PASS_ODDS; TURN OFF PASS_ODDS;"); err != nil {
+		t.Fatalf("Failed to place odds: %v", err)
+	}
+
+	if err := table.RemovePlayer(playerID); err != nil {
+		t.Fatalf("Failed to remove player: %v", err)
+	}
+
+	refunded := make(map[string]float64)
+	for _, tx := range table.Transactions {
+		if tx.PlayerID == playerID && tx.Type == crapsgame.TransactionRefund {
+			refunded[tx.BetType] += tx.Amount
+		}
+	}
+	if refunded["PASS_LINE"] != 10.0 || refunded["PASS_ODDS"] != 20.0 {
+		t.Errorf("Expected the line bet and odds to be refunded, got %v", refunded)
+	}
+}
+
+func TestTakeDownAll(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON PASS_LINE;"); err != nil {
+		t.Fatalf("Failed to place pass line: %v", err)
+	}
+	simulateDiceRoll(t, table, 2, 2) // point is 4
+
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $20 ON PASS_ODDS; PLACE $12 ON PLACE_6; PLACE $10 ON COME;")
+	if err != nil {
+		t.Fatalf("Failed to place bets: %v", err)
+	}
+	simulateDiceRoll(t, table, 2, 3) // the come bet travels to the 5
+	verifyPlayerBankroll(t, table, playerID, 948.0)
+
+	interpreter := NewInterpreter(table)
+	results, err := interpreter.ExecuteStringForPlayer("TAKE DOWN ALL;", playerID)
+	if err != nil {
+		t.Fatalf("TAKE DOWN ALL failed: %v", err)
+	}
+	if len(results) != 1 || results[0] != "✅ Took down 2 bets, returned $32.00 to bankroll" {
+		t.Errorf("Expected the odds and place 6 to come down, got %v", results)
+	}
+
+	// The pass line and the come bet on the 5 are contract bets
+	verifyBetExists(t, table, playerID, "PASS_LINE", 10.0)
+	verifyBetExists(t, table, playerID, "COME", 10.0)
+	verifyBetNotExists(t, table, playerID, "PASS_ODDS")
+	verifyBetNotExists(t, table, playerID, "PLACE_6")
+	verifyPlayerBankroll(t, table, playerID, 980.0)
+
+	results, err = interpreter.ExecuteStringForPlayer("TAKE_DOWN ALL;", playerID)
+	if err != nil || len(results) != 1 || results[0] != "ℹ️ No bets that can come down" {
+		t.Errorf("Expected nothing left to take down, got %v (%v)", results, err)
+	}
+}
+
+func TestRemoveBetByIDAndRemoveAll(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	small, err := table.PlaceBet(playerID, "PLACE_6", 12.0, nil)
+	if err != nil {
+		t.Fatalf("Failed to place $12 on the 6: %v", err)
+	}
+	if _, err := table.PlaceBet(playerID, "PLACE_6", 24.0, nil); err != nil {
+		t.Fatalf("Failed to place $24 on the 6: %v", err)
+	}
+
+	// Quoted, since bet IDs may start with a digit
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, fmt.Sprintf("REMOVE BET \"%s\";", small.ID)); err != nil {
+		t.Fatalf("REMOVE BET failed: %v", err)
+	}
+	verifyBetExists(t, table, playerID, "PLACE_6", 24.0)
+	if count := getPlayerBetCount(t, table, playerID); count != 1 {
+		t.Errorf("Expected 1 bet left, got %d", count)
+	}
+	verifyPlayerBankroll(t, table, playerID, 1000.0-24.0)
+
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, fmt.Sprintf("REMOVE BET \"%s\";", small.ID)); err == nil {
+		t.Error("Expected error removing a bet that is already down, got nil")
+	}
+
+	// REMOVE ALL refunds every working bet; an off bet stays up
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON FIELD; PLACE $10 ON HARD_8 OFF;"); err != nil {
+		t.Fatalf("Failed to place bets: %v", err)
+	}
+	results, err := executeCrapsQLForPlayer(t, table, playerID, "REMOVE ALL;")
+	if err != nil {
+		t.Fatalf("REMOVE ALL failed: %v", err)
+	}
+	if len(results) != 1 || results[0] != "✅ Removed 2 bets, returned $34.00 to bankroll" {
+		t.Errorf("Unexpected REMOVE ALL output: %v", results)
+	}
+	verifyBetNotExists(t, table, playerID, "PLACE_6")
+	verifyBetNotExists(t, table, playerID, "FIELD")
+	verifyBetExists(t, table, playerID, "HARD_8", 10.0)
+	verifyPlayerBankroll(t, table, playerID, 1000.0-10.0)
+
+	// Neither takes down a contract bet: the pass line on a point, or a come
+	// bet that has traveled
+	line, err := table.PlaceBet(players[1], "PASS_LINE", 10.0, nil)
+	if err != nil {
+		t.Fatalf("Failed to place pass line: %v", err)
+	}
+	simulateDiceRoll(t, table, 2, 2) // Point 4
+	if _, err := executeCrapsQLForPlayer(t, table, players[1], "PLACE $10 ON COME; PLACE $10 ON FIELD;"); err != nil {
+		t.Fatalf("Failed to place bets: %v", err)
+	}
+	simulateDiceRoll(t, table, 3, 3) // Come travels to the 6, field loses
+	if _, err := executeCrapsQLForPlayer(t, table, players[1], fmt.Sprintf("REMOVE BET \"%s\";", line.ID)); err == nil {
+		t.Error("Expected error removing a pass line bet on a point, got nil")
+	}
+	if _, err := executeCrapsQLForPlayer(t, table, players[1], "PLACE $12 ON PLACE_8;"); err != nil {
+		t.Fatalf("Failed to place bet on 8: %v", err)
+	}
+	results, err = executeCrapsQLForPlayer(t, table, players[1], "REMOVE ALL;")
+	if err != nil {
+		t.Fatalf("REMOVE ALL failed: %v", err)
+	}
+	if len(results) != 1 || results[0] != "✅ Removed 1 bets, returned $12.00 to bankroll" {
+		t.Errorf("Unexpected REMOVE ALL output: %v", results)
+	}
+	verifyBetExists(t, table, players[1], "PASS_LINE", 10.0)
+	verifyBetExists(t, table, players[1], "COME", 10.0)
+	verifyPlayerBankroll(t, table, players[1], 1000.0-30.0)
+}
+
+func TestTurnOffPreservation(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	// First, place a bet
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PLACE_6;")
+	if err != nil {
+		t.Fatalf("Failed to place PLACE_6 bet: %v", err)
+	}
+
+	// Verify bet exists and is working by default
+	player, err := table.GetPlayer(playerID)
+	if err != nil {
+		t.Fatalf("Failed to get player: %v", err)
+	}
+
+	var place6Bet *crapsgame.Bet
+	for _, bet := range player.Bets {
+		if bet.Type == "PLACE_6" {
+			place6Bet = bet
+			break
+		}
+	}
+
+	if place6Bet == nil {
+		t.Fatalf("PLACE_6 bet not found")
+	}
+
+	// Verify initial state: off for the come-out, on for the player
+	if place6Bet.Working {
+		t.Errorf("Bet should be off on the come-out initially, got Working=%v", place6Bet.Working)
+	}
+	if !place6Bet.PlayerWorking {
+		t.Errorf("Bet should have PlayerWorking=true initially, got PlayerWorking=%v", place6Bet.PlayerWorking)
+	}
+
+	// Turn the bet OFF
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "TURN OFF PLACE_6;")
+	if err != nil {
+		t.Fatalf("Failed to turn OFF PLACE_6 bet: %v", err)
+	}
+
+	// Verify bet is now OFF
+	if place6Bet.Working {
+		t.Errorf("Bet should be OFF after TURN OFF, got Working=%v", place6Bet.Working)
+	}
+	if place6Bet.PlayerWorking {
+		t.Errorf("Bet should have PlayerWorking=false after TURN OFF, got PlayerWorking=%v", place6Bet.PlayerWorking)
+	}
+
+	// Simulate system working status update (this used to be the bug)
+	table.UpdateBetWorkingStatus()
+
+	// Verify bet is STILL OFF after system update
+	if place6Bet.Working {
+		t.Errorf("Bet should remain OFF after UpdateBetWorkingStatus, got Working=%v", place6Bet.Working)
+	}
+	if place6Bet.PlayerWorking {
+		t.Errorf("Bet should still have PlayerWorking=false after UpdateBetWorkingStatus, got PlayerWorking=%v", place6Bet.PlayerWorking)
+	}
+
+	// Roll dice to see that OFF bet is not resolved
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "ROLL DICE;")
+	if err != nil {
+		t.Fatalf("Failed to roll dice: %v", err)
+	}
+
+	// Verify bet still exists (wasn't resolved)
+	found := false
+	for _, bet := range player.Bets {
+		if bet.Type == "PLACE_6" {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Errorf("PLACE_6 bet should still exist after roll because it was OFF")
+	}
+
+	// Turn the bet back ON
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "TURN ON PLACE_6;")
+	if err != nil {
+		t.Fatalf("Failed to turn ON PLACE_6 bet: %v", err)
+	}
+
+	// Verify bet is now ON
+	if !place6Bet.Working {
+		t.Errorf("Bet should be ON after TURN ON, got Working=%v", place6Bet.Working)
+	}
+	if !place6Bet.PlayerWorking {
+		t.Errorf("Bet should have PlayerWorking=true after TURN ON, got PlayerWorking=%v", place6Bet.PlayerWorking)
+	}
+
+	// Test that bet can be removed when OFF
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "TURN OFF PLACE_6;")
+	if err != nil {
+		t.Fatalf("Failed to turn OFF PLACE_6 bet: %v", err)
+	}
+
+	initialBankrollForRemove := player.Bankroll
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "REMOVE PLACE_6;")
+	if err != nil {
+		t.Fatalf("Failed to remove OFF PLACE_6 bet: %v", err)
+	}
+
+	// Verify bet was removed and money returned
+	found = false
+	for _, bet := range player.Bets {
+		if bet.Type == "PLACE_6" {
+			found = true
+			break
+		}
+	}
+	if found {
+		t.Errorf("PLACE_6 bet should have been removed")
+	}
+
+	expectedBankroll := initialBankrollForRemove + 25.0
+	if player.Bankroll != expectedBankroll {
+		t.Errorf("Expected bankroll $%.2f after removing OFF bet, got $%.2f", expectedBankroll, player.Bankroll)
+	}
+}
+
+func TestTurnOffSevenOutScenario(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	// Establish a point first (like user did)
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "ROLL DICE;")
+	if err != nil {
+		t.Fatalf("Failed to roll dice: %v", err)
+	}
+
+	// Keep rolling until we get a point
+	for table.GetState().String() != "POINT" {
+		_, err = executeCrapsQLForPlayer(t, table, playerID, "ROLL DICE;")
+		if err != nil {
+			t.Fatalf("Failed to roll dice: %v", err)
+		}
+	}
+
+	// Get initial bankroll
+	player, err := table.GetPlayer(playerID)
+	if err != nil {
+		t.Fatalf("Failed to get player: %v", err)
+	}
+	initialBankroll := player.Bankroll
+
+	// Place PLACE_6 bet
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PLACE_6;")
+	if err != nil {
+		t.Fatalf("Failed to place PLACE_6 bet: %v", err)
+	}
+
+	// Verify bankroll decreased
+	if player.Bankroll != initialBankroll-25 {
+		t.Errorf("Expected bankroll $%.2f after placing bet, got $%.2f", initialBankroll-25, player.Bankroll)
+	}
+
+	// Find the bet
+	var place6Bet *crapsgame.Bet
+	for _, bet := range player.Bets {
+		if bet.Type == "PLACE_6" {
+			place6Bet = bet
+			break
+		}
+	}
+	if place6Bet == nil {
+		t.Fatalf("PLACE_6 bet not found")
+	}
+
+	// Turn bet OFF
+	_, err = executeCrapsQLForPlayer(t, table, playerID, "TURN OFF PLACE_6;")
+	if err != nil {
+		t.Fatalf("Failed to turn OFF PLACE_6 bet: %v", err)
+	}
+
+	// Debug: Check bet status after turning OFF
+	t.Logf("After TURN OFF: Working=%v, PlayerWorking=%v", place6Bet.Working, place6Bet.PlayerWorking)
+
+	// Force a seven out using simulateDiceRoll for deterministic testing
+	roll, results := simulateDiceRoll(t, table, 3, 4) // 7
+	sevenOutOccurred := false
+
+	// Check if seven out occurred in the results
+	for _, result := range results {
+		if strings.Contains(result, "Seven out") {
+			sevenOutOccurred = true
+			t.Logf("Seven out occurred: %s", result)
+			break
+		}
+	}
+
+	if !sevenOutOccurred {
+		t.Logf("Seven out not detected in results, but 7 was rolled (total=%d)", roll.Total)
+		// Seven out should occur when we're in POINT state and roll a 7
+		if roll.Total == 7 {
+			sevenOutOccurred = true
+		}
+	}
+
+	// Debug: Check bet status after seven out
+	t.Logf("After seven out: Working=%v, PlayerWorking=%v", place6Bet.Working, place6Bet.PlayerWorking)
+
+	// Check if bet still exists
+	betStillExists := false
+	for _, bet := range player.Bets {
+		if bet.Type == "PLACE_6" {
+			betStillExists = true
+			break
+		}
+	}
+
+	// The OFF bet should still exist (not resolved)
+	if !betStillExists {
+		t.Errorf("PLACE_6 bet should still exist because it was OFF during seven out")
+	}
+
+	// Bankroll should be unchanged (bet wasn't resolved)
+	expectedBankroll := initialBankroll - 25 // Still down the $25 for the unresolved bet
+	if player.Bankroll != expectedBankroll {
+		t.Errorf("Expected bankroll $%.2f (bet unresolved), got $%.2f", expectedBankroll, player.Bankroll)
+	}
+
+	t.Logf("Final bankroll: $%.2f (should still be down $25)", player.Bankroll)
+}
+
+func TestFairSettlement(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON PASS_LINE;"); err != nil {
+		t.Fatalf("Failed to place pass line: %v", err)
+	}
+	simulateDiceRoll(t, table, 3, 3) // point is 6
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON PASS_ODDS; PLACE $12 ON PLACE_8;"); err != nil {
+		t.Fatalf("Failed to place bets: %v", err)
+	}
+
+	expected := map[string]float64{
+		"PASS_LINE": 9.09,  // $20 back 5 times in 11
+		"PASS_ODDS": 10.00, // $22 back 5 times in 11, no house edge
+		"PLACE_8":   11.82, // $26 back 5 times in 11
+	}
+
+	settlement := table.FairSettlement(playerID)
+	player, _ := table.GetPlayer(playerID)
+	if len(settlement) != len(player.Bets) {
+		t.Fatalf("Expected a value for each of %d bets, got %v", len(player.Bets), settlement)
+	}
+	for _, bet := range player.Bets {
+		if settlement[bet.ID] != expected[bet.Type] {
+			t.Errorf("Expected %s to be worth $%.2f, got $%.2f", bet.Type, expected[bet.Type], settlement[bet.ID])
+		}
+	}
+
+	if table.FairSettlement("nobody") != nil {
+		t.Error("Expected no settlement for an unknown player")
+	}
+}
+
+// recordingObserver logs each table event it is told about
+type recordingObserver struct {
+	events []string
+}
+
+func (o *recordingObserver) OnRoll(roll *crapsgame.Roll) {
+	o.events = append(o.events, fmt.Sprintf("roll %d", roll.Total))
+}
+
+func (o *recordingObserver) OnBetResolved(playerID string, bet *crapsgame.Bet, outcome string, payout float64) {
+	o.events = append(o.events, fmt.Sprintf("%s %s %s $%.2f", playerID, bet.Type, outcome, payout))
+}
+
+func (o *recordingObserver) OnStateChange(from, to crapsgame.GameState) {
+	o.events = append(o.events, fmt.Sprintf("%s -> %s", from, to))
+}
+
+func TestTableObserver(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
 
-	// Verify player's bet list is empty after resolution
-	if len(player.Bets) != 0 {
-		t.Errorf("Expected empty bet list after resolution, got %d bets", len(player.Bets))
-	}
+	observer := &recordingObserver{}
+	table.RegisterObserver(observer)
 
-	// Test 5: Validate invalid states are detected
-	// Try to place odds bet in come out state
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_ODDS;")
-	if err == nil {
-		t.Error("Expected error when placing odds bet in come out state, got nil")
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON PASS_LINE;"); err != nil {
+		t.Fatalf("Failed to place pass line: %v", err)
 	}
-
-	// Test 6: Validate shooter assignment
-	if table.Shooter == "" {
-		t.Error("Expected shooter to be assigned")
+	playSequence(t, table, [][2]int{{3, 3}, {2, 2}, {4, 2}, {5, 5}})
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON FIELD;"); err != nil {
+		t.Fatalf("Failed to place field: %v", err)
 	}
+	playSequence(t, table, [][2]int{{3, 4}})
 
-	// Remove all players and verify shooter is cleared
-	for _, player := range players {
-		err := table.RemovePlayer(player)
-		if err != nil {
-			t.Fatalf("Failed to remove player %s: %v", player, err)
-		}
+	// A barred 12 pushes the don't pass
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON DONT_PASS;"); err != nil {
+		t.Fatalf("Failed to place don't pass: %v", err)
 	}
+	playSequence(t, table, [][2]int{{6, 6}})
 
-	if table.Shooter != "" {
-		t.Errorf("Expected empty shooter when no players, got %s", table.Shooter)
+	expected := []string{
+		"roll 6", "COME_OUT -> POINT",
+		"roll 4",
+		"roll 6", "player1 PASS_LINE win $10.00", "POINT -> COME_OUT",
+		"roll 10", "COME_OUT -> POINT",
+		"roll 7", "player1 FIELD lose $0.00", "POINT -> SEVEN_OUT", "SEVEN_OUT -> COME_OUT",
+		"roll 12", "player1 DONT_PASS push $0.00",
+	}
+	if strings.Join(observer.events, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected events:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(observer.events, "\n"))
 	}
 }
 
-// 6.10 Performance and Stress Tests
-func TestLargeGameScenarios(t *testing.T) {
-	// Test with many players
-	table := crapsgame.NewTable(5.0, 1000.0, 3)
+func TestTrajectory(t *testing.T) {
+	play := func() []float64 {
+		table, players := setupTestGame(t)
+		playerID := players[0]
 
-	// Add 10 players
-	players := make([]string, 10)
-	for i := 0; i < 10; i++ {
-		playerID := fmt.Sprintf("player%d", i+1)
-		err := table.AddPlayer(playerID, fmt.Sprintf("Player %d", i+1), 1000.0)
-		if err != nil {
-			t.Fatalf("Failed to add player %s: %v", playerID, err)
+		// Keep a pass line bet up whenever one can go down
+		betFn := func(table *crapsgame.Table) {
+			if table.State == crapsgame.StateComeOut {
+				table.PlaceBet(playerID, "PASS_LINE", 10.0, nil)
+			}
 		}
-		players[i] = playerID
+		return crapsgame.Trajectory(table, playerID, betFn, 50, 42)
 	}
 
-	// Each player places multiple bets
-	interpreter := NewInterpreter(table)
-	for _, playerID := range players {
-		script := fmt.Sprintf(`
-			PLACE $25 ON PASS_LINE;
-			PLACE $10 ON FIELD;
-			PLACE $15 ON ANY_SEVEN;
-		`)
-
-		_, err := interpreter.ExecuteStringForPlayer(script, playerID)
-		if err != nil {
-			t.Fatalf("Failed to place bets for player %s: %v", playerID, err)
-		}
+	first := play()
+	if len(first) != 51 {
+		t.Fatalf("Expected 51 bankrolls for 50 rolls, got %d", len(first))
+	}
+	if first[0] != 1000.0 {
+		t.Errorf("Expected the trajectory to start at $1000.00, got $%.2f", first[0])
 	}
 
-	// Verify all bets were placed
-	for _, playerID := range players {
-		player, err := table.GetPlayer(playerID)
-		if err != nil {
-			t.Fatalf("Failed to get player %s: %v", playerID, err)
-		}
-
-		if len(player.Bets) != 3 {
-			t.Errorf("Expected 3 bets for player %s, got %d", playerID, len(player.Bets))
+	second := play()
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("Expected the same trajectory for the same seed, differs at roll %d: $%.2f vs $%.2f", i, first[i], second[i])
 		}
 	}
 
-	// Test rapid bet placement and resolution (simplified)
-	for i := 0; i < 3; i++ {
-		// Roll dice
-		simulateDiceRoll(t, table, 3, 4) // 7
-
-		// Just verify game state is consistent
-		if table.State != crapsgame.StateComeOut {
-			t.Errorf("Expected come out state after 7, got %v", table.State)
-		}
+	table, _ := setupTestGame(t)
+	if crapsgame.Trajectory(table, "nobody", nil, 10, 42) != nil {
+		t.Error("Expected no trajectory for an unknown player")
 	}
 }
 
-func TestConcurrentOperations(t *testing.T) {
+func TestShowSevenExposure(t *testing.T) {
 	table, players := setupTestGame(t)
+	playerID := players[0]
 
-	// Test basic concurrent-like operations (sequential but simulating concurrent access)
-	interpreter := NewInterpreter(table)
-
-	// Simulate multiple players placing bets "simultaneously"
-	results := make(chan error, len(players))
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON PASS_LINE; PLACE $12 ON PLACE_8;"); err != nil {
+		t.Fatalf("Failed to place bets: %v", err)
+	}
 
-	for _, playerID := range players {
-		go func(pid string) {
-			script := "PLACE $25 ON PASS_LINE;"
-			_, err := interpreter.ExecuteStringForPlayer(script, pid)
-			results <- err
-		}(playerID)
+	// On the come-out the place bet is off and the pass line wins on a 7
+	results, err := executeCrapsQLForPlayer(t, table, playerID, "SHOW SEVEN_EXPOSURE;")
+	if err != nil {
+		t.Fatalf("SHOW SEVEN_EXPOSURE failed: %v", err)
+	}
+	if len(results) != 1 || results[0] != "Player player1 Seven Exposure: nothing loses to a 7" {
+		t.Errorf("Expected nothing at risk on the come-out, got %v", results)
 	}
 
-	// Collect results
-	for i := 0; i < len(players); i++ {
-		err := <-results
-		if err != nil {
-			t.Errorf("Concurrent bet placement failed: %v", err)
-		}
+	simulateDiceRoll(t, table, 3, 3) // point is 6
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $20 ON PASS_ODDS; PLACE $10 ON COME;"); err != nil {
+		t.Fatalf("Failed to place bets: %v", err)
+	}
+	simulateDiceRoll(t, table, 2, 2) // the come bet travels to the 4
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $5 ON ANY_SEVEN;"); err != nil {
+		t.Fatalf("Failed to place any seven: %v", err)
 	}
 
-	// Verify all bets were placed correctly
-	for _, playerID := range players {
-		verifyBetExists(t, table, playerID, "PASS_LINE", 25.0)
+	bets, total := table.SevenExposure(playerID)
+	if len(bets) != 4 || total != 52.0 {
+		t.Errorf("Expected 4 bets with $52.00 at risk, got %d with $%.2f", len(bets), total)
 	}
 
-	// Test concurrent state queries
-	queryResults := make(chan string, len(players))
+	results, err = executeCrapsQLForPlayer(t, table, playerID, "SHOW SEVEN_EXPOSURE;")
+	if err != nil {
+		t.Fatalf("SHOW SEVEN_EXPOSURE failed: %v", err)
+	}
+	expected := "Player player1 Seven Exposure: $52.00\n" +
+		"  PASS_LINE: $10.00\n" +
+		"  PLACE_8: $12.00\n" +
+		"  PASS_ODDS: $20.00\n" +
+		"  COME on 4: $10.00"
+	if len(results) != 1 || results[0] != expected {
+		t.Errorf("Expected:\n%s\ngot %v", expected, results)
+	}
+}
 
-	for _, playerID := range players {
-		go func(pid string) {
-			script := "SHOW BANKROLL;"
-			results, err := interpreter.ExecuteStringForPlayer(script, pid)
-			if err != nil {
-				queryResults <- fmt.Sprintf("ERROR: %v", err)
-			} else {
-				queryResults <- results[0]
-			}
-		}(playerID)
+func TestCallOddsOnComeOut(t *testing.T) {
+	testCases := []struct {
+		name     string
+		call     bool
+		bankroll float64
+	}{
+		// The odds are off, so the come-out 7 only takes the come bet
+		{"table default", false, 990.0},
+		// Called on, the odds lose with the come bet
+		{"called on", true, 970.0},
 	}
 
-	// Collect query results
-	for i := 0; i < len(players); i++ {
-		result := <-queryResults
-		if strings.Contains(result, "ERROR") {
-			t.Errorf("Concurrent query failed: %s", result)
+	for _, tc := range testCases {
+		table, players := setupTestGame(t)
+		playerID := players[0]
+		if err := table.SetCallOddsOnComeOut(playerID, tc.call); err != nil {
+			t.Fatalf("%s: failed to set the override: %v", tc.name, err)
+		}
+
+		simulateDiceRoll(t, table, 3, 3) // point is 6
+		if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON COME;"); err != nil {
+			t.Fatalf("%s: failed to place come bet: %v", tc.name, err)
 		}
+		simulateDiceRoll(t, table, 4, 5) // come point 9
+		if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $20 ON COME_ODDS;"); err != nil {
+			t.Fatalf("%s: failed to place come odds: %v", tc.name, err)
+		}
+		simulateDiceRoll(t, table, 2, 4) // point made, back to the come-out
+		simulateDiceRoll(t, table, 3, 4)
+		verifyBetNotExists(t, table, playerID, "COME")
+		verifyBetNotExists(t, table, playerID, "COME_ODDS")
+		verifyPlayerBankroll(t, table, playerID, tc.bankroll)
+	}
+
+	// A table can leave odds working on the come-out for everyone
+	table, players := setupTestGame(t)
+	if err := table.SetWorkingDefault(crapsgame.OddsBets, true); err != nil {
+		t.Fatalf("Failed to turn odds on for the come-out: %v", err)
+	}
+	simulateDiceRoll(t, table, 3, 3)
+	executeCrapsQLForPlayer(t, table, players[0], "PLACE $10 ON COME;")
+	simulateDiceRoll(t, table, 4, 5)
+	executeCrapsQLForPlayer(t, table, players[0], "PLACE $20 ON COME_ODDS;")
+	simulateDiceRoll(t, table, 2, 4)
+	simulateDiceRoll(t, table, 3, 4)
+	verifyPlayerBankroll(t, table, players[0], 970.0)
+
+	if err := table.SetCallOddsOnComeOut("nobody", true); err == nil {
+		t.Error("Expected error for an unknown player, got nil")
+	}
+	if err := table.SetWorkingDefault("NOT_A_CATEGORY", false); err == nil {
+		t.Error("Expected error for an unknown bet category, got nil")
 	}
 }
 
-func TestMemoryAndResourceUsage(t *testing.T) {
-	// Test memory usage with large number of operations
-	table := crapsgame.NewTable(5.0, 1000.0, 3)
+func TestStatementsForNamedPlayer(t *testing.T) {
+	table, players := setupTestGame(t)
+	interpreter := NewInterpreter(table)
 
-	// Add player
-	err := table.AddPlayer("player1", "Test Player", 10000.0)
+	results, err := interpreter.ExecuteString(`PLACE $25 ON PASS_LINE FOR player2;
+		PLACE $10 ON FIELD WORKING FOR player3;
+		SET BANKROLL $500 FOR player1;
+		SHOW BANKROLL FOR player2;`)
 	if err != nil {
-		t.Fatalf("Failed to add player: %v", err)
+		t.Fatalf("Failed to execute statements for named players: %v", err)
 	}
 
-	interpreter := NewInterpreter(table)
+	verifyBetNotExists(t, table, players[0], "PASS_LINE")
+	verifyBetExists(t, table, players[1], "PASS_LINE", 25.0)
+	verifyBetExists(t, table, players[2], "FIELD", 10.0)
+	verifyPlayerBankroll(t, table, players[0], 500.0)
+	verifyPlayerBankroll(t, table, players[1], 975.0)
+	verifyPlayerBankroll(t, table, players[2], 990.0)
+	if last := results[len(results)-1]; !strings.Contains(last, "player2") || !strings.Contains(last, "975.00") {
+		t.Errorf("Expected player2's bankroll, got %q", last)
+	}
 
-	// Perform a few operations (simplified)
-	for i := 0; i < 5; i++ {
-		script := "PLACE $10 ON FIELD;"
+	// FOR overrides the player a script runs for
+	if _, err := interpreter.ExecuteStringForPlayer("PLACE $10 ON DONT_PASS FOR player3;", players[0]); err != nil {
+		t.Fatalf("Failed to place a bet for player3: %v", err)
+	}
+	verifyBetNotExists(t, table, players[0], "DONT_PASS")
+	verifyBetExists(t, table, players[2], "DONT_PASS", 10.0)
 
-		_, err := interpreter.ExecuteStringForPlayer(script, "player1")
-		if err != nil {
-			t.Logf("Operation %d failed: %v", i, err)
-			break
-		}
+	if _, err := interpreter.ExecuteString("PLACE $10 ON FIELD FOR nobody;"); err == nil || !strings.Contains(err.Error(), "player nobody not found") {
+		t.Errorf("Expected an error for an unknown player, got %v", err)
+	}
+	if _, err := interpreter.ExecuteString("SHOW BANKROLL FOR;"); err == nil {
+		t.Error("Expected a parse error for FOR without a player, got nil")
+	}
+}
 
-		// Simulate a simple roll to clear the field bet
-		simulateDiceRoll(t, table, 3, 4) // 7 - field loses
+func TestComeOddsLimitedByComeBet(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
+
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PASS_LINE;"); err != nil {
+		t.Fatalf("Failed to place pass line: %v", err)
 	}
+	simulateDiceRoll(t, table, 3, 3) // point is 6
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON COME;"); err != nil {
+		t.Fatalf("Failed to place come bet: %v", err)
+	}
+	simulateDiceRoll(t, table, 2, 3) // come point 5
 
-	// Verify system is still functional
-	player, err := table.GetPlayer("player1")
-	if err != nil {
-		t.Fatalf("Failed to get player after stress test: %v", err)
+	// 3x the $10 come bet, not the $25 pass line
+	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $40 ON COME_ODDS;")
+	if err == nil || !strings.Contains(err.Error(), "$30.00 allowed behind $10.00 COME") {
+		t.Errorf("Expected $40 come odds to exceed 3x the come bet, got %v", err)
+	}
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $30 ON COME_ODDS;"); err != nil {
+		t.Fatalf("Expected $30 come odds to be allowed: %v", err)
 	}
+	verifyBetExists(t, table, playerID, "COME_ODDS", 30.0)
 
-	if player.Bankroll <= 0 {
-		t.Logf("Player bankroll depleted after stress test: $%.2f", player.Bankroll)
+	// The pass line still takes its own 3x
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $75 ON PASS_ODDS;"); err != nil {
+		t.Errorf("Expected $75 pass odds behind the $25 pass line: %v", err)
 	}
 }
 
-func TestBetTypeCoverage(t *testing.T) {
+func TestBreakEvenWinRate(t *testing.T) {
 	table, players := setupTestGame(t)
 	playerID := players[0]
 
-	// Test all major bet types
-	betTypes := []string{
-		"PASS_LINE", "DONT_PASS", "FIELD", "ANY_SEVEN", "ANY_CRAPS",
-		"PLACE_4", "PLACE_5", "PLACE_6", "PLACE_8", "PLACE_9", "PLACE_10",
-		"HARD_4", "HARD_6", "HARD_8", "HARD_10",
-		"BUY_4", "BUY_10", "LAY_4", "LAY_10",
+	results, err := executeCrapsQLForPlayer(t, table, playerID, "SHOW BREAKEVEN;")
+	if err != nil {
+		t.Fatalf("SHOW BREAKEVEN failed: %v", err)
+	}
+	if len(results) != 1 || results[0] != "Player player1 Break-even: no bets with a fixed payout" {
+		t.Errorf("Expected no break-even without bets, got %v", results)
 	}
 
-	interpreter := NewInterpreter(table)
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON PASS_LINE;"); err != nil {
+		t.Fatalf("Failed to place pass line: %v", err)
+	}
+	simulateDiceRoll(t, table, 3, 3) // point is 6
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON PASS_ODDS; PLACE $12 ON PLACE_6;"); err != nil {
+		t.Fatalf("Failed to place bets: %v", err)
+	}
 
-	for _, betType := range betTypes {
-		script := fmt.Sprintf("PLACE $10 ON %s;", betType)
-		_, err := interpreter.ExecuteStringForPlayer(script, playerID)
-		if err != nil {
-			t.Logf("Bet type %s failed: %v", betType, err)
-		} else {
-			t.Logf("Bet type %s succeeded", betType)
-		}
+	// $32 staked pays $10 + $12 + $14 = $36, so 32 of 68 decisions must win
+	if rate := table.BreakEvenWinRate(playerID); fmt.Sprintf("%.6f", rate) != fmt.Sprintf("%.6f", 32.0/68.0) {
+		t.Errorf("Expected break-even rate %.6f, got %.6f", 32.0/68.0, rate)
 	}
 
-	// Verify some bets were placed successfully
-	player, err := table.GetPlayer(playerID)
+	results, err = executeCrapsQLForPlayer(t, table, playerID, "SHOW BREAKEVEN;")
 	if err != nil {
-		t.Fatalf("Failed to get player: %v", err)
+		t.Fatalf("SHOW BREAKEVEN failed: %v", err)
 	}
-
-	if len(player.Bets) == 0 {
-		t.Error("No bets were placed successfully")
-	} else {
-		t.Logf("Successfully placed %d bets", len(player.Bets))
+	if len(results) != 1 || results[0] != "Player player1 Break-even: win 47.06% of decisions" {
+		t.Errorf("Expected a 47.06%% break-even, got %v", results)
 	}
 }
 
-// ============================================================================
-// 7. Helper Functions for Integration Tests
-// ============================================================================
+func TestHopCombinations(t *testing.T) {
+	testCases := []struct {
+		bet     string
+		betType string
+		dice    [2]int
+		payout  float64
+	}{
+		{"HOP(2,2)", "HOP_HARD_4", [2]int{2, 2}, 150.0}, // 30:1
+		{"HOP(4, 2)", "HOP_2_4", [2]int{2, 4}, 75.0},    // 15:1, either order
+		{"HOP(5,5)", "HOP_HARD_10", [2]int{5, 5}, 150.0},
+		{"HOP(6,6)", "BOXCARS", [2]int{6, 6}, 150.0},
+	}
 
-// setupTestGame creates a standard test game setup
-func setupTestGame(t *testing.T) (*crapsgame.Table, []string) {
-	table := crapsgame.NewTable(5.0, 1000.0, 3)
+	for _, tc := range testCases {
+		table, players := setupTestGame(t)
+		playerID := players[0]
 
-	// Add test players
-	players := []string{"player1", "player2", "player3"}
-	for i, playerID := range players {
-		err := table.AddPlayer(playerID, fmt.Sprintf("Player %d", i+1), 1000.0)
-		if err != nil {
-			t.Fatalf("Failed to add player %s: %v", playerID, err)
+		if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $5 ON "+tc.bet+";"); err != nil {
+			t.Fatalf("%s: failed to place hop: %v", tc.bet, err)
 		}
+		verifyBetExists(t, table, playerID, tc.betType, 5.0)
+
+		simulateDiceRoll(t, table, tc.dice[0], tc.dice[1])
+		verifyPlayerBankroll(t, table, playerID, 1000.0+tc.payout)
 	}
 
-	return table, players
+	// A hard hop only wins on its pair
+	table, players := setupTestGame(t)
+	if _, err := executeCrapsQLForPlayer(t, table, players[0], "PLACE $5 ON HOP(2,2);"); err != nil {
+		t.Fatalf("Failed to place hard hop: %v", err)
+	}
+	simulateDiceRoll(t, table, 1, 3)
+	verifyBetNotExists(t, table, players[0], "HOP_HARD_4")
+	verifyPlayerBankroll(t, table, players[0], 995.0)
+
+	for _, input := range []string{"PLACE $5 ON HOP(7,1);", "PLACE $5 ON HOP(0,3);", "PLACE $5 ON HOP(2);"} {
+		parser := NewParser(NewLexer(input))
+		parser.ParseProgram()
+		if len(parser.Errors()) == 0 {
+			t.Errorf("Expected %q to be rejected", input)
+		}
+	}
 }
 
-// executeCrapsQL executes a CrapsQL statement and returns results
-func executeCrapsQL(t *testing.T, table *crapsgame.Table, statement string) ([]string, error) {
-	interpreter := NewInterpreter(table)
-	return interpreter.ExecuteString(statement)
-}
+func TestComeOutResolutionOrder(t *testing.T) {
+	table, players := setupTestGame(t)
 
-// executeCrapsQLForPlayer executes a CrapsQL statement for a specific player
-func executeCrapsQLForPlayer(t *testing.T, table *crapsgame.Table, playerID string, statement string) ([]string, error) {
-	interpreter := NewInterpreter(table)
-	return interpreter.ExecuteStringForPlayer(statement, playerID)
-}
+	for _, playerID := range []string{players[1], players[0]} {
+		if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON FIELD; PLACE $10 ON PASS_LINE; PLACE $12 ON PLACE_6;"); err != nil {
+			t.Fatalf("Failed to place bets for %s: %v", playerID, err)
+		}
+	}
 
-// verifyGameState verifies the game is in expected state
-func verifyGameState(t *testing.T, table *crapsgame.Table, expectedState crapsgame.GameState, expectedPoint crapsgame.Point) {
-	if table.State != expectedState {
-		t.Errorf("Expected game state %v, got %v", expectedState, table.State)
+	// Decided against the come-out: the field pays 2:1 on the 2, the pass
+	// line loses and the place bet is off
+	results := playSequence(t, table, [][2]int{{1, 1}})[0]
+
+	var decided []string
+	for _, result := range results {
+		decided = append(decided, result.PlayerID+" "+result.BetType+" "+result.Outcome)
+	}
+	// Seat order, not the order the players bet in
+	expected := []string{
+		"player1 FIELD win", "player1 PASS_LINE lose",
+		"player2 FIELD win", "player2 PASS_LINE lose",
+	}
+	if strings.Join(decided, ", ") != strings.Join(expected, ", ") {
+		t.Errorf("Expected decisions in seat order %v, got %v", expected, decided)
 	}
 
-	if table.Point != expectedPoint {
-		t.Errorf("Expected point %v, got %v", expectedPoint, table.Point)
+	for _, playerID := range players[:2] {
+		verifyPlayerBankroll(t, table, playerID, 1000.0-10.0-10.0-12.0+10.0+20.0)
+		verifyBetExists(t, table, playerID, "PLACE_6", 12.0)
+		verifyBetNotExists(t, table, playerID, "PASS_LINE")
 	}
+	verifyGameState(t, table, crapsgame.StateComeOut, crapsgame.PointOff)
 }
 
-// verifyPlayerBankroll verifies player bankroll is expected amount
-func verifyPlayerBankroll(t *testing.T, table *crapsgame.Table, playerID string, expectedBankroll float64) {
-	player, err := table.GetPlayer(playerID)
-	if err != nil {
-		t.Fatalf("Failed to get player %s: %v", playerID, err)
-	}
+func TestPreviewResolution(t *testing.T) {
+	for _, dice := range [][2]int{{4, 4}, {3, 5}, {1, 6}, {3, 3}, {2, 2}} {
+		table, players := setupTestGame(t)
+		playerID := players[0]
 
-	if player.Bankroll != expectedBankroll {
-		t.Errorf("Expected player %s bankroll to be $%.2f, got $%.2f", playerID, expectedBankroll, player.Bankroll)
+		if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON PASS_LINE;"); err != nil {
+			t.Fatalf("Failed to place pass line: %v", err)
+		}
+		simulateDiceRoll(t, table, 2, 4) // point 6
+		if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON FIELD; PLACE $12 ON PLACE_8; PLACE $5 ON HARD_8; PLACE $10 ON COME;"); err != nil {
+			t.Fatalf("Failed to place bets: %v", err)
+		}
+
+		outcomes := table.PreviewResolution(dice[0], dice[1])
+		if len(outcomes) != 5 {
+			t.Fatalf("%v: expected an outcome for each of 5 working bets, got %d", dice, len(outcomes))
+		}
+		// Previewing changes nothing
+		verifyPlayerBankroll(t, table, playerID, 1000.0-10.0-10.0-12.0-5.0-10.0)
+		verifyGameState(t, table, crapsgame.StatePoint, crapsgame.Point6)
+
+		results := make(map[string]crapsgame.BetResult)
+		for _, result := range playSequence(t, table, [][2]int{dice})[0] {
+			results[result.BetID] = result
+		}
+		player, _ := table.GetPlayer(playerID)
+		for _, outcome := range outcomes {
+			result, decided := results[outcome.BetID]
+			if decided != (outcome.Win || outcome.Remove) {
+				t.Errorf("%v: %s previewed win=%v remove=%v, roll decided=%v", dice, outcome.BetType, outcome.Win, outcome.Remove, decided)
+				continue
+			}
+			if outcome.Win != (result.Outcome == crapsgame.OutcomeWin) || outcome.Payout != result.Payout {
+				t.Errorf("%v: %s previewed win=%v paying %.2f, roll gave %q paying %.2f", dice, outcome.BetType, outcome.Win, outcome.Payout, result.Outcome, result.Payout)
+			}
+
+			stillUp := false
+			for _, bet := range player.Bets {
+				stillUp = stillUp || bet.ID == outcome.BetID
+			}
+			if stillUp == outcome.Remove {
+				t.Errorf("%v: %s previewed remove=%v, but still up=%v after the roll", dice, outcome.BetType, outcome.Remove, stillUp)
+			}
+		}
 	}
 }
 
-// verifyBetExists verifies a specific bet exists for a player
-func verifyBetExists(t *testing.T, table *crapsgame.Table, playerID string, betType string, amount float64) {
-	player, err := table.GetPlayer(playerID)
-	if err != nil {
-		t.Fatalf("Failed to get player %s: %v", playerID, err)
-	}
+func TestShowMinBankroll(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
 
-	found := false
-	for _, bet := range player.Bets {
-		if bet.Type == betType && bet.Amount == amount {
-			found = true
-			break
+	testCases := []struct {
+		betType  string
+		expected string
+	}{
+		{"PLACE_6", "$6.00"},   // 7:6 pays whole dollars on multiples of $6
+		{"PASS_LINE", "$5.00"}, // the table minimum
+		{"PLACE_5", "$5.00"},   // 7:5
+		{"LAY_4", "$6.00"},     // 1:2, so multiples of $2
+	}
+	for _, tc := range testCases {
+		results, err := executeCrapsQLForPlayer(t, table, playerID, "SHOW MIN BANKROLL "+tc.betType+";")
+		if err != nil {
+			t.Fatalf("SHOW MIN BANKROLL %s failed: %v", tc.betType, err)
+		}
+		if result := strings.Join(results, "\n"); !strings.Contains(result, tc.expected) {
+			t.Errorf("Expected %s to need %s, got %q", tc.betType, tc.expected, result)
 		}
 	}
 
-	if !found {
-		t.Errorf("Expected bet %s with amount $%.2f for player %s, not found", betType, amount, playerID)
+	// A player's own minimum applies when it is higher
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "SET MIN_BET $10;"); err != nil {
+		t.Fatalf("Failed to set player minimum: %v", err)
 	}
-}
-
-// verifyBetNotExists verifies a specific bet does NOT exist for a player
-func verifyBetNotExists(t *testing.T, table *crapsgame.Table, playerID string, betType string) {
-	player, err := table.GetPlayer(playerID)
+	minimum, err := table.MinBankroll(playerID, "PLACE_6")
 	if err != nil {
-		t.Fatalf("Failed to get player %s: %v", playerID, err)
+		t.Fatalf("MinBankroll failed: %v", err)
 	}
-
-	for _, bet := range player.Bets {
-		if bet.Type == betType {
-			t.Errorf("Expected bet %s to NOT exist for player %s, but found bet with amount $%.2f", betType, playerID, bet.Amount)
-			return
-		}
+	if minimum != 12.0 {
+		t.Errorf("Expected $12.00 for PLACE_6 with a $10 player minimum, got $%.2f", minimum)
 	}
-}
 
-// simulateDiceRoll simulates a dice roll with specific outcome
-func simulateDiceRoll(t *testing.T, table *crapsgame.Table, dice1, dice2 int) (*crapsgame.Roll, []string) {
-	// Create a roll with the specified values
-	roll := &crapsgame.Roll{
-		Die1:   dice1,
-		Die2:   dice2,
-		Total:  dice1 + dice2,
-		IsHard: dice1 == dice2,
-		Time:   time.Now(),
+	if _, err := table.MinBankroll(playerID, "NOT_A_BET"); err == nil {
+		t.Error("Expected an error for an unknown bet type")
 	}
+}
 
-	// Set the roll as current
-	table.CurrentRoll = roll
-
-	// Resolve all bets FIRST (before game state changes)
-	results := table.ResolveAllBets(roll)
+func TestBigSixAndEight(t *testing.T) {
+	for _, betType := range []string{"BIG_6", "BIG_8"} {
+		table, players := setupTestGame(t)
+		playerID := players[0]
+		number := 6
+		if betType == "BIG_8" {
+			number = 8
+		}
 
-	// THEN update game state
-	table.UpdateGameState(roll)
+		// Big bets are off on the come-out by default, so play on a point
+		simulateDiceRoll(t, table, 2, 2)
+		if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON "+betType+";"); err != nil {
+			t.Fatalf("Failed to place %s: %v", betType, err)
+		}
+		verifyBetExists(t, table, playerID, betType, 10.0)
 
-	return roll, results
-}
+		// Pays even money and stays up
+		simulateDiceRoll(t, table, number/2, number-number/2)
+		verifyPlayerBankroll(t, table, playerID, 1000.0)
+		verifyBetExists(t, table, playerID, betType, 10.0)
 
-// getPlayerBetCount returns the number of bets a player has
-func getPlayerBetCount(t *testing.T, table *crapsgame.Table, playerID string) int {
-	player, err := table.GetPlayer(playerID)
-	if err != nil {
-		t.Fatalf("Failed to get player %s: %v", playerID, err)
+		simulateDiceRoll(t, table, 3, 4)
+		verifyBetNotExists(t, table, playerID, betType)
+		verifyPlayerBankroll(t, table, playerID, 1000.0)
 	}
-	return len(player.Bets)
 }
 
-func TestTurnOffPreservation(t *testing.T) {
+func TestPushToRail(t *testing.T) {
 	table, players := setupTestGame(t)
 	playerID := players[0]
+	table.PushToRail = true
 
-	// First, place a bet
-	_, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PLACE_6;")
-	if err != nil {
-		t.Fatalf("Failed to place PLACE_6 bet: %v", err)
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON DONT_PASS;"); err != nil {
+		t.Fatalf("Failed to place don't pass: %v", err)
 	}
 
-	// Verify bet exists and is working by default
-	player, err := table.GetPlayer(playerID)
-	if err != nil {
-		t.Fatalf("Failed to get player: %v", err)
+	// Bar 12: the don't pass pushes and its $10 goes to the rail
+	results := playSequence(t, table, [][2]int{{6, 6}})[0]
+	if len(results) != 1 || results[0].Outcome != crapsgame.OutcomePush || results[0].Payout != 0 {
+		t.Fatalf("Expected the don't pass to push, got %+v", results)
 	}
+	verifyBetNotExists(t, table, playerID, "DONT_PASS")
+	verifyPlayerBankroll(t, table, playerID, 990.0)
 
-	var place6Bet *crapsgame.Bet
-	for _, bet := range player.Bets {
-		if bet.Type == "PLACE_6" {
-			place6Bet = bet
-			break
-		}
+	player, _ := table.GetPlayer(playerID)
+	if player.Rail != 10.0 {
+		t.Errorf("Expected $10.00 on the rail, got $%.2f", player.Rail)
 	}
-
-	if place6Bet == nil {
-		t.Fatalf("PLACE_6 bet not found")
+	transactions := table.GetTransactions(playerID)
+	if last := transactions[len(transactions)-1]; last.Type != crapsgame.TransactionRail || last.Amount != 10.0 {
+		t.Errorf("Expected a $10.00 rail transaction, got %+v", last)
 	}
 
-	// Verify initial state
-	if !place6Bet.Working {
-		t.Errorf("Bet should be working initially, got Working=%v", place6Bet.Working)
+	// Wins still go to the bankroll
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $10 ON DONT_PASS;"); err != nil {
+		t.Fatalf("Failed to place don't pass: %v", err)
 	}
-	if !place6Bet.PlayerWorking {
-		t.Errorf("Bet should have PlayerWorking=true initially, got PlayerWorking=%v", place6Bet.PlayerWorking)
-	}
-
-	// Turn the bet OFF
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "TURN OFF PLACE_6;")
-	if err != nil {
-		t.Fatalf("Failed to turn OFF PLACE_6 bet: %v", err)
+	simulateDiceRoll(t, table, 1, 2)
+	verifyPlayerBankroll(t, table, playerID, 1000.0)
+	if player.Rail != 10.0 {
+		t.Errorf("Expected the rail to stay at $10.00, got $%.2f", player.Rail)
 	}
 
-	// Verify bet is now OFF
-	if place6Bet.Working {
-		t.Errorf("Bet should be OFF after TURN OFF, got Working=%v", place6Bet.Working)
-	}
-	if place6Bet.PlayerWorking {
-		t.Errorf("Bet should have PlayerWorking=false after TURN OFF, got PlayerWorking=%v", place6Bet.PlayerWorking)
+	// The push is neither a win nor a loss
+	if got := table.OutcomeHistogram(playerID)["DONT_PASS"]; got != (crapsgame.OutcomeCount{Wins: 1, Pushes: 1}) {
+		t.Errorf("Expected one don't pass win and one push, got %+v", got)
 	}
+}
 
-	// Simulate system working status update (this used to be the bug)
-	table.UpdateBetWorkingStatus()
+func TestPlaceBetBatch(t *testing.T) {
+	table, players := setupTestGame(t)
+	playerID := players[0]
 
-	// Verify bet is STILL OFF after system update
-	if place6Bet.Working {
-		t.Errorf("Bet should remain OFF after UpdateBetWorkingStatus, got Working=%v", place6Bet.Working)
-	}
-	if place6Bet.PlayerWorking {
-		t.Errorf("Bet should still have PlayerWorking=false after UpdateBetWorkingStatus, got PlayerWorking=%v", place6Bet.PlayerWorking)
+	// A dealer's spread across the inside numbers
+	inside := []crapsgame.BetRequest{
+		{BetType: "PLACE_5", Amount: 10, Numbers: []int{5}},
+		{BetType: "PLACE_6", Amount: 12, Numbers: []int{6}},
+		{BetType: "PLACE_8", Amount: 12, Numbers: []int{8}},
+		{BetType: "PLACE_9", Amount: 10, Numbers: []int{9}},
 	}
-
-	// Roll dice to see that OFF bet is not resolved
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "ROLL DICE;")
+	bets, err := table.PlaceBetBatch(playerID, inside)
 	if err != nil {
-		t.Fatalf("Failed to roll dice: %v", err)
-	}
-
-	// Verify bet still exists (wasn't resolved)
-	found := false
-	for _, bet := range player.Bets {
-		if bet.Type == "PLACE_6" {
-			found = true
-			break
-		}
+		t.Fatalf("Failed to place the batch: %v", err)
 	}
-	if !found {
-		t.Errorf("PLACE_6 bet should still exist after roll because it was OFF")
+	if len(bets) != len(inside) {
+		t.Fatalf("Expected %d bets, got %d", len(inside), len(bets))
 	}
-
-	// Turn the bet back ON
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "TURN ON PLACE_6;")
-	if err != nil {
-		t.Fatalf("Failed to turn ON PLACE_6 bet: %v", err)
+	for _, request := range inside {
+		verifyBetExists(t, table, playerID, request.BetType, request.Amount)
 	}
+	verifyPlayerBankroll(t, table, playerID, 1000.0-44.0)
 
-	// Verify bet is now ON
-	if !place6Bet.Working {
-		t.Errorf("Bet should be ON after TURN ON, got Working=%v", place6Bet.Working)
+	// One bad bet and nothing in the batch is placed
+	player, _ := table.GetPlayer(playerID)
+	transactions := len(table.GetTransactions(playerID))
+	betsPlaced := player.Stats.BetsPlaced
+	_, err = table.PlaceBetBatch(playerID, []crapsgame.BetRequest{
+		{BetType: "PASS_LINE", Amount: 10},
+		{BetType: "FIELD", Amount: 25},
+		{BetType: "HARD_6", Amount: 2000, Numbers: []int{6}}, // over the table maximum
+	})
+	if err == nil {
+		t.Fatal("Expected the batch to be rejected")
 	}
-	if !place6Bet.PlayerWorking {
-		t.Errorf("Bet should have PlayerWorking=true after TURN ON, got PlayerWorking=%v", place6Bet.PlayerWorking)
+	verifyPlayerBankroll(t, table, playerID, 1000.0-44.0)
+	verifyBetNotExists(t, table, playerID, "PASS_LINE")
+	verifyBetNotExists(t, table, playerID, "FIELD")
+	if len(player.Bets) != len(inside) {
+		t.Errorf("Expected only the %d inside bets after the rollback, got %d", len(inside), len(player.Bets))
 	}
-
-	// Test that bet can be removed when OFF
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "TURN OFF PLACE_6;")
-	if err != nil {
-		t.Fatalf("Failed to turn OFF PLACE_6 bet: %v", err)
+	if got := len(table.GetTransactions(playerID)); got != transactions {
+		t.Errorf("Expected the ledger rolled back to %d entries, got %d", transactions, got)
 	}
-
-	initialBankrollForRemove := player.Bankroll
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "REMOVE PLACE_6;")
-	if err != nil {
-		t.Fatalf("Failed to remove OFF PLACE_6 bet: %v", err)
+	if player.Stats.BetsPlaced != betsPlaced {
+		t.Errorf("Expected %d bets placed in the stats, got %d", betsPlaced, player.Stats.BetsPlaced)
 	}
+}
 
-	// Verify bet was removed and money returned
-	found = false
-	for _, bet := range player.Bets {
-		if bet.Type == "PLACE_6" {
-			found = true
-			break
-		}
-	}
-	if found {
-		t.Errorf("PLACE_6 bet should have been removed")
+func TestPlaceNumbersParsesEachNumber(t *testing.T) {
+	parser := NewParser(NewLexer("PLACE $10 ON PLACE_NUMBERS(4, 5, 10);"))
+	program := parser.ParseProgram()
+	if errors := parser.Errors(); len(errors) > 0 {
+		t.Fatalf("Failed to parse PLACE_NUMBERS: %v", errors)
 	}
 
-	expectedBankroll := initialBankrollForRemove + 25.0
-	if player.Bankroll != expectedBankroll {
-		t.Errorf("Expected bankroll $%.2f after removing OFF bet, got $%.2f", expectedBankroll, player.Bankroll)
+	stmt := program.Statements[0].(*BetStatement)
+	if numbers := extractNumbersForBetType(stmt.BetType); fmt.Sprint(numbers) != "[4 5 10]" {
+		t.Errorf("Expected numbers [4 5 10], got %v", numbers)
 	}
 }
 
-func TestTurnOffSevenOutScenario(t *testing.T) {
+func TestCompRating(t *testing.T) {
 	table, players := setupTestGame(t)
 	playerID := players[0]
 
-	// Establish a point first (like user did)
-	_, err := executeCrapsQLForPlayer(t, table, playerID, "ROLL DICE;")
-	if err != nil {
-		t.Fatalf("Failed to roll dice: %v", err)
+	if _, err := executeCrapsQLForPlayer(t, table, playerID, "PLACE $20 ON PASS_LINE;"); err != nil {
+		t.Fatalf("Failed to place pass line: %v", err)
 	}
 
-	// Keep rolling until we get a point
-	for table.GetState().String() != "POINT" {
-		_, err = executeCrapsQLForPlayer(t, table, playerID, "ROLL DICE;")
-		if err != nil {
-			t.Fatalf("Failed to roll dice: %v", err)
-		}
+	// Point 6, then 49 rolls of 4 that leave the pass line up
+	simulateDiceRoll(t, table, 3, 3)
+	for n := 0; n < 49; n++ {
+		simulateDiceRoll(t, table, 2, 2)
 	}
 
-	// Get initial bankroll
-	player, err := table.GetPlayer(playerID)
-	if err != nil {
-		t.Fatalf("Failed to get player: %v", err)
+	// $20 average bet x 1.41% edge x 50 rolls
+	if rating := table.CompRating(playerID, 0); fmt.Sprintf("%.2f", rating) != "14.10" {
+		t.Errorf("Expected a $14.10 comp rating, got $%.4f", rating)
+	}
+	// Half an hour at the table's 100 rolls an hour, rated at 60: 30 rolls
+	if rating := table.CompRating(playerID, 60); fmt.Sprintf("%.2f", rating) != "8.46" {
+		t.Errorf("Expected a $8.46 comp rating at 60 rolls an hour, got $%.4f", rating)
+	}
+	if hours := table.HoursPlayed(playerID, 60); fmt.Sprintf("%.2f", hours) != "0.83" {
+		t.Errorf("Expected 50 rolls to be 0.83 hours at 60 an hour, got %.4f", hours)
 	}
-	initialBankroll := player.Bankroll
 
-	// Place PLACE_6 bet
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "PLACE $25 ON PLACE_6;")
+	// The table's pace is the default, 100 rolls an hour
+	results, err := executeCrapsQLForPlayer(t, table, playerID, "SHOW COMP;")
 	if err != nil {
-		t.Fatalf("Failed to place PLACE_6 bet: %v", err)
+		t.Fatalf("SHOW COMP failed: %v", err)
 	}
-
-	// Verify bankroll decreased
-	if player.Bankroll != initialBankroll-25 {
-		t.Errorf("Expected bankroll $%.2f after placing bet, got $%.2f", initialBankroll-25, player.Bankroll)
+	if output := strings.Join(results, "\n"); !strings.Contains(output, "Comp Rating: $14.10") || !strings.Contains(output, "0.50 hours") {
+		t.Errorf("Expected the comp rating in %q", output)
 	}
 
-	// Find the bet
-	var place6Bet *crapsgame.Bet
-	for _, bet := range player.Bets {
-		if bet.Type == "PLACE_6" {
-			place6Bet = bet
-			break
-		}
-	}
-	if place6Bet == nil {
-		t.Fatalf("PLACE_6 bet not found")
+	if rating := table.CompRating("nobody", 60); rating != 0 {
+		t.Errorf("Expected 0 for an unknown player, got %.2f", rating)
 	}
+}
 
-	// Turn bet OFF
-	_, err = executeCrapsQLForPlayer(t, table, playerID, "TURN OFF PLACE_6;")
-	if err != nil {
-		t.Fatalf("Failed to turn OFF PLACE_6 bet: %v", err)
+func TestComeBetsOnComeOut(t *testing.T) {
+	// By default come bets wait for a point
+	table, players := setupTestGame(t)
+	if _, err := executeCrapsQLForPlayer(t, table, players[0], "PLACE $10 ON COME;"); err == nil {
+		t.Fatal("Expected a come bet on the come-out to be rejected")
 	}
 
-	// Debug: Check bet status after turning OFF
-	t.Logf("After TURN OFF: Working=%v, PlayerWorking=%v", place6Bet.Working, place6Bet.PlayerWorking)
+	table, players = setupTestGame(t)
+	table.ComeBetsOnComeOut = true
+	if _, err := executeCrapsQLForPlayer(t, table, players[0], "PLACE $10 ON COME;"); err != nil {
+		t.Fatalf("Failed to place come bet on the come-out: %v", err)
+	}
+	if _, err := executeCrapsQLForPlayer(t, table, players[1], "PLACE $10 ON DONT_COME;"); err != nil {
+		t.Fatalf("Failed to place don't come on the come-out: %v", err)
+	}
 
-	// Force a seven out using simulateDiceRoll for deterministic testing
-	roll, results := simulateDiceRoll(t, table, 3, 4) // 7
-	sevenOutOccurred := false
+	// The come-out natural is the come bets' first roll
+	simulateDiceRoll(t, table, 5, 2)
+	verifyBetNotExists(t, table, players[0], "COME")
+	verifyPlayerBankroll(t, table, players[0], 1010.0)
+	verifyBetNotExists(t, table, players[1], "DONT_COME")
+	verifyPlayerBankroll(t, table, players[1], 990.0)
+	verifyGameState(t, table, crapsgame.StateComeOut, crapsgame.PointOff)
 
-	// Check if seven out occurred in the results
-	for _, result := range results {
-		if strings.Contains(result, "Seven out") {
-			sevenOutOccurred = true
-			t.Logf("Seven out occurred: %s", result)
-			break
-		}
+	// A point number sends the come bet to the same number as the table point
+	executeCrapsQLForPlayer(t, table, players[0], "PLACE $10 ON COME;")
+	simulateDiceRoll(t, table, 2, 4)
+	verifyGameState(t, table, crapsgame.StatePoint, crapsgame.Point6)
+	player, _ := table.GetPlayer(players[0])
+	if len(player.Bets) != 1 || len(player.Bets[0].Numbers) != 1 || player.Bets[0].Numbers[0] != 6 {
+		t.Fatalf("Expected the come bet to travel to 6, got %+v", player.Bets)
 	}
+	simulateDiceRoll(t, table, 3, 3)
+	verifyBetNotExists(t, table, players[0], "COME")
+	verifyPlayerBankroll(t, table, players[0], 1020.0)
+}
 
-	if !sevenOutOccurred {
-		t.Logf("Seven out not detected in results, but 7 was rolled (total=%d)", roll.Total)
-		// Seven out should occur when we're in POINT state and roll a 7
-		if roll.Total == 7 {
-			sevenOutOccurred = true
+func TestHouseBankroll(t *testing.T) {
+	table, players := setupTestGame(t)
+	table.BuyCommissionMode = crapsgame.CommissionOnPlacement
+
+	place := func(playerID, statement string) {
+		t.Helper()
+		if _, err := executeCrapsQLForPlayer(t, table, playerID, statement); err != nil {
+			t.Fatalf("%s: %v", statement, err)
 		}
 	}
 
-	// Debug: Check bet status after seven out
-	t.Logf("After seven out: Working=%v, PlayerWorking=%v", place6Bet.Working, place6Bet.PlayerWorking)
+	place(players[0], "PLACE $10 ON PASS_LINE; PLACE $10 ON FIELD;")
+	place(players[1], "PLACE $10 ON DONT_PASS; PLACE $5 ON ANY_SEVEN;")
+	playSequence(t, table, [][2]int{{6, 6}}) // Field 3:1, don't pass pushes, pass line and any seven lose
 
-	// Check if bet still exists
-	betStillExists := false
-	for _, bet := range player.Bets {
-		if bet.Type == "PLACE_6" {
-			betStillExists = true
-			break
+	place(players[0], "PLACE $10 ON PASS_LINE;")
+	place(players[1], "PLACE $10 ON DONT_PASS;")
+	place(players[2], "PLACE $20 ON BUY_4;") // $1 commission up front
+	playSequence(t, table, [][2]int{{3, 3}}) // Point 6
+
+	place(players[0], "PLACE $20 ON PASS_ODDS; PLACE $12 ON PLACE_8; PLACE $5 ON HARD_8;")
+	place(players[2], "PLACE $10 ON COME;")
+	playSequence(t, table, [][2]int{{4, 4}, {2, 2}, {1, 5}, {3, 4}}) // Hard 8, a 4, the point, then a come-out 7
+
+	net := 0.0
+	for _, playerID := range players {
+		player, _ := table.GetPlayer(playerID)
+		net += player.Bankroll - 1000.0
+		for _, bet := range player.Bets {
+			net += bet.Amount // still the player's money
 		}
 	}
-
-	// The OFF bet should still exist (not resolved)
-	if !betStillExists {
-		t.Errorf("PLACE_6 bet should still exist because it was OFF during seven out")
+	if net == 0 {
+		t.Fatal("Expected the session to move money")
+	}
+	if fmt.Sprintf("%.2f", table.HouseBankroll) != fmt.Sprintf("%.2f", -net) {
+		t.Errorf("Expected the house bankroll to be $%.2f, the mirror of the players' net, got $%.2f", -net, table.HouseBankroll)
 	}
 
-	// Bankroll should be unchanged (bet wasn't resolved)
-	expectedBankroll := initialBankroll - 25 // Still down the $25 for the unresolved bet
-	if player.Bankroll != expectedBankroll {
-		t.Errorf("Expected bankroll $%.2f (bet unresolved), got $%.2f", expectedBankroll, player.Bankroll)
+	results, err := executeCrapsQLForPlayer(t, table, players[0], "SHOW HOUSE;")
+	if err != nil {
+		t.Fatalf("SHOW HOUSE failed: %v", err)
+	}
+	expected := fmt.Sprintf("House Bankroll: $%.2f", table.HouseBankroll)
+	if table.HouseBankroll < 0 {
+		expected = fmt.Sprintf("House Bankroll: -$%.2f", -table.HouseBankroll)
+	}
+	if output := strings.Join(results, "\n"); !strings.Contains(output, expected) {
+		t.Errorf("Expected %q in %q", expected, output)
 	}
 
-	t.Logf("Final bankroll: $%.2f (should still be down $25)", player.Bankroll)
+	// A push leaves the house alone
+	house := table.HouseBankroll
+	place(players[1], "PLACE $10 ON DONT_PASS;")
+	playSequence(t, table, [][2]int{{6, 6}})
+	verifyBetNotExists(t, table, players[1], "DONT_PASS")
+	if table.HouseBankroll != house {
+		t.Errorf("Expected a push to leave the house at $%.2f, got $%.2f", house, table.HouseBankroll)
+	}
 }
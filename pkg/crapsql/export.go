@@ -0,0 +1,138 @@
+package crapsql
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sessionReport is the JSON shape produced by ExportSession
+type sessionReport struct {
+	ExportedAt   time.Time           `json:"exported_at"`
+	Transactions []transactionRecord `json:"transactions"`
+	Players      []playerSummary     `json:"players"`
+}
+
+type transactionRecord struct {
+	Time     time.Time `json:"time"`
+	Player   string    `json:"player"`
+	BetID    string    `json:"bet_id"`
+	BetType  string    `json:"bet_type"`
+	Type     string    `json:"type"`
+	Amount   float64   `json:"amount"`
+	Bankroll float64   `json:"bankroll"`
+}
+
+type playerSummary struct {
+	ID           string  `json:"id"`
+	Name         string  `json:"name"`
+	Bankroll     float64 `json:"bankroll"`
+	BetsPlaced   int     `json:"bets_placed"`
+	TotalWagered float64 `json:"total_wagered"`
+	TotalWon     float64 `json:"total_won"`
+	TotalLost    float64 `json:"total_lost"`
+	Commission   float64 `json:"commission_paid"`
+	Net          float64 `json:"net"`
+}
+
+// ExportSession produces a report of the session's transactions, final
+// bankrolls and per-player stats. Supported formats are "csv" and "json".
+func (i *Interpreter) ExportSession(format string) ([]byte, error) {
+	report := i.buildSessionReport()
+
+	switch strings.ToLower(format) {
+	case "json":
+		return json.MarshalIndent(report, "", "  ")
+	case "csv":
+		return writeSessionCSV(report)
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+func (i *Interpreter) buildSessionReport() sessionReport {
+	report := sessionReport{
+		ExportedAt:   time.Now(),
+		Transactions: []transactionRecord{},
+		Players:      []playerSummary{},
+	}
+
+	for _, tx := range i.table.GetAllTransactions() {
+		report.Transactions = append(report.Transactions, transactionRecord{
+			Time:     tx.Time,
+			Player:   tx.PlayerID,
+			BetID:    tx.BetID,
+			BetType:  tx.BetType,
+			Type:     string(tx.Type),
+			Amount:   tx.Amount,
+			Bankroll: tx.Bankroll,
+		})
+	}
+
+	// Players come sorted by ID so reports are stable between runs
+	for _, player := range i.table.PlayerSnapshots() {
+		report.Players = append(report.Players, playerSummary{
+			ID:           player.ID,
+			Name:         player.Name,
+			Bankroll:     player.Bankroll,
+			BetsPlaced:   player.Stats.BetsPlaced,
+			TotalWagered: player.Stats.TotalWagered,
+			TotalWon:     player.Stats.TotalWon,
+			TotalLost:    player.Stats.TotalLost,
+			Commission:   player.Stats.CommissionPaid,
+			Net:          player.Stats.Net(),
+		})
+	}
+
+	return report
+}
+
+// writeSessionCSV writes the transactions section followed by a blank line
+// and the per-player summary section, each with its own header row
+func writeSessionCSV(report sessionReport) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	records := [][]string{{"time", "player", "bet_id", "bet_type", "type", "amount", "bankroll"}}
+	for _, tx := range report.Transactions {
+		records = append(records, []string{
+			tx.Time.Format(time.RFC3339Nano),
+			tx.Player,
+			tx.BetID,
+			tx.BetType,
+			tx.Type,
+			formatMoney(tx.Amount),
+			formatMoney(tx.Bankroll),
+		})
+	}
+
+	records = append(records, []string{})
+	records = append(records, []string{"player", "name", "bankroll", "bets_placed", "total_wagered", "total_won", "total_lost", "commission_paid", "net"})
+	for _, p := range report.Players {
+		records = append(records, []string{
+			p.ID,
+			p.Name,
+			formatMoney(p.Bankroll),
+			strconv.Itoa(p.BetsPlaced),
+			formatMoney(p.TotalWagered),
+			formatMoney(p.TotalWon),
+			formatMoney(p.TotalLost),
+			formatMoney(p.Commission),
+			formatMoney(p.Net),
+		})
+	}
+
+	if err := w.WriteAll(records); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %v", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func formatMoney(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
@@ -1,7 +1,10 @@
 package crapsql
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"strings"
 	"time"
 
@@ -27,12 +30,27 @@ func AddPlayer(table *Table, id, name string, bankroll float64) error {
 type Interpreter struct {
 	table   *crapsgame.Table
 	results []string
+
+	// standingBets holds each player's ALWAYS bets, re-placed after every roll
+	standingBets map[string][]*BetStatement
+
+	// variables holds the amounts set with LET
+	variables map[string]float64
+
+	// lastRejection is the most recent bet the table refused, for SHOW WHY
+	lastRejection *crapsgame.BetRejection
+
+	// strategies holds each player's strategies started with RUN STRATEGY
+	strategies map[string][]*runningStrategy
 }
 
 // NewInterpreter creates a new interpreter
 func NewInterpreter(table *crapsgame.Table) *Interpreter {
 	return &Interpreter{
-		table: table,
+		table:        table,
+		standingBets: make(map[string][]*BetStatement),
+		variables:    make(map[string]float64),
+		strategies:   make(map[string][]*runningStrategy),
 	}
 }
 
@@ -96,12 +114,34 @@ func (i *Interpreter) ExecuteForPlayer(program *Program, playerID string) ([]str
 	return results, nil
 }
 
+// forPlayer returns the player a statement's FOR clause names, or "" if it
+// has none
+func forPlayer(stmt Statement) string {
+	switch s := stmt.(type) {
+	case *BetStatement:
+		return s.Player
+	case *QueryStatement:
+		return s.Player
+	case *ManagementStatement:
+		return s.Player
+	}
+	return ""
+}
+
 func (i *Interpreter) executeStatement(stmt Statement) (string, error) {
+	if target := forPlayer(stmt); target != "" {
+		return i.executeStatementForPlayer(stmt, target)
+	}
+
 	switch s := stmt.(type) {
 	case *BetStatement:
 		return i.executeBetStatement(s)
 	case *ConditionalStatement:
 		return i.executeConditionalStatement(s)
+	case *WhileStatement:
+		return i.executeWhileStatement(s)
+	case *RepeatStatement:
+		return i.executeRepeatStatement(s)
 	case *QueryStatement:
 		return i.executeQueryStatement(s)
 	case *ManagementStatement:
@@ -114,17 +154,37 @@ func (i *Interpreter) executeStatement(stmt Statement) (string, error) {
 		return i.executeTurnStatement(s)
 	case *RollStatement:
 		return i.executeRollStatement(s)
+	case *AlwaysStatement:
+		return i.executeAlwaysStatement(s)
+	case *CancelStatement:
+		return i.executeCancelStatement(s)
+	case *LetStatement:
+		return i.executeLetStatement(s)
+	case *RunStrategyStatement:
+		return i.executeRunStrategyStatement(s)
 	default:
 		return "", fmt.Errorf("unknown statement type: %T", stmt)
 	}
 }
 
 func (i *Interpreter) executeStatementForPlayer(stmt Statement, playerID string) (string, error) {
+	// FOR <player> runs the statement for the player named instead
+	if target := forPlayer(stmt); target != "" {
+		if _, err := i.table.GetPlayer(target); err != nil {
+			return "", err
+		}
+		playerID = target
+	}
+
 	switch s := stmt.(type) {
 	case *BetStatement:
 		return i.executeBetStatementForPlayer(s, playerID)
 	case *ConditionalStatement:
 		return i.executeConditionalStatementForPlayer(s, playerID)
+	case *WhileStatement:
+		return i.executeWhileStatementForPlayer(s, playerID)
+	case *RepeatStatement:
+		return i.executeRepeatStatementForPlayer(s, playerID)
 	case *QueryStatement:
 		return i.executeQueryStatementForPlayer(s, playerID)
 	case *ManagementStatement:
@@ -137,6 +197,14 @@ func (i *Interpreter) executeStatementForPlayer(stmt Statement, playerID string)
 		return i.executeTurnStatementForPlayer(s, playerID)
 	case *RollStatement:
 		return i.executeRollStatementForPlayer(s, playerID)
+	case *AlwaysStatement:
+		return i.executeAlwaysStatementForPlayer(s, playerID)
+	case *CancelStatement:
+		return i.executeCancelStatementForPlayer(s, playerID)
+	case *LetStatement:
+		return i.executeLetStatementForPlayer(s, playerID)
+	case *RunStrategyStatement:
+		return i.executeRunStrategyStatementForPlayer(s, playerID)
 	default:
 		return "", fmt.Errorf("unknown statement type: %T", stmt)
 	}
@@ -159,14 +227,228 @@ func (i *Interpreter) executeBetStatement(stmt *BetStatement) (string, error) {
 func (i *Interpreter) executeBetStatementForPlayer(stmt *BetStatement, playerID string) (string, error) {
 	betType := i.betTypeToString(stmt.BetType.Type)
 	numbers := extractNumbersForBetType(stmt.BetType)
+	if stmt.BetType.Type == BetHop {
+		hop, err := hopBetType(numbers)
+		if err != nil {
+			return "", err
+		}
+		betType, numbers = hop, nil
+	}
 
-	// Place the bet using the game engine
-	placedBet, err := i.table.PlaceBet(playerID, betType, stmt.Amount.Value, numbers)
+	// FULL/DOUBLE ODDS are checked up front so the line bet isn't placed alone
+	oddsMultiple := i.oddsMultipleFromModifiers(stmt.Modifiers)
+	oddsType := lineOddsBetTypes[betType]
+	if oddsMultiple > 0 {
+		if oddsType == "" {
+			return "", fmt.Errorf("FULL and DOUBLE ODDS only apply to PASS_LINE or DONT_PASS, not %s", betType)
+		}
+		if !i.table.IsPointEstablished() {
+			return "", fmt.Errorf("odds can only be taken once a point is established")
+		}
+	}
+	parlay := hasModifier(stmt.Modifiers, ModParlay)
+	if parlay {
+		if def, exists := crapsgame.GetBetDefinition(betType); !exists || !def.OneRoll {
+			return "", fmt.Errorf("PARLAY only applies to one-roll bets, not %s", betType)
+		}
+	}
+	keep := hasModifier(stmt.Modifiers, ModKeep)
+	if keep {
+		if def, exists := crapsgame.GetBetDefinition(betType); !exists || !def.OneRoll {
+			return "", fmt.Errorf("KEEP only applies to one-roll bets, not %s", betType)
+		}
+	}
+
+	amount, err := i.extractAmountFromExpression(stmt.Amount, playerID)
 	if err != nil {
+		return "", err
+	}
+	if amount <= 0 {
+		return "", fmt.Errorf("bet amount must be positive, got $%.2f", amount)
+	}
+
+	// Place the bet using the game engine; odds go up behind this bet or
+	// neither is placed
+	var placedBet, oddsBet *crapsgame.Bet
+	if oddsMultiple > 0 {
+		placedBet, oddsBet, err = i.table.PlaceBetWithOdds(playerID, betType, amount, amount*float64(oddsMultiple))
+	} else {
+		placedBet, err = i.table.PlaceBet(playerID, betType, amount, numbers)
+	}
+	if err != nil {
+		var rejection *crapsgame.BetRejection
+		if errors.As(err, &rejection) {
+			i.lastRejection = rejection
+		}
 		return "", fmt.Errorf("failed to place bet: %v", err)
 	}
 
-	return fmt.Sprintf("✅ Placed $%.2f on %s", placedBet.Amount, betType), nil
+	result := fmt.Sprintf("✅ Placed $%.2f on %s", placedBet.Amount, betType)
+	if placedBet.Advisory != "" {
+		result += "\n⚠️ " + placedBet.Advisory
+	}
+	switch {
+	case hasModifier(stmt.Modifiers, ModWorking):
+		if err := i.table.TurnBetByID(playerID, placedBet.ID, true); err != nil {
+			return result, fmt.Errorf("failed to call bet working: %v", err)
+		}
+		result += fmt.Sprintf("\n🔛 %s is working", betType)
+	case hasModifier(stmt.Modifiers, ModOff):
+		if err := i.table.TurnBetByID(playerID, placedBet.ID, false); err != nil {
+			return result, fmt.Errorf("failed to turn bet off: %v", err)
+		}
+		result += fmt.Sprintf("\n⏸️ %s is off", betType)
+	case !placedBet.Working:
+		// Off on the come-out by table rules; it comes on with the point
+		result += fmt.Sprintf("\n⏸️ %s placed but OFF until point established", betType)
+	}
+	if parlay {
+		if err := i.table.ParlayBetByID(playerID, placedBet.ID, true); err != nil {
+			return result, fmt.Errorf("failed to parlay bet: %v", err)
+		}
+		result += fmt.Sprintf("\n🔁 Parlaying %s until it loses", betType)
+	}
+	if keep {
+		if err := i.table.KeepBetOnLossByID(playerID, placedBet.ID, true); err != nil {
+			return result, fmt.Errorf("failed to keep bet up: %v", err)
+		}
+		result += fmt.Sprintf("\n🔁 Keeping %s up after each loss", betType)
+	}
+	if oddsBet == nil {
+		return result, nil
+	}
+	return result + fmt.Sprintf("\n✅ Placed $%.2f on %s", oddsBet.Amount, oddsType), nil
+}
+
+// lineOddsBetTypes maps line bets to the odds bet taken behind them
+var lineOddsBetTypes = map[string]string{
+	"PASS_LINE": "PASS_ODDS",
+	"DONT_PASS": "DONT_PASS_ODDS",
+}
+
+// linePointBetTypes are decided by the table point rather than a number of
+// their own
+var linePointBetTypes = map[string]bool{
+	"PASS_LINE":      true,
+	"DONT_PASS":      true,
+	"PASS_ODDS":      true,
+	"DONT_PASS_ODDS": true,
+}
+
+// hasModifier reports whether a modifier of the given type is present
+func hasModifier(modifiers []*ModifierExpression, modType ModifierType) bool {
+	for _, mod := range modifiers {
+		if mod.Type == modType {
+			return true
+		}
+	}
+	return false
+}
+
+// oddsMultipleFromModifiers returns the odds multiple requested by FULL ODDS
+// (the table maximum) or DOUBLE ODDS (2x), or 0 when neither is present
+func (i *Interpreter) oddsMultipleFromModifiers(modifiers []*ModifierExpression) int {
+	for _, mod := range modifiers {
+		switch mod.Type {
+		case ModFullOdds:
+			return i.table.MaxOdds
+		case ModDoubleOdds:
+			return 2
+		}
+	}
+	return 0
+}
+
+// maxWhileIterations stops a WHILE loop whose condition never becomes false,
+// and caps the count of a REPEAT loop
+const maxWhileIterations = 10000
+
+func (i *Interpreter) executeWhileStatement(stmt *WhileStatement) (string, error) {
+	var playerID string
+	for id := range i.table.Players {
+		playerID = id
+		break
+	}
+
+	if playerID == "" {
+		return "", fmt.Errorf("no players at table - add a player first")
+	}
+
+	return i.executeWhileStatementForPlayer(stmt, playerID)
+}
+
+func (i *Interpreter) executeWhileStatementForPlayer(stmt *WhileStatement, playerID string) (string, error) {
+	var results []string
+
+	for iterations := 0; ; iterations++ {
+		// Re-evaluate the condition against the current game state each pass
+		condition, err := i.evaluateConditionForPlayer(stmt.Condition, playerID)
+		if err != nil {
+			return "", fmt.Errorf("condition evaluation failed: %v", err)
+		}
+		if !condition {
+			break
+		}
+		if iterations >= maxWhileIterations {
+			return "", fmt.Errorf("WHILE loop exceeded %d iterations", maxWhileIterations)
+		}
+
+		for _, bodyStmt := range stmt.Body.Statements {
+			result, err := i.executeStatementForPlayer(bodyStmt, playerID)
+			if err != nil {
+				return "", err
+			}
+			if result != "" {
+				results = append(results, result)
+			}
+		}
+	}
+
+	return strings.Join(results, "\n"), nil
+}
+
+func (i *Interpreter) executeRepeatStatement(stmt *RepeatStatement) (string, error) {
+	var playerID string
+	for id := range i.table.Players {
+		playerID = id
+		break
+	}
+
+	if playerID == "" {
+		return "", fmt.Errorf("no players at table - add a player first")
+	}
+
+	return i.executeRepeatStatementForPlayer(stmt, playerID)
+}
+
+// executeRepeatStatementForPlayer runs the body a fixed number of times,
+// collecting every result. The count is evaluated once, before the first pass.
+func (i *Interpreter) executeRepeatStatementForPlayer(stmt *RepeatStatement, playerID string) (string, error) {
+	count, err := i.evaluateExpressionForPlayer(stmt.Count, playerID)
+	if err != nil {
+		return "", fmt.Errorf("REPEAT count evaluation failed: %v", err)
+	}
+	if count < 1 || count != math.Trunc(count) {
+		return "", fmt.Errorf("REPEAT count must be a positive whole number, got %v", count)
+	}
+	if count > maxWhileIterations {
+		return "", fmt.Errorf("REPEAT count %v exceeds the %d iteration limit", count, maxWhileIterations)
+	}
+
+	var results []string
+	for iteration := 0; iteration < int(count); iteration++ {
+		for _, bodyStmt := range stmt.Body.Statements {
+			result, err := i.executeStatementForPlayer(bodyStmt, playerID)
+			if err != nil {
+				return "", err
+			}
+			if result != "" {
+				results = append(results, result)
+			}
+		}
+	}
+
+	return strings.Join(results, "\n"), nil
 }
 
 func (i *Interpreter) executeConditionalStatement(stmt *ConditionalStatement) (string, error) {
@@ -236,12 +518,58 @@ func (i *Interpreter) executeQueryStatementForPlayer(stmt *QueryStatement, playe
 	switch stmt.Type {
 	case QueryPoint:
 		return i.executeShowPoint(), nil
-	case QueryBets:
-		return i.executeShowBets(), nil
+	case QueryBetTypes:
+		return i.executeShowBetTypes(), nil
 	case QueryBankroll:
 		return i.executeShowBankroll(playerID), nil
 	case QueryTableMinimums:
 		return i.executeShowTableMinimums(), nil
+	case QueryMyBets:
+		return i.executeShowMyBets(playerID, stmt.Filter), nil
+	case QueryWays:
+		return i.executeShowWays(stmt.Value)
+	case QueryVig:
+		return i.executeShowVig(stmt.BetType)
+	case QueryImplied:
+		return i.executeShowImplied(stmt.BetType)
+	case QueryVariance:
+		return i.executeShowVariance(stmt.BetType)
+	case QueryBehavior:
+		return i.executeShowBehavior(stmt.BetType)
+	case QueryAvgBet:
+		return i.executeShowAvgBet(playerID), nil
+	case QueryCost:
+		return i.executeShowCost(playerID), nil
+	case QueryComp:
+		return i.executeShowComp(playerID), nil
+	case QueryHouse:
+		return i.executeShowHouse(), nil
+	case QueryStreak:
+		return i.executeShowStreak(playerID), nil
+	case QueryIfRoll:
+		return i.executeShowIfRoll(playerID, stmt.Dice)
+	case QueryStats:
+		return i.executeShowStats(playerID), nil
+	case QuerySevenExposure:
+		return i.executeShowSevenExposure(playerID), nil
+	case QueryBreakEven:
+		return i.executeShowBreakEven(playerID), nil
+	case QueryMinBankroll:
+		return i.executeShowMinBankroll(playerID, stmt.BetType)
+	case QueryOutcomeHistogram:
+		return i.executeShowOutcomeHistogram(playerID), nil
+	case QueryShooter:
+		return i.executeShowShooter(), nil
+	case QueryRemainingAction:
+		return i.executeShowRemainingAction(playerID), nil
+	case QueryHistory:
+		return i.executeShowHistory(), nil
+	case QueryTableTotal:
+		return i.executeShowTableTotal(), nil
+	case QueryOdds:
+		return i.executeShowOdds()
+	case QueryWhy:
+		return i.executeShowWhy(), nil
 	default:
 		return "", fmt.Errorf("unknown query type: %v", stmt.Type)
 	}
@@ -262,14 +590,18 @@ func (i *Interpreter) executeManagementStatement(stmt *ManagementStatement) (str
 }
 
 func (i *Interpreter) executeManagementStatementForPlayer(stmt *ManagementStatement, playerID string) (string, error) {
-	amount, err := i.extractAmountFromExpression(stmt.Value)
+	if stmt.Type == ManageDiceSet {
+		return i.executeSetDice(stmt.Value)
+	}
+
+	amount, err := i.extractAmountFromExpression(stmt.Value, playerID)
 	if err != nil {
 		return "", fmt.Errorf("invalid amount: %v", err)
 	}
 
 	switch stmt.Type {
 	case ManageBankroll:
-		return i.executeSetBankroll(playerID, amount)
+		return i.executeSetBankroll(playerID, amount, stmt.ResetStats)
 	case ManageMaxBet:
 		return i.executeSetMaxBet(playerID, amount)
 	case ManageMinBet:
@@ -283,62 +615,80 @@ func (i *Interpreter) executeManagementStatementForPlayer(stmt *ManagementStatem
 	}
 }
 
-func (i *Interpreter) executeSetBankroll(playerID string, amount float64) (string, error) {
-	player, err := i.table.GetPlayer(playerID)
-	if err != nil {
-		return "", fmt.Errorf("player %s not found", playerID)
+// executeSetDice records the shooter's dice set. It is purely cosmetic and
+// has no effect on the roll.
+func (i *Interpreter) executeSetDice(value Expression) (string, error) {
+	label, ok := value.(*StringExpression)
+	if !ok {
+		return "", fmt.Errorf("SET DICE expects a quoted label, got %T", value)
+	}
+	if err := i.table.SetDiceSetting(label.Value); err != nil {
+		return "", err
 	}
+	return fmt.Sprintf("✅ Shooter %s sets the dice: %s", i.table.GetShooter(), label.Value), nil
+}
 
-	player.Bankroll = amount
-	return fmt.Sprintf("✅ Set bankroll to $%.2f", amount), nil
+func (i *Interpreter) executeSetBankroll(playerID string, amount float64, resetStats bool) (string, error) {
+	// A new bankroll starts a new session for the win goal and loss limit
+	if err := i.table.SetPlayerBankroll(playerID, amount, resetStats); err != nil {
+		return "", err
+	}
+	bankroll := crapsgame.ToMoney(amount).Dollars()
+	if resetStats {
+		return fmt.Sprintf("✅ Set bankroll to $%.2f and reset session stats", bankroll), nil
+	}
+	return fmt.Sprintf("✅ Set bankroll to $%.2f", bankroll), nil
 }
 
 func (i *Interpreter) executeSetMaxBet(playerID string, amount float64) (string, error) {
-	player, err := i.table.GetPlayer(playerID)
-	if err != nil {
-		return "", fmt.Errorf("player %s not found", playerID)
+	if err := i.table.SetPlayerMaxBet(playerID, amount); err != nil {
+		return "", err
 	}
-
-	player.MaxBet = amount
 	return fmt.Sprintf("✅ Set max bet to $%.2f", amount), nil
 }
 
 func (i *Interpreter) executeSetMinBet(playerID string, amount float64) (string, error) {
-	player, err := i.table.GetPlayer(playerID)
-	if err != nil {
-		return "", fmt.Errorf("player %s not found", playerID)
+	if err := i.table.SetPlayerMinBet(playerID, amount); err != nil {
+		return "", err
 	}
-
-	player.MinBet = amount
 	return fmt.Sprintf("✅ Set min bet to $%.2f", amount), nil
 }
 
 func (i *Interpreter) executeSetWinGoal(playerID string, amount float64) (string, error) {
-	player, err := i.table.GetPlayer(playerID)
-	if err != nil {
-		return "", fmt.Errorf("player %s not found", playerID)
+	if err := i.table.SetPlayerWinGoal(playerID, amount); err != nil {
+		return "", err
 	}
-
-	player.WinGoal = amount
 	return fmt.Sprintf("✅ Set win goal to $%.2f", amount), nil
 }
 
 func (i *Interpreter) executeSetLossLimit(playerID string, amount float64) (string, error) {
-	player, err := i.table.GetPlayer(playerID)
-	if err != nil {
-		return "", fmt.Errorf("player %s not found", playerID)
+	if err := i.table.SetPlayerLossLimit(playerID, amount); err != nil {
+		return "", err
 	}
-
-	player.LossLimit = amount
 	return fmt.Sprintf("✅ Set loss limit to $%.2f", amount), nil
 }
 
-func (i *Interpreter) extractAmountFromExpression(expr Expression) (float64, error) {
+// extractAmountFromExpression returns the dollar amount an expression stands
+// for, looking up variables and evaluating arithmetic for the player
+func (i *Interpreter) extractAmountFromExpression(expr Expression, playerID string) (float64, error) {
 	switch e := expr.(type) {
 	case *NumberExpression:
 		return e.Value, nil
 	case *AmountExpression:
-		return e.Value, nil
+		switch {
+		case e.Expr != nil:
+			return i.extractAmountFromExpression(e.Expr, playerID)
+		case e.Name != "":
+			return i.variableValue(e.Name, e.Token)
+		default:
+			return e.Value, nil
+		}
+	case *IdentifierExpression, *InfixExpression:
+		value, err := i.evaluateExpressionForPlayer(e, playerID)
+		if err != nil {
+			return 0, err
+		}
+		return crapsgame.ToMoney(value).Dollars(), nil
 	default:
 		return 0, fmt.Errorf("unsupported expression type for amount: %T", expr)
 	}
@@ -359,38 +709,40 @@ func (i *Interpreter) executeRemoveStatement(stmt *RemoveStatement) (string, err
 }
 
 func (i *Interpreter) executeRemoveStatementForPlayer(stmt *RemoveStatement, playerID string) (string, error) {
-	// Handle REMOVE ALL case
-	if stmt.BetType == nil {
-		// Remove all bets for the player
-		player, err := i.table.GetPlayer(playerID)
+	// Handle REMOVE BET <id> case
+	if stmt.BetID != "" {
+		if err := i.table.RemoveBetByID(playerID, stmt.BetID); err != nil {
+			return "", fmt.Errorf("failed to remove bet: %v", err)
+		}
+		return fmt.Sprintf("✅ Removed bet %s", stmt.BetID), nil
+	}
+
+	// Handle TAKE DOWN ALL case
+	if stmt.TakeDown {
+		removedCount, returned, err := i.table.TakeDownAll(playerID)
 		if err != nil {
-			return "", fmt.Errorf("player %s not found", playerID)
+			return "", fmt.Errorf("failed to take down bets: %v", err)
 		}
 
-		removedCount := 0
-		totalReturned := 0.0
+		if removedCount == 0 {
+			return "ℹ️ No bets that can come down", nil
+		}
 
-		// Create a copy of the bets slice to avoid modification during iteration
-		bets := make([]*Bet, len(player.Bets))
-		copy(bets, player.Bets)
+		return fmt.Sprintf("✅ Took down %d bets, returned %s to bankroll", removedCount, crapsgame.ToMoney(returned)), nil
+	}
 
-		for _, bet := range bets {
-			if bet.Working {
-				// Return bet amount to player's bankroll
-				player.Bankroll += bet.Amount
-				totalReturned += bet.Amount
-				removedCount++
-			}
+	// Handle REMOVE ALL case
+	if stmt.BetType == nil {
+		removedCount, returned, err := i.table.RemoveAllBets(playerID)
+		if err != nil {
+			return "", fmt.Errorf("failed to remove bets: %v", err)
 		}
 
-		// Clear all bets
-		player.Bets = []*Bet{}
-
 		if removedCount == 0 {
 			return "ℹ️ No active bets to remove", nil
 		}
 
-		return fmt.Sprintf("✅ Removed %d bets, returned $%.2f to bankroll", removedCount, totalReturned), nil
+		return fmt.Sprintf("✅ Removed %d bets, returned %s to bankroll", removedCount, crapsgame.ToMoney(returned)), nil
 	}
 
 	// Handle REMOVE <bet_type> case
@@ -422,13 +774,31 @@ func (i *Interpreter) executePressStatement(stmt *PressStatement) (string, error
 func (i *Interpreter) executePressStatementForPlayer(stmt *PressStatement, playerID string) (string, error) {
 	betType := i.betTypeToString(stmt.BetType.Type)
 
-	// Press the bet using the game engine
-	err := i.table.PressBet(playerID, betType, stmt.Amount.Value)
+	if stmt.Mode == PressFull {
+		if err := i.table.PressBetFull(playerID, betType); err != nil {
+			return "", fmt.Errorf("failed to press bet: %v", err)
+		}
+		return fmt.Sprintf("✅ Full pressed %s bet", betType), nil
+	}
+
+	amount, err := i.extractAmountFromExpression(stmt.Amount, playerID)
 	if err != nil {
+		return "", err
+	}
+
+	if stmt.Mode == PressTo {
+		if err := i.table.PressBetTo(playerID, betType, amount); err != nil {
+			return "", fmt.Errorf("failed to press bet: %v", err)
+		}
+		return fmt.Sprintf("✅ Pressed %s bet to $%.2f", betType, amount), nil
+	}
+
+	// Press the bet using the game engine
+	if err := i.table.PressBet(playerID, betType, amount); err != nil {
 		return "", fmt.Errorf("failed to press bet: %v", err)
 	}
 
-	return fmt.Sprintf("✅ Pressed %s bet by $%.2f", betType, stmt.Amount.Value), nil
+	return fmt.Sprintf("✅ Pressed %s bet by $%.2f", betType, amount), nil
 }
 
 func (i *Interpreter) executeTurnStatement(stmt *TurnStatement) (string, error) {
@@ -514,14 +884,33 @@ func (i *Interpreter) evaluateExpressionForPlayer(expr Expression, playerID stri
 		return i.evaluateIdentifierExpressionForPlayer(e, playerID)
 	case *NumberExpression:
 		return e.Value, nil
+	case *AmountExpression:
+		return e.Value, nil
+	case *InfixExpression:
+		return i.evaluateArithmeticForPlayer(e, playerID)
 	default:
 		return 0, fmt.Errorf("unsupported expression type: %T", expr)
 	}
 }
 
 func (i *Interpreter) executeRollStatement(stmt *RollStatement) (string, error) {
+	if stmt.UntilResolved {
+		var playerID string
+		for id := range i.table.Players {
+			playerID = id
+			break
+		}
+
+		if playerID == "" {
+			return "", fmt.Errorf("no players at table - add a player first")
+		}
+
+		return i.executeRollUntilResolved(playerID)
+	}
+
 	// Use the new clean game flow
 	roll, results := i.table.ExecuteGameTurn()
+	results = append(results, i.afterRoll()...)
 
 	// Format the output
 	var output strings.Builder
@@ -536,9 +925,14 @@ func (i *Interpreter) executeRollStatement(stmt *RollStatement) (string, error)
 }
 
 func (i *Interpreter) executeRollStatementForPlayer(stmt *RollStatement, playerID string) (string, error) {
+	if stmt.UntilResolved {
+		return i.executeRollUntilResolved(playerID)
+	}
+
 	// For player-specific rolls, we still roll for the whole table
 	// but we can filter results for the specific player
 	roll, allResults := i.table.RollDiceAndResolve()
+	allResults = append(allResults, i.afterRoll()...)
 
 	// Filter results for this player
 	var playerResults []string
@@ -561,6 +955,203 @@ func (i *Interpreter) executeRollStatementForPlayer(stmt *RollStatement, playerI
 	return output.String(), nil
 }
 
+// maxRollsUntilResolved caps ROLL UNTIL RESOLVED so bets that are off or
+// can never be decided don't loop forever
+const maxRollsUntilResolved = 1000
+
+// executeRollUntilResolved rolls until one of the player's bets wins or loses,
+// detected through new WIN/LOSS entries in the table ledger
+func (i *Interpreter) executeRollUntilResolved(playerID string) (string, error) {
+	player, err := i.table.PlayerSnapshot(playerID)
+	if err != nil {
+		return "", err
+	}
+	if len(player.Bets) == 0 {
+		return "", fmt.Errorf("no bets to resolve for player %s", playerID)
+	}
+
+	var output strings.Builder
+	for rolls := 1; rolls <= maxRollsUntilResolved; rolls++ {
+		ledgerStart := len(i.table.GetTransactions(playerID))
+		roll, results := i.table.RollDiceAndResolve()
+		results = append(results, i.afterRoll()...)
+
+		output.WriteString(fmt.Sprintf("🎲 Rolled %d (%d + %d)\n", roll.Total, roll.Die1, roll.Die2))
+		for _, result := range results {
+			output.WriteString(result + "\n")
+		}
+
+		for _, tx := range i.table.GetTransactions(playerID)[ledgerStart:] {
+			switch tx.Type {
+			case crapsgame.TransactionWin, crapsgame.TransactionLoss, crapsgame.TransactionPush, crapsgame.TransactionRail:
+				output.WriteString(fmt.Sprintf("ℹ️ Bet resolved after %d roll(s)", rolls))
+				return output.String(), nil
+			}
+		}
+	}
+
+	return output.String(), fmt.Errorf("no bet resolved after %d rolls", maxRollsUntilResolved)
+}
+
+func (i *Interpreter) executeAlwaysStatement(stmt *AlwaysStatement) (string, error) {
+	var playerID string
+	for id := range i.table.Players {
+		playerID = id
+		break
+	}
+
+	if playerID == "" {
+		return "", fmt.Errorf("no players at table - add a player first")
+	}
+
+	return i.executeAlwaysStatementForPlayer(stmt, playerID)
+}
+
+// executeAlwaysStatementForPlayer records a standing bet and places it now.
+// A later ALWAYS on the same bet type replaces the earlier instruction.
+func (i *Interpreter) executeAlwaysStatementForPlayer(stmt *AlwaysStatement, playerID string) (string, error) {
+	if _, err := i.table.GetPlayer(playerID); err != nil {
+		return "", err
+	}
+
+	// A variable's value is fixed when the standing bet is made
+	amount, err := i.extractAmountFromExpression(stmt.Bet.Amount, playerID)
+	if err != nil {
+		return "", err
+	}
+	bet := *stmt.Bet
+	bet.Amount = &AmountExpression{Token: stmt.Bet.Amount.Token, Value: amount}
+
+	betType := i.betTypeToString(bet.BetType.Type)
+	standing := i.standingBets[playerID]
+	replaced := false
+	for idx, existing := range standing {
+		if i.betTypeToString(existing.BetType.Type) == betType {
+			standing[idx] = &bet
+			replaced = true
+		}
+	}
+	if !replaced {
+		standing = append(standing, &bet)
+	}
+	i.standingBets[playerID] = standing
+
+	result := fmt.Sprintf("🔁 Always $%.2f on %s", amount, betType)
+	if hasBetType(i.table, playerID, betType) {
+		return result, nil
+	}
+
+	placed, err := i.executeBetStatementForPlayer(&bet, playerID)
+	if err != nil {
+		return result, err
+	}
+	return result + "\n" + placed, nil
+}
+
+// executeLetStatement stores an amount in a variable for later statements.
+// Variables belong to the interpreter session and are shared by all players.
+func (i *Interpreter) executeLetStatement(stmt *LetStatement) (string, error) {
+	var playerID string
+	for id := range i.table.Players {
+		playerID = id
+		break
+	}
+
+	return i.executeLetStatementForPlayer(stmt, playerID)
+}
+
+// executeLetStatementForPlayer evaluates the value for the player, so LET can
+// capture things like BANKROLL / 10
+func (i *Interpreter) executeLetStatementForPlayer(stmt *LetStatement, playerID string) (string, error) {
+	amount, err := i.extractAmountFromExpression(stmt.Value, playerID)
+	if err != nil {
+		return "", err
+	}
+	i.variables[stmt.Name] = amount
+	return fmt.Sprintf("✅ Set %s to $%.2f", stmt.Name, amount), nil
+}
+
+func (i *Interpreter) executeCancelStatement(stmt *CancelStatement) (string, error) {
+	var playerID string
+	for id := range i.table.Players {
+		playerID = id
+		break
+	}
+
+	if playerID == "" {
+		return "", fmt.Errorf("no players at table - add a player first")
+	}
+
+	return i.executeCancelStatementForPlayer(stmt, playerID)
+}
+
+// executeCancelStatementForPlayer stops a standing bet. The bet already on
+// the layout stays up until it is decided.
+func (i *Interpreter) executeCancelStatementForPlayer(stmt *CancelStatement, playerID string) (string, error) {
+	betType := i.betTypeToString(stmt.BetType.Type)
+	standing := i.standingBets[playerID]
+	for idx, existing := range standing {
+		if i.betTypeToString(existing.BetType.Type) == betType {
+			i.standingBets[playerID] = append(standing[:idx], standing[idx+1:]...)
+			return fmt.Sprintf("✅ Cancelled always bet on %s", betType), nil
+		}
+	}
+	return "", fmt.Errorf("no always bet on %s to cancel", betType)
+}
+
+// afterRoll puts standing bets back up and advances running strategies once
+// a roll has been resolved
+func (i *Interpreter) afterRoll() []string {
+	return append(i.replaceStandingBets(), i.advanceStrategies()...)
+}
+
+// replaceStandingBets puts each player's ALWAYS bets back on the layout once
+// the previous one has been decided. Players are visited in ID order so the
+// output is stable; a bet that can't be placed is reported and retried after
+// the next roll.
+func (i *Interpreter) replaceStandingBets() []string {
+	var playerIDs []string
+	for id := range i.standingBets {
+		playerIDs = append(playerIDs, id)
+	}
+	sort.Strings(playerIDs)
+
+	var results []string
+	for _, playerID := range playerIDs {
+		if _, err := i.table.GetPlayer(playerID); err != nil {
+			// The player left the table
+			delete(i.standingBets, playerID)
+			continue
+		}
+		for _, stmt := range i.standingBets[playerID] {
+			betType := i.betTypeToString(stmt.BetType.Type)
+			if hasBetType(i.table, playerID, betType) {
+				continue
+			}
+			if _, err := i.executeBetStatementForPlayer(stmt, playerID); err != nil {
+				results = append(results, fmt.Sprintf("⚠️ Could not re-place always bet on %s for %s: %v", betType, playerID, err))
+				continue
+			}
+			results = append(results, fmt.Sprintf("🔁 Re-placed $%.2f on %s for %s", stmt.Amount.Value, betType, playerID))
+		}
+	}
+	return results
+}
+
+// hasBetType reports whether the player has a bet of the given type on the layout
+func hasBetType(table *crapsgame.Table, playerID, betType string) bool {
+	player, err := table.GetPlayer(playerID)
+	if err != nil {
+		return false
+	}
+	for _, bet := range player.Bets {
+		if bet.Type == betType {
+			return true
+		}
+	}
+	return false
+}
+
 func (i *Interpreter) executeShowPoint() string {
 	pointNumber := i.table.GetPointNumber()
 	if pointNumber == 0 {
@@ -570,7 +1161,40 @@ func (i *Interpreter) executeShowPoint() string {
 	}
 }
 
-func (i *Interpreter) executeShowBets() string {
+// rejectionExplanations describes each kind of bet rejection for SHOW WHY
+var rejectionExplanations = map[crapsgame.RejectionReason]string{
+	crapsgame.RejectLimit:    "The amount is outside the table limits, your own limits, the odds allowed or the exposure cap.",
+	crapsgame.RejectBankroll: "Your bankroll doesn't cover the bet.",
+	crapsgame.RejectState:    "The bet isn't allowed at this point in the game.",
+	crapsgame.RejectType:     "The table doesn't offer that bet.",
+	crapsgame.RejectSession:  "You've reached your win goal or loss limit for the session.",
+}
+
+// executeShowWhy explains the most recent bet the table refused
+func (i *Interpreter) executeShowWhy() string {
+	r := i.lastRejection
+	if r == nil {
+		return "No bets have been rejected"
+	}
+	return fmt.Sprintf("Rejected $%.2f on %s (%s)\n  %s\n  %v",
+		r.Amount, r.BetType, r.Reason, rejectionExplanations[r.Reason], r.Err)
+}
+
+// executeShowOdds shows what pass and don't pass odds pay on the current point
+func (i *Interpreter) executeShowOdds() (string, error) {
+	point := i.table.GetPointNumber()
+	if point == 0 {
+		return "Odds: no point established", nil
+	}
+	num, den, err := crapsgame.PointOdds(point)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Pass Odds %d:%d, Don't Pass Odds %d:%d", num, den, den, num), nil
+}
+
+// executeShowBetTypes lists the catalog of bet types by category
+func (i *Interpreter) executeShowBetTypes() string {
 	var output strings.Builder
 	output.WriteString("=== AVAILABLE BET TYPES ===\n\n")
 
@@ -632,6 +1256,402 @@ func (i *Interpreter) executeShowBankroll(playerID string) string {
 	return fmt.Sprintf("Player %s Bankroll: $%.2f", playerID, player.Bankroll)
 }
 
+// executeShowMyBets lists the player's bets, optionally filtered to ONE_ROLL
+// or MULTI_ROLL bets using the OneRoll flag from the canonical definitions
+func (i *Interpreter) executeShowMyBets(playerID string, filter string) string {
+	player, err := i.table.GetPlayer(playerID)
+	if err != nil {
+		return fmt.Sprintf("Error: Player %s not found", playerID)
+	}
+
+	var bets []*Bet
+	for _, bet := range player.Bets {
+		def, _ := crapsgame.GetBetDefinition(bet.Type)
+		if (filter == "ONE_ROLL" && !def.OneRoll) || (filter == "MULTI_ROLL" && def.OneRoll) {
+			continue
+		}
+		bets = append(bets, bet)
+	}
+
+	if len(bets) == 0 {
+		return fmt.Sprintf("Player %s has no active bets", playerID)
+	}
+
+	point := i.table.GetPointNumber()
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("Player %s Bets:", playerID))
+	for _, bet := range bets {
+		output.WriteString(fmt.Sprintf("\n  %s: $%.2f", bet.Type, bet.Amount))
+		if bet.CommissionPaid > 0 {
+			output.WriteString(fmt.Sprintf(" (commission paid: $%.2f)", bet.CommissionPaid))
+		}
+		output.WriteString(betNumbers(bet))
+		if point != 0 && linePointBetTypes[bet.Type] {
+			output.WriteString(fmt.Sprintf(" (point %d)", point))
+		}
+		if bet.Working {
+			output.WriteString(" [WORKING]")
+		} else {
+			output.WriteString(" [OFF]")
+		}
+		output.WriteString(fmt.Sprintf(" (ID %s)", bet.ID))
+	}
+	return output.String()
+}
+
+// betNumbers returns " on 4, 10" for a bet's numbers, or "" when its type
+// already says them (PLACE_6) or it has none
+func betNumbers(bet *Bet) string {
+	if len(bet.Numbers) == 0 || (len(bet.Numbers) == 1 && strings.HasSuffix(bet.Type, fmt.Sprintf("_%d", bet.Numbers[0]))) {
+		return ""
+	}
+	numbers := make([]string, len(bet.Numbers))
+	for idx, number := range bet.Numbers {
+		numbers[idx] = fmt.Sprintf("%d", number)
+	}
+	return " on " + strings.Join(numbers, ", ")
+}
+
+// executeShowSevenExposure lists the player's bets that lose to a 7 on the
+// next roll and the total at risk
+func (i *Interpreter) executeShowSevenExposure(playerID string) string {
+	if _, err := i.table.GetPlayer(playerID); err != nil {
+		return fmt.Sprintf("Error: Player %s not found", playerID)
+	}
+
+	bets, total := i.table.SevenExposure(playerID)
+	if len(bets) == 0 {
+		return fmt.Sprintf("Player %s Seven Exposure: nothing loses to a 7", playerID)
+	}
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("Player %s Seven Exposure: $%.2f", playerID, total))
+	for _, bet := range bets {
+		output.WriteString(fmt.Sprintf("\n  %s%s: $%.2f", bet.Type, betNumbers(bet), bet.Amount))
+	}
+	return output.String()
+}
+
+// executeShowBreakEven shows how often the player's current bets must win to
+// break even
+func (i *Interpreter) executeShowBreakEven(playerID string) string {
+	if _, err := i.table.GetPlayer(playerID); err != nil {
+		return fmt.Sprintf("Error: Player %s not found", playerID)
+	}
+
+	rate := i.table.BreakEvenWinRate(playerID)
+	if rate == 0 {
+		return fmt.Sprintf("Player %s Break-even: no bets with a fixed payout", playerID)
+	}
+	return fmt.Sprintf("Player %s Break-even: win %.2f%% of decisions", playerID, rate*100)
+}
+
+// executeShowMinBankroll shows the smallest bankroll that can place a bet type
+// at its minimum
+func (i *Interpreter) executeShowMinBankroll(playerID string, expr *BetTypeExpression) (string, error) {
+	betType := i.betTypeToString(expr.Type)
+	minimum, err := i.table.MinBankroll(playerID, betType)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Player %s Min bankroll for %s: $%.2f", playerID, betType, minimum), nil
+}
+
+func (i *Interpreter) executeShowWays(expr Expression) (string, error) {
+	total, err := i.evaluateExpression(expr)
+	if err != nil {
+		return "", err
+	}
+	if total < 2 || total > 12 {
+		return "", fmt.Errorf("invalid dice total: %d", int(total))
+	}
+
+	ways := crapsgame.WaysToRoll(int(total))
+	return fmt.Sprintf("Ways to roll %d: %d of 36 (%.2f%%)", int(total), ways, crapsgame.RollProbability(int(total))*100), nil
+}
+
+func (i *Interpreter) executeShowAvgBet(playerID string) string {
+	player, err := i.table.GetPlayer(playerID)
+	if err != nil {
+		return fmt.Sprintf("Error: Player %s not found", playerID)
+	}
+	return fmt.Sprintf("Player %s Average Bet: $%.2f (%d bets)",
+		playerID, i.table.AverageBetSize(playerID), player.Stats.BetsPlaced)
+}
+
+// executeShowCost estimates what the player's session costs per hour at their
+// average bet and the average house edge of what they've wagered
+func (i *Interpreter) executeShowCost(playerID string) string {
+	if _, err := i.table.GetPlayer(playerID); err != nil {
+		return fmt.Sprintf("Error: Player %s not found", playerID)
+	}
+
+	avgBet := i.table.AverageBetSize(playerID)
+	if avgBet == 0 {
+		return fmt.Sprintf("Player %s has no bets to estimate a cost from", playerID)
+	}
+	edge := i.table.AverageHouseEdge(playerID)
+	pace := i.table.HourlyPace()
+	return fmt.Sprintf("Player %s Expected Cost: $%.2f per hour ($%.2f average bet, %.2f%% house edge, %.0f rolls per hour)",
+		playerID, crapsgame.ExpectedLossPerHour(avgBet, edge, pace), avgBet, edge, pace)
+}
+
+// executeShowComp shows the player's comp rating: the session's theoretical
+// loss over the rolls the player has been at the table for
+func (i *Interpreter) executeShowComp(playerID string) string {
+	if _, err := i.table.GetPlayer(playerID); err != nil {
+		return fmt.Sprintf("Error: Player %s not found", playerID)
+	}
+
+	avgBet := i.table.AverageBetSize(playerID)
+	if avgBet == 0 {
+		return fmt.Sprintf("Player %s has no bets to rate", playerID)
+	}
+	// Rated at the table's own pace, so the rating covers the rolls played
+	pace := i.table.HourlyPace()
+	return fmt.Sprintf("Player %s Comp Rating: $%.2f theoretical loss (%.2f hours at $%.2f average bet, %.2f%% house edge, %.0f rolls per hour)",
+		playerID, i.table.CompRating(playerID, pace), i.table.HoursPlayed(playerID, pace), avgBet, i.table.AverageHouseEdge(playerID), pace)
+}
+
+// executeShowStats shows the player's session totals
+func (i *Interpreter) executeShowStats(playerID string) string {
+	player, err := i.table.GetPlayer(playerID)
+	if err != nil {
+		return fmt.Sprintf("Error: Player %s not found", playerID)
+	}
+
+	stats := player.Stats
+	return fmt.Sprintf("Player %s Stats: wagered $%.2f, won $%.2f, lost $%.2f, biggest win $%.2f, rolls survived %d",
+		playerID, stats.TotalWagered, stats.TotalWon, stats.TotalLost, stats.BiggestWin, stats.RollsSurvived)
+}
+
+// executeShowStreak shows the player's current run of wins or losses and the
+// longest of each this session
+func (i *Interpreter) executeShowStreak(playerID string) string {
+	player, err := i.table.GetPlayer(playerID)
+	if err != nil {
+		return fmt.Sprintf("Error: Player %s not found", playerID)
+	}
+
+	stats := player.Stats
+	current := "none"
+	switch {
+	case stats.CurrentStreak > 0:
+		current = fmt.Sprintf("%d win(s)", stats.CurrentStreak)
+	case stats.CurrentStreak < 0:
+		current = fmt.Sprintf("%d loss(es)", -stats.CurrentStreak)
+	}
+	return fmt.Sprintf("Player %s Streak: current %s, longest win streak %d, longest loss streak %d",
+		playerID, current, stats.LongestWinStreak, stats.LongestLossStreak)
+}
+
+// executeShowIfRoll previews how each of the player's bets would fare on a
+// roll without making it. A total is previewed the easy way, so a 6 is 1+5;
+// give both dice to preview a hardway.
+func (i *Interpreter) executeShowIfRoll(playerID string, dice []int) (string, error) {
+	player, err := i.table.GetPlayer(playerID)
+	if err != nil {
+		return "", err
+	}
+
+	var die1, die2 int
+	if len(dice) == 1 {
+		if dice[0] < 2 || dice[0] > 12 {
+			return "", fmt.Errorf("invalid dice total: %d", dice[0])
+		}
+		die1 = max(1, dice[0]-6)
+		die2 = dice[0] - die1
+	} else {
+		die1, die2 = dice[0], dice[1]
+		if die1 < 1 || die1 > 6 || die2 < 1 || die2 > 6 {
+			return "", fmt.Errorf("invalid dice: %d and %d, each die must be 1-6", die1, die2)
+		}
+	}
+
+	if len(player.Bets) == 0 {
+		return fmt.Sprintf("Player %s has no active bets", playerID), nil
+	}
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("If %d (%d+%d) rolls for %s:", die1+die2, die1, die2, playerID))
+	for _, bet := range player.Bets {
+		win, payout, decided := i.table.WouldWin(bet, die1, die2)
+		switch {
+		case !bet.Working:
+			output.WriteString(fmt.Sprintf("\n  %s: off", bet.Type))
+		case !decided:
+			output.WriteString(fmt.Sprintf("\n  %s: no decision", bet.Type))
+		case win && payout == 0:
+			output.WriteString(fmt.Sprintf("\n  %s: pushes", bet.Type))
+		case win:
+			output.WriteString(fmt.Sprintf("\n  %s: wins $%.2f", bet.Type, payout))
+		default:
+			output.WriteString(fmt.Sprintf("\n  %s: loses $%.2f", bet.Type, bet.Amount))
+		}
+	}
+	return output.String(), nil
+}
+
+// executeShowRemainingAction shows how much more the player can wager right now
+func (i *Interpreter) executeShowRemainingAction(playerID string) string {
+	if _, err := i.table.GetPlayer(playerID); err != nil {
+		return fmt.Sprintf("Error: Player %s not found", playerID)
+	}
+	return fmt.Sprintf("Player %s Remaining Action: $%.2f", playerID, i.table.RemainingAction(playerID))
+}
+
+// executeShowTableTotal shows the total working action across all players
+func (i *Interpreter) executeShowTableTotal() string {
+	return fmt.Sprintf("Table Total Working: $%.2f", i.table.TotalWorkingWager())
+}
+
+// executeShowHouse shows the house bankroll, the house's result so far unless
+// it was given a float
+func (i *Interpreter) executeShowHouse() string {
+	house, sign := i.table.GetHouseBankroll(), ""
+	if house < 0 {
+		house, sign = -house, "-"
+	}
+	return fmt.Sprintf("House Bankroll: %s$%.2f", sign, house)
+}
+
+// executeShowShooter shows the current shooter and their dice set, if any
+func (i *Interpreter) executeShowShooter() string {
+	shooter, diceSetting := i.table.GetDiceSetting()
+	if shooter == "" {
+		return "No shooter"
+	}
+	if diceSetting == "" {
+		return fmt.Sprintf("Shooter: %s", shooter)
+	}
+	return fmt.Sprintf("Shooter: %s (dice set: %s)", shooter, diceSetting)
+}
+
+// executeShowHistory lists the table's recent rolls, newest first, with the
+// state the game was in when each was thrown
+func (i *Interpreter) executeShowHistory() string {
+	history := i.table.GetRollHistory()
+	if len(history) == 0 {
+		return "No rolls yet"
+	}
+
+	result := fmt.Sprintf("Roll History (last %d):", len(history))
+	for n := len(history) - 1; n >= 0; n-- {
+		record := history[n]
+		state := "come out"
+		if record.State == crapsgame.StatePoint {
+			state = "point " + record.Point.String()
+		}
+		result += fmt.Sprintf("\n  %d (%d + %d) on %s", record.Roll.Total, record.Roll.Die1, record.Roll.Die2, state)
+	}
+	return result
+}
+
+// executeShowOutcomeHistogram lists the player's wins, losses and pushes per
+// bet type
+func (i *Interpreter) executeShowOutcomeHistogram(playerID string) string {
+	histogram := i.table.OutcomeHistogram(playerID)
+	if len(histogram) == 0 {
+		return fmt.Sprintf("Player %s has no decided bets", playerID)
+	}
+
+	betTypes := make([]string, 0, len(histogram))
+	for betType := range histogram {
+		betTypes = append(betTypes, betType)
+	}
+	sort.Strings(betTypes)
+
+	result := fmt.Sprintf("Player %s Outcomes:", playerID)
+	for _, betType := range betTypes {
+		count := histogram[betType]
+		result += fmt.Sprintf("\n  %s: %d W / %d L", betType, count.Wins, count.Losses)
+		if count.Pushes > 0 {
+			result += fmt.Sprintf(" / %d P", count.Pushes)
+		}
+	}
+	return result
+}
+
+// executeShowVig compares a bet's true-odds payout with what the house pays
+func (i *Interpreter) executeShowVig(expr *BetTypeExpression) (string, error) {
+	betType := i.betTypeToString(expr.Type)
+	num, den, err := crapsgame.FairOdds(betType)
+	if err != nil {
+		return "", err
+	}
+	fair, _ := crapsgame.FairPayout(betType)
+	actual, _ := i.table.ActualPayout(betType)
+	take, _ := i.table.HouseTake(betType)
+	def, _ := i.table.BetDefinition(betType)
+
+	return fmt.Sprintf("%s: fair %d:%d, actual %s, gap $%.2f per $1, house take %.2f%%",
+		betType, num, den, def.Payout, fair-actual, take*100), nil
+}
+
+// executeShowImplied shows the win probability a bet's payout implies next to
+// the bet's actual chance of winning, where that is defined
+func (i *Interpreter) executeShowImplied(expr *BetTypeExpression) (string, error) {
+	betType := i.betTypeToString(expr.Type)
+	def, exists := i.table.BetDefinition(betType)
+	if !exists {
+		return "", fmt.Errorf("unknown bet type: %s", betType)
+	}
+	if def.PayoutNumerator <= 0 || def.PayoutDenominator <= 0 {
+		return "", fmt.Errorf("%s has no fixed payout", betType)
+	}
+
+	implied := crapsgame.ImpliedProbability(def.PayoutNumerator, def.PayoutDenominator)
+	result := fmt.Sprintf("%s: pays %s, implied probability %.2f%%", betType, def.Payout, implied*100)
+	if actual, err := crapsgame.WinProbability(betType); err == nil {
+		result += fmt.Sprintf(", actual %.2f%%", actual*100)
+	}
+	return result, nil
+}
+
+// executeShowVariance shows how widely a bet's results swing per $1 wagered,
+// as the variance and standard deviation of one decision
+func (i *Interpreter) executeShowVariance(expr *BetTypeExpression) (string, error) {
+	betType := i.betTypeToString(expr.Type)
+	variance, ok := i.table.BetVariance(betType)
+	if !ok {
+		return "", fmt.Errorf("variance not defined for %s", betType)
+	}
+	return fmt.Sprintf("%s: variance %.4f, standard deviation %.4f per $1",
+		betType, variance, math.Sqrt(variance)), nil
+}
+
+// executeShowBehavior shows what a bet does on the come-out roll and while a
+// point is on, following the table's working defaults
+func (i *Interpreter) executeShowBehavior(expr *BetTypeExpression) (string, error) {
+	betType := i.betTypeToString(expr.Type)
+	def, exists := crapsgame.GetBetDefinition(betType)
+	if !exists {
+		return "", fmt.Errorf("unknown bet type: %s", betType)
+	}
+
+	comeOut, point := "working", "working"
+	if def.OneRoll {
+		comeOut, point = "one roll, decided on the next roll", "one roll, decided on the next roll"
+	} else {
+		working, err := i.table.WorksOnComeOut(betType)
+		if err != nil {
+			return "", err
+		}
+		if !working {
+			comeOut = "off unless called working"
+		}
+	}
+	if def.RequiresPoint && !(def.Category == crapsgame.ComeBets && i.table.ComeBetsOnComeOut) {
+		comeOut += " (can't be placed until a point is set)"
+	}
+	if def.RequiresComeOut {
+		point += " (can only be placed on the come-out)"
+	}
+
+	return fmt.Sprintf("%s (%s):\n  Come-out: %s\n  Point: %s", betType, def.Name, comeOut, point), nil
+}
+
 func (i *Interpreter) executeShowTableMinimums() string {
 	return fmt.Sprintf("Table Limits:\n  Minimum Bet: $%.2f\n  Maximum Bet: $%.2f\n  Maximum Odds: %dx",
 		i.table.MinBet, i.table.MaxBet, i.table.MaxOdds)
@@ -661,6 +1681,8 @@ func (i *Interpreter) betTypeToString(betType BetType) string {
 		return "ACES"
 	case BetBoxcars:
 		return "BOXCARS"
+	case BetComeOutHardways:
+		return "COME_OUT_HARDWAYS"
 	case BetPlace4:
 		return "PLACE_4"
 	case BetPlace5:
@@ -693,29 +1715,97 @@ func (i *Interpreter) betTypeToString(betType BetType) string {
 		return "PASS_ODDS"
 	case BetDontPassOdds:
 		return "DONT_PASS_ODDS"
+	case BetComeOdds:
+		return "COME_ODDS"
+	case BetDontComeOdds:
+		return "DONT_COME_ODDS"
 	case BetBuy4:
 		return "BUY_4"
+	case BetBuy5:
+		return "BUY_5"
+	case BetBuy6:
+		return "BUY_6"
+	case BetBuy8:
+		return "BUY_8"
+	case BetBuy9:
+		return "BUY_9"
 	case BetBuy10:
 		return "BUY_10"
 	case BetLay4:
 		return "LAY_4"
+	case BetLay5:
+		return "LAY_5"
+	case BetLay6:
+		return "LAY_6"
+	case BetLay8:
+		return "LAY_8"
+	case BetLay9:
+		return "LAY_9"
 	case BetLay10:
 		return "LAY_10"
+	case BetPlaceToLose4:
+		return "PLACE_TO_LOSE_4"
+	case BetPlaceToLose5:
+		return "PLACE_TO_LOSE_5"
+	case BetPlaceToLose6:
+		return "PLACE_TO_LOSE_6"
+	case BetPlaceToLose8:
+		return "PLACE_TO_LOSE_8"
+	case BetPlaceToLose9:
+		return "PLACE_TO_LOSE_9"
+	case BetPlaceToLose10:
+		return "PLACE_TO_LOSE_10"
 	case BetBig6:
 		return "BIG_6"
 	case BetBig8:
 		return "BIG_8"
 	case BetHop:
 		return "HOP"
+	case BetHop12:
+		return "HOP_1_2"
+	case BetHop13:
+		return "HOP_1_3"
+	case BetHop14:
+		return "HOP_1_4"
+	case BetHop15:
+		return "HOP_1_5"
+	case BetHop16:
+		return "HOP_1_6"
+	case BetHop23:
+		return "HOP_2_3"
+	case BetHop24:
+		return "HOP_2_4"
+	case BetHop25:
+		return "HOP_2_5"
+	case BetHop26:
+		return "HOP_2_6"
+	case BetHop34:
+		return "HOP_3_4"
+	case BetHop35:
+		return "HOP_3_5"
+	case BetHop36:
+		return "HOP_3_6"
+	case BetHop45:
+		return "HOP_4_5"
+	case BetHop46:
+		return "HOP_4_6"
+	case BetHop56:
+		return "HOP_5_6"
+	case BetHopHard4:
+		return "HOP_HARD_4"
 	case BetHopHard6:
 		return "HOP_HARD_6"
+	case BetHopHard8:
+		return "HOP_HARD_8"
+	case BetHopHard10:
+		return "HOP_HARD_10"
 	case BetHopEasy8:
 		return "HOP_EASY_8"
 	case BetHorn:
 		return "HORN"
 	case BetHornHigh2:
 		return "HORN_HIGH_2"
-	case BetHornHigh3:
+	case BetHornHigh3, BetHornHighAceDeuce:
 		return "HORN_HIGH_3"
 	case BetHornHigh11:
 		return "HORN_HIGH_11"
@@ -732,6 +1822,30 @@ func (i *Interpreter) betTypeToString(betType BetType) string {
 
 // Helper functions that should remain in the interpreter (language-specific)
 
+// hopBetType returns the bet HOP(a, b) places: HOP_<low>_<high> for two
+// different faces, which pays 15:1, or a 30:1 hard hop for a pair, with 1-1
+// and 6-6 being ACES and BOXCARS
+func hopBetType(faces []int) (string, error) {
+	if len(faces) != 2 {
+		return "", fmt.Errorf("HOP needs two dice, e.g. HOP(2, 4)")
+	}
+	low, high := faces[0], faces[1]
+	if low > high {
+		low, high = high, low
+	}
+	switch {
+	case low < 1 || high > 6:
+		return "", fmt.Errorf("invalid hop combination: %d-%d", faces[0], faces[1])
+	case low == 1 && high == 1:
+		return "ACES", nil
+	case low == 6 && high == 6:
+		return "BOXCARS", nil
+	case low == high:
+		return fmt.Sprintf("HOP_HARD_%d", low+high), nil
+	}
+	return fmt.Sprintf("HOP_%d_%d", low, high), nil
+}
+
 func generateBetID() string {
 	return fmt.Sprintf("bet_%d", time.Now().UnixNano())
 }
@@ -831,6 +1945,8 @@ func extractNumbersForBetType(expr *BetTypeExpression) []int {
 		numbers = []int{2}
 	case BetBoxcars:
 		numbers = []int{12}
+	case BetComeOutHardways:
+		numbers = []int{4, 6, 8, 10}
 	}
 
 	return numbers
@@ -907,6 +2023,35 @@ func (i *Interpreter) evaluateIdentifierExpression(expr *IdentifierExpression) (
 	return i.evaluateIdentifierExpressionForPlayer(expr, playerID)
 }
 
+// evaluateArithmeticForPlayer evaluates +, -, * and / between two expressions
+func (i *Interpreter) evaluateArithmeticForPlayer(expr *InfixExpression, playerID string) (float64, error) {
+	left, err := i.evaluateExpressionForPlayer(expr.Left, playerID)
+	if err != nil {
+		return 0, err
+	}
+
+	right, err := i.evaluateExpressionForPlayer(expr.Right, playerID)
+	if err != nil {
+		return 0, err
+	}
+
+	switch expr.Operator {
+	case "+":
+		return left + right, nil
+	case "-":
+		return left - right, nil
+	case "*":
+		return left * right, nil
+	case "/":
+		if right == 0 {
+			return 0, fmt.Errorf("division by zero")
+		}
+		return left / right, nil
+	default:
+		return 0, fmt.Errorf("unsupported arithmetic operator: %s", expr.Operator)
+	}
+}
+
 func (i *Interpreter) evaluateIdentifierExpressionForPlayer(expr *IdentifierExpression, playerID string) (float64, error) {
 	switch expr.Value {
 	case "POINT":
@@ -917,7 +2062,40 @@ func (i *Interpreter) evaluateIdentifierExpressionForPlayer(expr *IdentifierExpr
 			return 0, err
 		}
 		return player.Bankroll, nil
+	case "PROFIT":
+		// Net result of decided bets this session, after commission
+		player, err := i.table.GetPlayer(playerID)
+		if err != nil {
+			return 0, err
+		}
+		return player.Stats.Net(), nil
+	case "ROLLS_SINCE_SEVEN":
+		// Rolls since the last seven, or every remembered roll if there wasn't one
+		history := i.table.GetRollHistory()
+		rolls := 0
+		for n := len(history) - 1; n >= 0 && history[n].Roll.Total != 7; n-- {
+			rolls++
+		}
+		return float64(rolls), nil
+	case "SEVENS_COUNT":
+		// Sevens among the remembered rolls
+		sevens := 0
+		for _, record := range i.table.GetRollHistory() {
+			if record.Roll.Total == 7 {
+				sevens++
+			}
+		}
+		return float64(sevens), nil
 	default:
-		return 0, fmt.Errorf("unknown identifier: %s", expr.Value)
+		return i.variableValue(expr.Value, expr.Token)
+	}
+}
+
+// variableValue returns the amount stored in a LET variable
+func (i *Interpreter) variableValue(name string, token Token) (float64, error) {
+	value, exists := i.variables[name]
+	if !exists {
+		return 0, fmt.Errorf("undefined variable %s at line %d, column %d", name, token.Line, token.Column)
 	}
+	return value, nil
 }
@@ -1,5 +1,10 @@
 package crapsql
 
+import (
+	"slices"
+	"strings"
+)
+
 type Lexer struct {
 	input        string
 	position     int  // current position in input (points to current char)
@@ -36,7 +41,17 @@ func (l *Lexer) peekChar() byte {
 func (l *Lexer) NextToken() Token {
 	var tok Token
 
-	l.skipWhitespace()
+	// Comments are skipped like whitespace
+	for {
+		l.skipWhitespace()
+		if l.ch != '/' || (l.peekChar() != '/' && l.peekChar() != '*') {
+			break
+		}
+		line, column := l.line, l.column
+		if comment, ok := l.skipComment(); !ok {
+			return Token{Type: ILLEGAL, Literal: comment, Line: line, Column: column}
+		}
+	}
 
 	tok.Line = l.line
 	tok.Column = l.column
@@ -82,6 +97,17 @@ func (l *Lexer) NextToken() Token {
 		}
 	case '$':
 		tok = newToken(DOLLAR, l.ch, l.line, l.column)
+	case '"':
+		tok.Line = l.line
+		tok.Column = l.column
+		literal, ok := l.readString()
+		if !ok {
+			tok.Type = ILLEGAL
+			tok.Literal = "\"" + literal
+			return tok
+		}
+		tok.Type = STRING
+		tok.Literal = literal
 	case '{':
 		tok = newToken(LBRACE, l.ch, l.line, l.column)
 	case '}':
@@ -91,10 +117,10 @@ func (l *Lexer) NextToken() Token {
 		tok.Type = EOF
 	default:
 		if isLetter(l.ch) {
-			tok.Literal = l.readIdentifier()
+			// The token starts where the first word does, which is where
+			// tok.Line and tok.Column already point
+			tok.Literal = l.readSpokenBetType(l.readIdentifier())
 			tok.Type = l.lookupIdent(tok.Literal)
-			tok.Line = l.line
-			tok.Column = l.column - len(tok.Literal)
 			return tok
 		} else if isDigit(l.ch) {
 			tok.Literal = l.readNumber()
@@ -119,6 +145,19 @@ func (l *Lexer) NextToken() Token {
 	return tok
 }
 
+// Tokenize reads the remaining input and returns every token, including the
+// trailing EOF token. It is intended for tooling such as syntax highlighters.
+func (l *Lexer) Tokenize() []Token {
+	var tokens []Token
+	for {
+		tok := l.NextToken()
+		tokens = append(tokens, tok)
+		if tok.Type == EOF {
+			return tokens
+		}
+	}
+}
+
 func (l *Lexer) skipWhitespace() {
 	for l.ch == ' ' || l.ch == '\t' || l.ch == '\n' || l.ch == '\r' {
 		if l.ch == '\n' {
@@ -129,6 +168,37 @@ func (l *Lexer) skipWhitespace() {
 	}
 }
 
+// skipComment skips a // line comment or a /* block comment */, leaving the
+// lexer on the character after it (a line comment stops before its newline).
+// ok is false if the input ends inside a block comment; the unterminated
+// comment text is returned so it can be reported.
+func (l *Lexer) skipComment() (string, bool) {
+	position := l.position
+	if l.peekChar() == '/' {
+		for l.ch != '\n' && l.ch != 0 {
+			l.readChar()
+		}
+		return l.input[position:l.position], true
+	}
+
+	l.readChar() // consume '/'
+	l.readChar() // consume '*'
+	for {
+		switch {
+		case l.ch == 0:
+			return l.input[position:l.position], false
+		case l.ch == '*' && l.peekChar() == '/':
+			l.readChar()
+			l.readChar()
+			return l.input[position:l.position], true
+		case l.ch == '\n':
+			l.line++
+			l.column = 0
+		}
+		l.readChar()
+	}
+}
+
 func (l *Lexer) readIdentifier() string {
 	position := l.position
 	for isLetter(l.ch) || isDigit(l.ch) || l.ch == '_' {
@@ -137,6 +207,73 @@ func (l *Lexer) readIdentifier() string {
 	return l.input[position:l.position]
 }
 
+// spokenBetTypes are bet types players say as separate words, such as ACE
+// DEUCE; the lexer reads the words as the one keyword
+var spokenBetTypes = []string{
+	"ACE_DEUCE",
+	"HORN_HIGH_2",
+	"HORN_HIGH_3",
+	"HORN_HIGH_11",
+	"HORN_HIGH_12",
+	"HORN_HIGH_ACE_DEUCE",
+}
+
+// readSpokenBetType joins word with the words after it on the same line, when
+// together they spell one of spokenBetTypes, and returns the keyword: HORN
+// HIGH 11 reads as HORN_HIGH_11. The longest match wins. Without a match the
+// lexer is left just after word and word is returned as it is.
+func (l *Lexer) readSpokenBetType(word string) string {
+	keyword, end := word, *l
+	for spokenPrefix(word + "_") {
+		if l.ch != ' ' && l.ch != '\t' {
+			break
+		}
+		for l.ch == ' ' || l.ch == '\t' {
+			l.readChar()
+		}
+
+		switch {
+		case isLetter(l.ch):
+			word += "_" + l.readIdentifier()
+		case isDigit(l.ch):
+			word += "_" + l.readNumber()
+		default:
+			*l = end
+			return keyword
+		}
+		if slices.Contains(spokenBetTypes, word) {
+			keyword, end = word, *l
+		}
+	}
+	*l = end
+	return keyword
+}
+
+// spokenPrefix reports whether prefix starts one of spokenBetTypes
+func spokenPrefix(prefix string) bool {
+	for _, betType := range spokenBetTypes {
+		if strings.HasPrefix(betType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// readString reads a double-quoted string and returns its contents, leaving the
+// lexer on the closing quote. ok is false if the input ends before the string does.
+func (l *Lexer) readString() (string, bool) {
+	position := l.position + 1
+	for {
+		l.readChar()
+		if l.ch == '"' {
+			return l.input[position:l.position], true
+		}
+		if l.ch == 0 || l.ch == '\n' {
+			return l.input[position:l.position], false
+		}
+	}
+}
+
 func (l *Lexer) readNumber() string {
 	position := l.position
 	for isDigit(l.ch) {
@@ -183,6 +320,10 @@ func (l *Lexer) lookupIdent(ident string) TokenType {
 		return ELSE
 	case "END":
 		return END
+	case "WHILE":
+		return WHILE
+	case "DO":
+		return DO
 	case "SET":
 		return SET
 	case "SHOW":
@@ -244,6 +385,8 @@ func (l *Lexer) lookupIdent(ident string) TokenType {
 		return ACES
 	case "BOXCARS":
 		return BOXCARS
+	case "COME_OUT_HARDWAYS":
+		return COME_OUT_HARDWAYS
 	case "PLACE_4":
 		return PLACE_4
 	case "PLACE_5":
@@ -290,8 +433,14 @@ func (l *Lexer) lookupIdent(ident string) TokenType {
 		return BIG_8
 	case "HOP":
 		return HOP
+	case "HOP_HARD_4":
+		return HOP_HARD_4
 	case "HOP_HARD_6":
 		return HOP_HARD_6
+	case "HOP_HARD_8":
+		return HOP_HARD_8
+	case "HOP_HARD_10":
+		return HOP_HARD_10
 	case "HOP_EASY_8":
 		return HOP_EASY_8
 	case "WORLD":
@@ -390,6 +539,22 @@ func (l *Lexer) lookupIdent(ident string) TokenType {
 		return ROLL
 	case "DICE":
 		return DICE
+	case "ALWAYS":
+		return ALWAYS
+	case "CANCEL":
+		return CANCEL
+	case "LET":
+		return LET
+	case "RUN":
+		return RUN
+	case "TAKE":
+		return TAKE
+	case "REPEAT":
+		return REPEAT
+	case "TIMES":
+		return TIMES
+	case "FOR":
+		return FOR
 	case "ONE_ROLL":
 		return ONE_ROLL
 	case "MAX":
@@ -7,7 +7,7 @@ import (
 
 type Parser struct {
 	l      *Lexer
-	errors []string
+	errors []*ParseError
 
 	curToken  Token
 	peekToken Token
@@ -24,7 +24,7 @@ type (
 func NewParser(l *Lexer) *Parser {
 	p := &Parser{
 		l:      l,
-		errors: []string{},
+		errors: []*ParseError{},
 	}
 
 	p.prefixParseFns = make(map[TokenType]prefixParseFn)
@@ -63,20 +63,34 @@ func (p *Parser) parseStatement() Statement {
 		return p.parseBetStatement()
 	case IF:
 		return p.parseConditionalStatement()
+	case WHILE:
+		return p.parseWhileStatement()
+	case REPEAT:
+		return p.parseRepeatStatement()
 	case SHOW:
 		return p.parseQueryStatement()
 	case SET:
 		return p.parseManagementStatement()
 	case REMOVE:
 		return p.parseRemoveStatement()
+	case TAKE, TAKE_DOWN:
+		return p.parseTakeDownStatement()
 	case PRESS:
 		return p.parsePressStatement()
 	case TURN:
 		return p.parseTurnStatement()
 	case ROLL:
 		return p.parseRollStatement()
+	case ALWAYS:
+		return p.parseAlwaysStatement()
+	case CANCEL:
+		return p.parseCancelStatement()
+	case LET:
+		return p.parseLetStatement()
+	case RUN:
+		return p.parseRunStrategyStatement()
 	default:
-		p.addError(fmt.Sprintf("unexpected token: %s", p.curToken.Literal))
+		p.addError(fmt.Sprintf("unexpected token '%s'", p.curToken.Literal))
 		// Use error recovery to skip to next statement
 		return recoverFromParseError(p)
 	}
@@ -85,22 +99,10 @@ func (p *Parser) parseStatement() Statement {
 func (p *Parser) parseBetStatement() *BetStatement {
 	stmt := &BetStatement{Token: p.curToken}
 
-	if !p.expectPeek(DOLLAR) {
-		return nil
-	}
-
-	if !p.expectPeek(NUMBER) {
-		return nil
-	}
-
-	// Parse amount
-	amount := &AmountExpression{Token: p.curToken}
-	val, err := parseAmount(p.curToken.Literal)
-	if err != nil {
-		p.addError(fmt.Sprintf("invalid amount: %s", p.curToken.Literal))
+	amount, validAmount := p.parseAmountExpression()
+	if amount == nil {
 		return nil
 	}
-	amount.Value = val
 	stmt.Amount = amount
 
 	if !p.expectPeek(ON) {
@@ -126,14 +128,96 @@ func (p *Parser) parseBetStatement() *BetStatement {
 	}
 	stmt.Modifiers = modifiers
 
+	// PLACE $25 ON PASS_LINE FOR player2;
+	if p.curToken.Type == FOR {
+		if !p.expectPeek(IDENT) {
+			return nil
+		}
+		stmt.Player = p.curToken.Literal
+		p.nextToken() // advance past the player
+	}
+
 	if p.curToken.Type != SEMICOLON {
 		p.addError("expected semicolon after bet statement")
 		return nil
 	}
 
+	if !validAmount {
+		return nil
+	}
+
 	return stmt
 }
 
+// parseAmountExpression parses the amount following the current token: $amount,
+// the name of a variable set with LET, or arithmetic over either such as
+// $10 + $5 * 2. valid is false when a literal amount isn't positive; the error
+// has been recorded, but the expression is still returned so the caller can
+// keep parsing the rest of the statement rather than cascade into unrelated
+// errors. Arithmetic is evaluated when the statement runs.
+func (p *Parser) parseAmountExpression() (amount *AmountExpression, valid bool) {
+	start := p.peekToken
+
+	var first Expression
+	if p.peekTokenIs(IDENT) || p.peekTokenIs(LPAREN) {
+		p.nextToken()
+		first = p.parsePrimaryExpression()
+		valid = true
+	} else {
+		amount, valid = p.parseLiteralAmount()
+		if amount == nil {
+			return nil, false
+		}
+		first = amount
+	}
+
+	switch expr := p.parseArithmeticFrom(p.parseTermFrom(first)); {
+	case expr != first:
+		return &AmountExpression{Token: start, Expr: expr}, valid
+	case amount != nil:
+		return amount, valid
+	}
+	if ident, ok := first.(*IdentifierExpression); ok {
+		return &AmountExpression{Token: ident.Token, Name: ident.Value}, valid
+	}
+	return &AmountExpression{Token: start, Expr: first}, valid
+}
+
+// parseLiteralAmount parses $amount following the current token
+func (p *Parser) parseLiteralAmount() (amount *AmountExpression, valid bool) {
+	if !p.expectPeek(DOLLAR) {
+		return nil, false
+	}
+	dollar := p.curToken
+
+	// Accept a leading minus so negative amounts get a clear diagnostic
+	negative := false
+	if p.peekTokenIs(MINUS) {
+		p.nextToken()
+		negative = true
+	}
+
+	if !p.expectPeek(NUMBER) {
+		return nil, false
+	}
+
+	amount = &AmountExpression{Token: p.curToken}
+	val, err := parseAmount(p.curToken.Literal)
+	if err != nil {
+		p.addError(fmt.Sprintf("invalid amount: %s", p.curToken.Literal))
+		return nil, false
+	}
+	if negative {
+		val = -val
+	}
+	valid = val > 0
+	if !valid {
+		p.addErrorAt(dollar, "bet amount must be positive")
+	}
+	amount.Value = val
+	return amount, valid
+}
+
 // Helper to check if a token is a modifier
 func isModifierToken(t TokenType) bool {
 	switch t {
@@ -171,6 +255,8 @@ func (p *Parser) parseBetTypeExpression() *BetTypeExpression {
 		expr.Type = BetAces
 	case BOXCARS:
 		expr.Type = BetBoxcars
+	case COME_OUT_HARDWAYS:
+		expr.Type = BetComeOutHardways
 	case PLACE_4:
 		expr.Type = BetPlace4
 	case PLACE_5:
@@ -219,8 +305,14 @@ func (p *Parser) parseBetTypeExpression() *BetTypeExpression {
 	case HOP:
 		expr.Type = BetHop
 		expr.Args = p.parseHopCombination()
+	case HOP_HARD_4:
+		expr.Type = BetHopHard4
 	case HOP_HARD_6:
 		expr.Type = BetHopHard6
+	case HOP_HARD_8:
+		expr.Type = BetHopHard8
+	case HOP_HARD_10:
+		expr.Type = BetHopHard10
 	case HOP_EASY_8:
 		expr.Type = BetHopEasy8
 	case WORLD:
@@ -322,7 +414,6 @@ func (p *Parser) parsePlaceNumbers() []Expression {
 	if !p.expectPeek(LPAREN) {
 		return numbers
 	}
-	p.nextToken() // consume (
 
 	// Valid place numbers in craps
 	validPlaceNumbers := map[int]bool{4: true, 5: true, 6: true, 8: true, 9: true, 10: true}
@@ -363,84 +454,29 @@ func (p *Parser) parsePlaceNumbers() []Expression {
 	return numbers
 }
 
+// parseHopCombination parses the two faces of HOP(a, b). Either face can be
+// 1 to 6 and the faces can match, e.g. HOP(2, 2) for a hard hop.
 func (p *Parser) parseHopCombination() []Expression {
 	var combinations []Expression
 
 	if !p.expectPeek(LPAREN) {
 		return combinations
 	}
-	p.nextToken() // consume (
-
-	// Valid hop combinations in craps (die1-die2 format)
-	validHopCombinations := map[string]bool{
-		"1-2": true, "1-3": true, "1-4": true, "1-5": true, "1-6": true,
-		"2-3": true, "2-4": true, "2-5": true, "2-6": true,
-		"3-4": true, "3-5": true, "3-6": true,
-		"4-5": true, "4-6": true,
-		"5-6": true,
-	}
 
-	for !p.peekTokenIs(RPAREN) && !p.peekTokenIs(EOF) {
-		p.nextToken()
-		if p.curToken.Type != NUMBER {
-			p.addError(fmt.Sprintf("expected number, got %s", p.curToken.Literal))
+	for len(combinations) < 2 {
+		if len(combinations) > 0 && !p.expectPeek(COMMA) {
 			return combinations
 		}
-
-		// Parse first die value
-		val1, err := strconv.Atoi(p.curToken.Literal)
-		if err != nil {
-			p.addError(fmt.Sprintf("invalid number: %s", p.curToken.Literal))
-			return combinations
-		}
-
-		// Validate first die is 1-6
-		if val1 < 1 || val1 > 6 {
-			p.addError(fmt.Sprintf("invalid die value: %s (must be 1-6)", p.curToken.Literal))
-			return combinations
-		}
-
-		// Create NumberExpression for first die
-		expr1 := &NumberExpression{Token: p.curToken, Value: float64(val1)}
-		combinations = append(combinations, expr1)
-
-		if !p.expectPeek(COMMA) {
-			return combinations
-		}
-		p.nextToken() // consume comma
-
 		if !p.expectPeek(NUMBER) {
 			return combinations
 		}
-		p.nextToken() // consume second number
-
-		// Parse second die value
-		val2, err := strconv.Atoi(p.curToken.Literal)
-		if err != nil {
-			p.addError(fmt.Sprintf("invalid number: %s", p.curToken.Literal))
-			return combinations
-		}
 
-		// Validate second die is 1-6
-		if val2 < 1 || val2 > 6 {
+		face, err := strconv.Atoi(p.curToken.Literal)
+		if err != nil || face < 1 || face > 6 {
 			p.addError(fmt.Sprintf("invalid die value: %s (must be 1-6)", p.curToken.Literal))
 			return combinations
 		}
-
-		// Validate the combination is valid
-		combination := fmt.Sprintf("%d-%d", val1, val2)
-		if !validHopCombinations[combination] {
-			p.addError(fmt.Sprintf("invalid hop combination: %d-%d", val1, val2))
-			return combinations
-		}
-
-		// Create NumberExpression for second die
-		expr2 := &NumberExpression{Token: p.curToken, Value: float64(val2)}
-		combinations = append(combinations, expr2)
-
-		if p.peekTokenIs(COMMA) {
-			p.nextToken() // consume comma
-		}
+		combinations = append(combinations, &NumberExpression{Token: p.curToken, Value: float64(face)})
 	}
 
 	if !p.expectPeek(RPAREN) {
@@ -456,7 +492,8 @@ func (p *Parser) parseModifiers() []*ModifierExpression {
 	// Track modifier types to validate combinations
 	usedModifiers := make(map[ModifierType]bool)
 
-	for p.curToken.Type != SEMICOLON && p.curToken.Type != EOF {
+	// A FOR <player> clause ends the modifiers
+	for p.curToken.Type != SEMICOLON && p.curToken.Type != EOF && p.curToken.Type != FOR {
 		mod := &ModifierExpression{Token: p.curToken}
 
 		switch p.curToken.Type {
@@ -539,6 +576,31 @@ func (p *Parser) parseModifiers() []*ModifierExpression {
 				p.addError("RATIO modifier requires a value")
 				return modifiers
 			}
+		case IDENT:
+			// FULL ODDS / DOUBLE ODDS shorthand, PARLAY and KEEP
+			switch p.curToken.Literal {
+			case "FULL":
+				mod.Type = ModFullOdds
+			case "DOUBLE":
+				mod.Type = ModDoubleOdds
+			case "PARLAY":
+				mod.Type = ModParlay
+			case "KEEP":
+				mod.Type = ModKeep
+			default:
+				p.addError(fmt.Sprintf("invalid modifier: %s", p.curToken.Literal))
+				return modifiers
+			}
+			if mod.Type == ModParlay || mod.Type == ModKeep {
+				break
+			}
+			if !p.expectPeek(ODDS) {
+				return modifiers
+			}
+			if usedModifiers[ModFullOdds] || usedModifiers[ModDoubleOdds] {
+				p.addError("cannot have both FULL ODDS and DOUBLE ODDS modifiers")
+				return modifiers
+			}
 		default:
 			p.addError(fmt.Sprintf("invalid modifier: %s", p.curToken.Literal))
 			return modifiers
@@ -558,53 +620,213 @@ func (p *Parser) parseConditionalStatement() *ConditionalStatement {
 
 	p.nextToken() // consume IF
 
+	stmt.Condition = p.parseCondition()
+
+	if !p.curTokenIs(THEN) {
+		p.addError(fmt.Sprintf("expected THEN, got %s", p.curToken.Literal))
+		return nil
+	}
+	p.nextToken() // consume THEN
+
+	stmt.Consequence = p.parseConditionalBranch()
+	if stmt.Consequence == nil {
+		return nil
+	}
+
+	if p.curTokenIs(ELSE) {
+		if p.peekTokenIs(IF) {
+			// ELSE IF nests another conditional as the alternative; the whole
+			// chain shares the one END, which the innermost conditional consumes
+			elseToken := p.curToken
+			p.nextToken() // advance to IF
+			nested := p.parseConditionalStatement()
+			if nested == nil {
+				return nil
+			}
+			stmt.Alternative = &BlockStatement{Token: elseToken, Statements: []Statement{nested}}
+			return stmt
+		}
+
+		p.nextToken() // consume ELSE
+		stmt.Alternative = p.parseConditionalBranch()
+		if stmt.Alternative == nil {
+			return nil
+		}
+		if p.curTokenIs(ELSE) {
+			p.addError("unexpected ELSE after the final ELSE of an IF")
+			return nil
+		}
+	}
+
+	if p.peekTokenIs(SEMICOLON) {
+		p.nextToken() // consume semicolon
+	}
+
+	return stmt
+}
+
+// parseConditionalBranch parses the statements of an IF branch, leaving the
+// parser on the ELSE or END that closes it. Nested IF, WHILE and REPEAT
+// consume their own END.
+func (p *Parser) parseConditionalBranch() *BlockStatement {
+	block := &BlockStatement{Token: p.curToken, Statements: []Statement{}}
+	for !p.curTokenIs(ELSE) && !p.curTokenIs(END) {
+		if p.curTokenIs(EOF) {
+			p.addError("unexpected end of input: missing END for IF")
+			return nil
+		}
+		if s := p.parseStatement(); s != nil {
+			block.Statements = append(block.Statements, s)
+		}
+		p.nextToken()
+	}
+	return block
+}
+
+// parseCondition parses an IF or WHILE condition, optionally with a comparison,
+// and leaves the parser on the token that follows it
+func (p *Parser) parseCondition() Expression {
 	// Parse full conditional expression with support for complex comparisons
-	stmt.Condition = p.parsePrimaryExpression()
+	condition := p.parseArithmeticExpression()
 	p.nextToken() // advance to next token
 
 	// Check if we have a comparison operator
 	if p.curTokenIs(GT) || p.curTokenIs(LT) || p.curTokenIs(EQ) || p.curTokenIs(NOT_EQ) {
 		operator := p.curToken.Literal
 		p.nextToken() // consume operator
-		right := p.parsePrimaryExpression()
+		right := p.parseArithmeticExpression()
 		p.nextToken() // advance to next token
 
-		stmt.Condition = &InfixExpression{
+		condition = &InfixExpression{
 			Token:    p.curToken,
-			Left:     stmt.Condition,
+			Left:     condition,
 			Operator: operator,
 			Right:    right,
 		}
 	}
 
-	if !p.curTokenIs(THEN) {
-		p.addError(fmt.Sprintf("expected THEN, got %s", p.curToken.Literal))
+	return condition
+}
+
+// parseArithmeticExpression parses + and - over terms, e.g. BANKROLL - $500,
+// leaving the parser on the last token of the expression
+func (p *Parser) parseArithmeticExpression() Expression {
+	return p.parseArithmeticFrom(p.parseTermExpression())
+}
+
+// parseArithmeticFrom continues an arithmetic expression whose first term has
+// already been parsed
+func (p *Parser) parseArithmeticFrom(left Expression) Expression {
+	for p.peekTokenIs(PLUS) || p.peekTokenIs(MINUS) {
+		p.nextToken()
+		operator := p.curToken
+		p.nextToken() // consume operator
+		left = &InfixExpression{
+			Token:    operator,
+			Left:     left,
+			Operator: operator.Literal,
+			Right:    p.parseTermExpression(),
+		}
+	}
+	return left
+}
+
+// parseTermExpression parses * and /, which bind tighter than + and -
+func (p *Parser) parseTermExpression() Expression {
+	return p.parseTermFrom(p.parsePrimaryExpression())
+}
+
+// parseTermFrom continues a term whose first factor has already been parsed
+func (p *Parser) parseTermFrom(left Expression) Expression {
+	for p.peekTokenIs(ASTERISK) || p.peekTokenIs(SLASH) {
+		p.nextToken()
+		operator := p.curToken
+		p.nextToken() // consume operator
+		left = &InfixExpression{
+			Token:    operator,
+			Left:     left,
+			Operator: operator.Literal,
+			Right:    p.parsePrimaryExpression(),
+		}
+	}
+	return left
+}
+
+// parseWhileStatement parses WHILE <condition> DO <statements> END;
+func (p *Parser) parseWhileStatement() *WhileStatement {
+	stmt := &WhileStatement{Token: p.curToken}
+	p.nextToken() // consume WHILE
+
+	stmt.Condition = p.parseCondition()
+
+	if !p.curTokenIs(DO) {
+		p.addError(fmt.Sprintf("expected DO, got %s", p.curToken.Literal))
 		return nil
 	}
-	p.nextToken() // consume THEN
+	p.nextToken() // consume DO
 
-	stmt.Consequence = p.parseBlockStatement()
+	// The body runs until the matching END; nested IF, WHILE and REPEAT consume their own
+	stmt.Body = &BlockStatement{Token: p.curToken, Statements: []Statement{}}
+	for !p.curTokenIs(END) {
+		if p.curTokenIs(EOF) {
+			p.addError("unexpected end of input: missing END for WHILE")
+			return nil
+		}
+		if s := p.parseStatement(); s != nil {
+			stmt.Body.Statements = append(stmt.Body.Statements, s)
+		}
+		p.nextToken()
+	}
 
-	// Check for ELSE clause
-	if p.peekTokenIs(ELSE) {
-		p.nextToken() // consume ELSE
-		stmt.Alternative = p.parseBlockStatement()
+	if p.peekTokenIs(SEMICOLON) {
+		p.nextToken() // consume semicolon
+	}
+
+	return stmt
+}
+
+// parseRepeatStatement parses REPEAT <count> TIMES <statements> END;
+func (p *Parser) parseRepeatStatement() *RepeatStatement {
+	stmt := &RepeatStatement{Token: p.curToken}
+	p.nextToken() // consume REPEAT
+
+	stmt.Count = p.parseArithmeticExpression()
+
+	if !p.expectPeek(TIMES) {
+		return nil
 	}
+	p.nextToken() // consume TIMES
 
-	// Expect END token
-	if p.peekTokenIs(END) {
-		p.nextToken() // consume END
-		if p.peekTokenIs(SEMICOLON) {
-			p.nextToken() // consume semicolon
+	stmt.Body = &BlockStatement{Token: p.curToken, Statements: []Statement{}}
+	for !p.curTokenIs(END) {
+		if p.curTokenIs(EOF) {
+			p.addError("unexpected end of input: missing END for REPEAT")
+			return nil
 		}
+		if s := p.parseStatement(); s != nil {
+			stmt.Body.Statements = append(stmt.Body.Statements, s)
+		}
+		p.nextToken()
+	}
+
+	if p.peekTokenIs(SEMICOLON) {
+		p.nextToken() // consume semicolon
 	}
 
 	return stmt
 }
 
-// parsePrimaryExpression parses primary expressions (identifiers, numbers, amounts)
+// parsePrimaryExpression parses primary expressions (identifiers, numbers,
+// amounts and parenthesized arithmetic)
 func (p *Parser) parsePrimaryExpression() Expression {
 	switch p.curToken.Type {
+	case LPAREN:
+		p.nextToken() // consume (
+		expr := p.parseArithmeticExpression()
+		if !p.expectPeek(RPAREN) {
+			return &NumberExpression{Token: p.curToken, Value: 0}
+		}
+		return expr
 	case IDENT:
 		expr := &IdentifierExpression{Token: p.curToken, Value: p.curToken.Literal}
 		return expr
@@ -628,37 +850,6 @@ func (p *Parser) parsePrimaryExpression() Expression {
 	}
 }
 
-func (p *Parser) parseBlockStatement() *BlockStatement {
-	block := &BlockStatement{Token: p.curToken}
-	block.Statements = []Statement{}
-
-	// Check if we have a brace to start a block
-	if p.peekTokenIs(LBRACE) {
-		p.nextToken() // consume {
-
-		for !p.curTokenIs(RBRACE) && !p.curTokenIs(EOF) {
-			stmt := p.parseStatement()
-			if stmt != nil {
-				block.Statements = append(block.Statements, stmt)
-			}
-			p.nextToken()
-		}
-
-		if p.curTokenIs(EOF) {
-			p.addError("unexpected end of input: missing closing brace '}'")
-			return nil
-		}
-	} else {
-		// Single statement without braces (for IF/THEN)
-		stmt := p.parseStatement()
-		if stmt != nil {
-			block.Statements = append(block.Statements, stmt)
-		}
-	}
-
-	return block
-}
-
 func (p *Parser) parseQueryStatement() *QueryStatement {
 	stmt := &QueryStatement{Token: p.curToken}
 
@@ -670,22 +861,164 @@ func (p *Parser) parseQueryStatement() *QueryStatement {
 		case "POINT":
 			stmt.Type = QueryPoint
 		case "BETS":
-			stmt.Type = QueryBets
+			stmt.Type = QueryMyBets
+			p.parseMyBetsFilter(stmt)
+		case "BET":
+			if !p.peekTokenIs(IDENT) || p.peekToken.Literal != "TYPES" {
+				p.addError(fmt.Sprintf("expected TYPES after BET, got %s", p.peekToken.Literal))
+				return nil
+			}
+			p.nextToken() // consume TYPES
+			stmt.Type = QueryBetTypes
 		case "BANKROLL":
 			stmt.Type = QueryBankroll
 		case "TABLE_MINIMUMS":
 			stmt.Type = QueryTableMinimums
 		case "ODDS_ALLOWED":
 			stmt.Type = QueryOddsAllowed
+		case "MY":
+			if !p.peekTokenIs(IDENT) || p.peekToken.Literal != "BETS" {
+				p.addError(fmt.Sprintf("expected BETS after MY, got %s", p.peekToken.Literal))
+				return nil
+			}
+			p.nextToken() // consume BETS
+			stmt.Type = QueryMyBets
+			p.parseMyBetsFilter(stmt)
+		case "WAYS":
+			if !p.expectPeek(NUMBER) {
+				return nil
+			}
+			total, err := strconv.Atoi(p.curToken.Literal)
+			if err != nil {
+				p.addError(fmt.Sprintf("invalid total: %s", p.curToken.Literal))
+				return nil
+			}
+			stmt.Type = QueryWays
+			stmt.Value = &NumberExpression{Token: p.curToken, Value: float64(total)}
+		case "VIG":
+			p.nextToken() // advance to bet type
+			stmt.BetType = p.parseBetTypeExpression()
+			if stmt.BetType == nil {
+				return nil
+			}
+			stmt.Type = QueryVig
+		case "IMPLIED":
+			p.nextToken() // advance to bet type
+			stmt.BetType = p.parseBetTypeExpression()
+			if stmt.BetType == nil {
+				return nil
+			}
+			stmt.Type = QueryImplied
+		case "VARIANCE":
+			p.nextToken() // advance to bet type
+			stmt.BetType = p.parseBetTypeExpression()
+			if stmt.BetType == nil {
+				return nil
+			}
+			stmt.Type = QueryVariance
+		case "BEHAVIOR":
+			p.nextToken() // advance to bet type
+			stmt.BetType = p.parseBetTypeExpression()
+			if stmt.BetType == nil {
+				return nil
+			}
+			stmt.Type = QueryBehavior
+		case "AVG":
+			if !p.peekTokenIs(IDENT) || p.peekToken.Literal != "BET" {
+				p.addError(fmt.Sprintf("expected BET after AVG, got %s", p.peekToken.Literal))
+				return nil
+			}
+			p.nextToken() // consume BET
+			stmt.Type = QueryAvgBet
+		case "MIN":
+			if !p.peekTokenIs(IDENT) || p.peekToken.Literal != "BANKROLL" {
+				p.addError(fmt.Sprintf("expected BANKROLL after MIN, got %s", p.peekToken.Literal))
+				return nil
+			}
+			p.nextToken() // consume BANKROLL
+			p.nextToken() // advance to bet type
+			stmt.BetType = p.parseBetTypeExpression()
+			if stmt.BetType == nil {
+				return nil
+			}
+			stmt.Type = QueryMinBankroll
+		case "OUTCOME":
+			if !p.peekTokenIs(IDENT) || p.peekToken.Literal != "HISTOGRAM" {
+				p.addError(fmt.Sprintf("expected HISTOGRAM after OUTCOME, got %s", p.peekToken.Literal))
+				return nil
+			}
+			p.nextToken() // consume HISTOGRAM
+			stmt.Type = QueryOutcomeHistogram
+		case "SHOOTER":
+			stmt.Type = QueryShooter
+		case "REMAINING":
+			if !p.peekTokenIs(IDENT) || p.peekToken.Literal != "ACTION" {
+				p.addError(fmt.Sprintf("expected ACTION after REMAINING, got %s", p.peekToken.Literal))
+				return nil
+			}
+			p.nextToken() // consume ACTION
+			stmt.Type = QueryRemainingAction
+		case "HISTORY":
+			stmt.Type = QueryHistory
+		case "COST":
+			stmt.Type = QueryCost
+		case "COMP":
+			stmt.Type = QueryComp
+		case "HOUSE":
+			stmt.Type = QueryHouse
+		case "STREAK":
+			stmt.Type = QueryStreak
+		case "STATS":
+			stmt.Type = QueryStats
+		case "SEVEN_EXPOSURE":
+			stmt.Type = QuerySevenExposure
+		case "BREAKEVEN":
+			stmt.Type = QueryBreakEven
+		case "WHY":
+			stmt.Type = QueryWhy
+		case "TABLE":
+			if !p.peekTokenIs(IDENT) || p.peekToken.Literal != "TOTAL" {
+				p.addError(fmt.Sprintf("expected TOTAL after TABLE, got %s", p.peekToken.Literal))
+				return nil
+			}
+			p.nextToken() // consume TOTAL
+			stmt.Type = QueryTableTotal
 		default:
 			p.addError(fmt.Sprintf("unknown query type: %s", p.curToken.Literal))
 			return nil
 		}
+	case ODDS:
+		stmt.Type = QueryOdds
+	case IF:
+		// SHOW IF ROLL <total>; or SHOW IF ROLL <die> <die>;
+		if !p.expectPeek(ROLL) {
+			return nil
+		}
+		for p.peekTokenIs(NUMBER) && len(stmt.Dice) < 2 {
+			p.nextToken()
+			value, err := strconv.Atoi(p.curToken.Literal)
+			if err != nil {
+				p.addError(fmt.Sprintf("invalid roll: %s", p.curToken.Literal))
+				return nil
+			}
+			stmt.Dice = append(stmt.Dice, value)
+		}
+		if len(stmt.Dice) == 0 {
+			p.addError(fmt.Sprintf("expected a total or two dice after ROLL, got %s", p.peekToken.Literal))
+			return nil
+		}
+		stmt.Type = QueryIfRoll
 	default:
 		p.addError(fmt.Sprintf("expected identifier, got %s", p.curToken.Literal))
 		return nil
 	}
 
+	player, ok := p.parseForPlayer()
+	if !ok {
+		return nil
+	}
+	stmt.Player = player
+
 	if !p.expectPeek(SEMICOLON) {
 		return nil
 	}
@@ -693,6 +1026,29 @@ func (p *Parser) parseQueryStatement() *QueryStatement {
 	return stmt
 }
 
+// parseForPlayer reads an optional FOR <player> clause following the current
+// token and returns the player named, or "" without one. ok is false if FOR
+// isn't followed by a player.
+func (p *Parser) parseForPlayer() (player string, ok bool) {
+	if !p.peekTokenIs(FOR) {
+		return "", true
+	}
+	p.nextToken() // consume FOR
+	if !p.expectPeek(IDENT) {
+		return "", false
+	}
+	return p.curToken.Literal, true
+}
+
+// parseMyBetsFilter reads the optional ONE_ROLL / MULTI_ROLL filter after
+// SHOW BETS or SHOW MY BETS
+func (p *Parser) parseMyBetsFilter(stmt *QueryStatement) {
+	if p.peekTokenIs(ONE_ROLL) || (p.peekTokenIs(IDENT) && p.peekToken.Literal == "MULTI_ROLL") {
+		p.nextToken()
+		stmt.Filter = p.curToken.Literal
+	}
+}
+
 func (p *Parser) parseManagementStatement() *ManagementStatement {
 	stmt := &ManagementStatement{Token: p.curToken}
 
@@ -718,6 +1074,8 @@ func (p *Parser) parseManagementStatement() *ManagementStatement {
 		}
 	case MAX_BET:
 		stmt.Type = ManageMaxBet
+	case DICE:
+		stmt.Type = ManageDiceSet
 	default:
 		p.addError(fmt.Sprintf("expected identifier or management type, got %s", p.curToken.Literal))
 		return nil
@@ -725,9 +1083,9 @@ func (p *Parser) parseManagementStatement() *ManagementStatement {
 
 	p.nextToken() // consume management type
 
-	// Handle optional "TO" keyword
-	if p.curToken.Type == TO {
-		p.nextToken() // consume TO
+	// Handle optional "TO" keyword or "=" (SET BANKROLL = $1000)
+	if p.curToken.Type == TO || p.curToken.Type == EQUALS {
+		p.nextToken() // consume TO or =
 	}
 
 	// Parse value with support for different types
@@ -757,11 +1115,61 @@ func (p *Parser) parseManagementStatement() *ManagementStatement {
 	case IDENT:
 		// Handle identifier values (like "ON", "OFF", etc.)
 		stmt.Value = &IdentifierExpression{Token: p.curToken, Value: p.curToken.Literal}
+	case LPAREN:
+		stmt.Value = p.parsePrimaryExpression()
+	case STRING:
+		// Handle labels (like SET DICE "hard_ways")
+		stmt.Value = &StringExpression{Token: p.curToken, Value: p.curToken.Literal}
 	default:
 		p.addError(fmt.Sprintf("expected $, number, or identifier, got %s", p.curToken.Literal))
 		return nil
 	}
 
+	// Amounts can be arithmetic, e.g. SET BANKROLL $1000 + $500
+	if _, isString := stmt.Value.(*StringExpression); !isString {
+		stmt.Value = p.parseArithmeticFrom(p.parseTermFrom(stmt.Value))
+	}
+
+	// SET BANKROLL $1000 RESET STATS;
+	if stmt.Type == ManageBankroll && p.peekTokenIs(RESET) {
+		p.nextToken() // consume RESET
+		if !p.peekTokenIs(IDENT) || p.peekToken.Literal != "STATS" {
+			p.addError(fmt.Sprintf("expected STATS after RESET, got %s", p.peekToken.Literal))
+			return nil
+		}
+		p.nextToken() // consume STATS
+		stmt.ResetStats = true
+	}
+
+	player, ok := p.parseForPlayer()
+	if !ok {
+		return nil
+	}
+	stmt.Player = player
+
+	if !p.expectPeek(SEMICOLON) {
+		return nil
+	}
+
+	return stmt
+}
+
+// parseTakeDownStatement parses TAKE DOWN ALL; (or TAKE_DOWN ALL;)
+func (p *Parser) parseTakeDownStatement() *RemoveStatement {
+	stmt := &RemoveStatement{Token: p.curToken, TakeDown: true}
+
+	if p.curTokenIs(TAKE) {
+		if !p.peekTokenIs(IDENT) || p.peekToken.Literal != "DOWN" {
+			p.addError(fmt.Sprintf("expected DOWN after TAKE, got %s", p.peekToken.Literal))
+			return nil
+		}
+		p.nextToken() // consume DOWN
+	}
+
+	if !p.expectPeek(ALL) {
+		return nil
+	}
+
 	if !p.expectPeek(SEMICOLON) {
 		return nil
 	}
@@ -778,6 +1186,14 @@ func (p *Parser) parseRemoveStatement() *RemoveStatement {
 	if p.curTokenIs(ALL) {
 		// REMOVE ALL case - BetType remains nil
 		// Don't advance past ALL, let expectPeek handle the semicolon
+	} else if p.curTokenIs(IDENT) && p.curToken.Literal == "BET" {
+		// REMOVE BET <id> case - IDs starting with a digit must be quoted
+		if !p.peekTokenIs(IDENT) && !p.peekTokenIs(STRING) {
+			p.addError(fmt.Sprintf("expected bet ID after BET, got %s", p.peekToken.Literal))
+			return nil
+		}
+		p.nextToken() // consume BET
+		stmt.BetID = p.curToken.Literal
 	} else {
 		// REMOVE <bet_type> case - parse the bet type
 		stmt.BetType = p.parseBetTypeExpression()
@@ -798,31 +1214,33 @@ func (p *Parser) parsePressStatement() *PressStatement {
 
 	// Parse bet type
 	stmt.BetType = p.parseBetTypeExpression()
-
-	if !p.expectPeek(BY) {
-		return nil
-	}
-	p.nextToken() // consume BY
-
-	if !p.expectPeek(DOLLAR) {
+	if stmt.BetType == nil {
 		return nil
 	}
-	p.nextToken() // consume $
 
-	if !p.expectPeek(NUMBER) {
+	switch {
+	case p.peekTokenIs(BY):
+		stmt.Mode = PressBy
+	case p.peekTokenIs(TO):
+		stmt.Mode = PressTo
+	case p.peekTokenIs(IDENT) && p.peekToken.Literal == "FULL":
+		stmt.Mode = PressFull
+	default:
+		p.addError(fmt.Sprintf("expected BY, TO or FULL after PRESS %s, got %s", stmt.BetType.Token.Literal, p.peekToken.Literal))
 		return nil
 	}
-	p.nextToken() // consume number
+	p.nextToken() // advance to BY, TO or FULL
 
-	// Parse amount
-	amount := &AmountExpression{Token: p.curToken}
-	val, err := parseAmount(p.curToken.Literal)
-	if err != nil {
-		p.addError(fmt.Sprintf("invalid amount: %s", p.curToken.Literal))
-		return nil
+	if stmt.Mode != PressFull {
+		amount, valid := p.parseAmountExpression()
+		if amount == nil {
+			return nil
+		}
+		stmt.Amount = amount
+		if !valid {
+			return nil
+		}
 	}
-	amount.Value = val
-	stmt.Amount = amount
 
 	if !p.expectPeek(SEMICOLON) {
 		return nil
@@ -880,21 +1298,145 @@ func (p *Parser) expectPeek(t TokenType) bool {
 func (p *Parser) peekError(t TokenType) {
 	msg := fmt.Sprintf("expected next token to be %s, got %s instead",
 		t.String(), p.peekToken.Type.String())
-	p.errors = append(p.errors, msg)
+	p.addErrorAt(p.peekToken, msg)
 }
 
+// addError records an error at the current token
 func (p *Parser) addError(msg string) {
-	p.errors = append(p.errors, msg)
+	p.addErrorAt(p.curToken, msg)
+}
+
+// addErrorAt records an error at the position of tok
+func (p *Parser) addErrorAt(tok Token, msg string) {
+	p.errors = append(p.errors, &ParseError{Message: msg, Line: tok.Line, Column: tok.Column})
 }
 
+// Errors returns each parse error as a string led by its position, e.g.
+// "line 3, col 12: unexpected token 'INVALID'"
 func (p *Parser) Errors() []string {
+	messages := make([]string, len(p.errors))
+	for i, err := range p.errors {
+		messages[i] = err.Error()
+	}
+	return messages
+}
+
+// ParseErrors returns the parse errors with their positions
+func (p *Parser) ParseErrors() []*ParseError {
 	return p.errors
 }
 
+// parseAlwaysStatement parses ALWAYS PLACE $amount ON bet_type;
+func (p *Parser) parseAlwaysStatement() *AlwaysStatement {
+	stmt := &AlwaysStatement{Token: p.curToken}
+
+	if !p.expectPeek(PLACE) {
+		return nil
+	}
+	stmt.Bet = p.parseBetStatement()
+	if stmt.Bet == nil {
+		return nil
+	}
+	return stmt
+}
+
+// parseLetStatement parses LET name = $amount;
+func (p *Parser) parseLetStatement() *LetStatement {
+	stmt := &LetStatement{Token: p.curToken}
+
+	if !p.expectPeek(IDENT) {
+		return nil
+	}
+	stmt.Name = p.curToken.Literal
+
+	if !p.expectPeek(EQUALS) {
+		return nil
+	}
+
+	value, valid := p.parseAmountExpression()
+	if value == nil {
+		return nil
+	}
+	stmt.Value = value
+
+	if !p.expectPeek(SEMICOLON) || !valid {
+		return nil
+	}
+	return stmt
+}
+
+// parseRunStrategyStatement parses RUN STRATEGY "name" ON bet_type BASE $amount;
+func (p *Parser) parseRunStrategyStatement() *RunStrategyStatement {
+	stmt := &RunStrategyStatement{Token: p.curToken}
+
+	if !p.peekTokenIs(IDENT) || p.peekToken.Literal != "STRATEGY" {
+		p.addError(fmt.Sprintf("expected STRATEGY after RUN, got %s", p.peekToken.Literal))
+		return nil
+	}
+	p.nextToken() // consume STRATEGY
+
+	if !p.expectPeek(STRING) {
+		return nil
+	}
+	stmt.Name = p.curToken.Literal
+
+	if !p.expectPeek(ON) {
+		return nil
+	}
+	p.nextToken() // advance to bet type
+
+	stmt.BetType = p.parseBetTypeExpression()
+	if stmt.BetType == nil {
+		return nil
+	}
+
+	if !p.peekTokenIs(IDENT) || p.peekToken.Literal != "BASE" {
+		p.addError(fmt.Sprintf("expected BASE after the strategy's bet type, got %s", p.peekToken.Literal))
+		return nil
+	}
+	p.nextToken() // consume BASE
+
+	base, valid := p.parseAmountExpression()
+	if base == nil {
+		return nil
+	}
+	stmt.Base = base
+
+	if !p.expectPeek(SEMICOLON) || !valid {
+		return nil
+	}
+	return stmt
+}
+
+// parseCancelStatement parses CANCEL ALWAYS bet_type;
+func (p *Parser) parseCancelStatement() *CancelStatement {
+	stmt := &CancelStatement{Token: p.curToken}
+
+	if !p.expectPeek(ALWAYS) {
+		return nil
+	}
+	p.nextToken() // advance to bet type
+
+	stmt.BetType = p.parseBetTypeExpression()
+
+	if !p.expectPeek(SEMICOLON) {
+		return nil
+	}
+	return stmt
+}
+
 func (p *Parser) parseRollStatement() *RollStatement {
 	stmt := &RollStatement{Token: p.curToken}
 
-	if !p.expectPeek(DICE) {
+	if p.peekTokenIs(IDENT) && p.peekToken.Literal == "UNTIL" {
+		p.nextToken() // consume UNTIL
+		if !p.peekTokenIs(IDENT) || p.peekToken.Literal != "RESOLVED" {
+			p.addError(fmt.Sprintf("expected RESOLVED after UNTIL, got %s", p.peekToken.Literal))
+			return nil
+		}
+		p.nextToken() // consume RESOLVED
+		stmt.UntilResolved = true
+	} else if !p.expectPeek(DICE) {
 		return nil
 	}
 	if !p.expectPeek(SEMICOLON) {
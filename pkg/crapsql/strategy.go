@@ -0,0 +1,228 @@
+package crapsql
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/headswim/CrapsQL/pkg/crapsgame"
+)
+
+// Strategy sizes the bets of a built-in strategy started with RUN STRATEGY
+type Strategy interface {
+	// NextAmount returns the next bet after the last one was decided
+	NextAmount(last float64, won bool) float64
+}
+
+// StrategyFactory builds a strategy from its base bet
+type StrategyFactory func(base float64) Strategy
+
+var strategies = map[string]StrategyFactory{
+	"martingale": newMartingale,
+}
+
+// RegisterStrategy makes a strategy available to RUN STRATEGY by name,
+// replacing any strategy already registered under that name
+func RegisterStrategy(name string, factory StrategyFactory) {
+	strategies[strings.ToLower(name)] = factory
+}
+
+// martingale doubles the bet after each loss and goes back to the base bet
+// after a win
+type martingale struct {
+	base float64
+}
+
+func newMartingale(base float64) Strategy {
+	return martingale{base: base}
+}
+
+func (m martingale) NextAmount(last float64, won bool) float64 {
+	if won {
+		return m.base
+	}
+	return last * 2
+}
+
+// runningStrategy is a strategy a player has started on one bet type
+type runningStrategy struct {
+	name     string
+	strategy Strategy
+	betType  string
+	amount   float64 // the bet on the layout, or the next one to place
+	betID    string  // the bet on the layout, "" until it is placed
+}
+
+func (i *Interpreter) executeRunStrategyStatement(stmt *RunStrategyStatement) (string, error) {
+	var playerID string
+	for id := range i.table.Players {
+		playerID = id
+		break
+	}
+
+	if playerID == "" {
+		return "", fmt.Errorf("no players at table - add a player first")
+	}
+
+	return i.executeRunStrategyStatementForPlayer(stmt, playerID)
+}
+
+// executeRunStrategyStatementForPlayer starts a strategy and places its first
+// bet. Starting a strategy on a bet type that already has one replaces it.
+func (i *Interpreter) executeRunStrategyStatementForPlayer(stmt *RunStrategyStatement, playerID string) (string, error) {
+	if _, err := i.table.GetPlayer(playerID); err != nil {
+		return "", err
+	}
+
+	factory, exists := strategies[strings.ToLower(stmt.Name)]
+	if !exists {
+		return "", fmt.Errorf("unknown strategy: %s", stmt.Name)
+	}
+
+	base, err := i.extractAmountFromExpression(stmt.Base, playerID)
+	if err != nil {
+		return "", err
+	}
+	if base <= 0 {
+		return "", fmt.Errorf("strategy base bet must be positive, got $%.2f", base)
+	}
+
+	betType := i.betTypeToString(stmt.BetType.Type)
+	if !strategyBetType(betType) {
+		return "", fmt.Errorf("RUN STRATEGY needs a line, come or one-roll bet, not %s", betType)
+	}
+	running := &runningStrategy{
+		name:     strings.ToLower(stmt.Name),
+		strategy: factory(base),
+		betType:  betType,
+		amount:   base,
+	}
+
+	var kept []*runningStrategy
+	for _, existing := range i.strategies[playerID] {
+		if existing.betType != betType {
+			kept = append(kept, existing)
+		}
+	}
+	i.strategies[playerID] = append(kept, running)
+
+	result := fmt.Sprintf("🤖 Running %s on %s from $%.2f", running.name, betType, base)
+	placed, err := i.placeStrategyBet(playerID, running)
+	if err != nil {
+		// Retried after the next roll, e.g. a pass line bet while a point is on
+		return result + fmt.Sprintf("\n⚠️ First bet waits for the next roll: %v", err), nil
+	}
+	return result + "\n" + placed, nil
+}
+
+// strategyBetType reports whether a strategy can size bets of a type. A
+// strategy moves on when its bet comes down with a decision, so it takes bets
+// that do: line and come bets and one-roll bets. A place bet that wins stays
+// up and would never hand the strategy a result.
+func strategyBetType(betType string) bool {
+	def, exists := crapsgame.GetBetDefinition(betType)
+	if !exists {
+		return false
+	}
+	return def.OneRoll || def.Category == crapsgame.LineBets || def.Category == crapsgame.ComeBets
+}
+
+// placeStrategyBet places a strategy's next bet, capped at the lower of the
+// table and player maximums and at the player's bankroll
+func (i *Interpreter) placeStrategyBet(playerID string, running *runningStrategy) (string, error) {
+	player, err := i.table.GetPlayer(playerID)
+	if err != nil {
+		return "", err
+	}
+	amount := math.Min(running.amount, i.table.MaxBet)
+	if player.MaxBet > 0 {
+		amount = math.Min(amount, player.MaxBet)
+	}
+	amount = math.Min(amount, player.Bankroll)
+
+	bet, err := i.table.PlaceBet(playerID, running.betType, amount, nil)
+	if err != nil {
+		return "", err
+	}
+	running.amount = bet.Amount
+	running.betID = bet.ID
+	return fmt.Sprintf("🤖 %s: $%.2f on %s for %s", running.name, bet.Amount, running.betType, playerID), nil
+}
+
+// advanceStrategies sizes and places the next bet for each running strategy
+// whose last bet has been decided. Players and bet types are visited in order
+// so the output is stable; a bet that can't be placed is retried after the
+// next roll.
+func (i *Interpreter) advanceStrategies() []string {
+	var playerIDs []string
+	for id := range i.strategies {
+		playerIDs = append(playerIDs, id)
+	}
+	sort.Strings(playerIDs)
+
+	var results []string
+	for _, playerID := range playerIDs {
+		if _, err := i.table.GetPlayer(playerID); err != nil {
+			// The player left the table
+			delete(i.strategies, playerID)
+			continue
+		}
+
+		running := i.strategies[playerID]
+		sort.Slice(running, func(a, b int) bool { return running[a].betType < running[b].betType })
+		for _, strategy := range running {
+			if strategy.betID != "" {
+				if hasBetID(i.table, playerID, strategy.betID) {
+					continue
+				}
+				if won, decided := i.betOutcome(playerID, strategy.betID); decided {
+					strategy.amount = strategy.strategy.NextAmount(strategy.amount, won)
+				}
+				strategy.betID = ""
+			}
+
+			placed, err := i.placeStrategyBet(playerID, strategy)
+			if err != nil {
+				results = append(results, fmt.Sprintf("⚠️ Could not place %s bet on %s for %s: %v", strategy.name, strategy.betType, playerID, err))
+				continue
+			}
+			results = append(results, placed)
+		}
+	}
+	return results
+}
+
+// betOutcome looks up how a bet was decided in the table ledger. decided is
+// false for a bet that came down without a decision, such as one removed or
+// one that pushed.
+func (i *Interpreter) betOutcome(playerID, betID string) (won, decided bool) {
+	transactions := i.table.GetTransactions(playerID)
+	for idx := len(transactions) - 1; idx >= 0; idx-- {
+		tx := transactions[idx]
+		if tx.BetID != betID {
+			continue
+		}
+		switch tx.Type {
+		case crapsgame.TransactionWin:
+			return true, true
+		case crapsgame.TransactionLoss:
+			return false, true
+		}
+	}
+	return false, false
+}
+
+// hasBetID reports whether the player still has the bet on the layout
+func hasBetID(table *crapsgame.Table, playerID, betID string) bool {
+	player, err := table.GetPlayer(playerID)
+	if err != nil {
+		return false
+	}
+	for _, bet := range player.Bets {
+		if bet.ID == betID {
+			return true
+		}
+	}
+	return false
+}
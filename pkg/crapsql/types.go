@@ -18,6 +18,7 @@ const (
 	IDENT  // bet types, keywords
 	DOLLAR // $
 	NUMBER // 25, 100, etc.
+	STRING // "hard_ways"
 
 	// Keywords
 	PLACE
@@ -27,6 +28,8 @@ const (
 	THEN
 	ELSE
 	END
+	WHILE
+	DO
 	SET
 	SHOW
 	DEFINE
@@ -51,6 +54,14 @@ const (
 	WORKING_KEYWORD
 	ROLL
 	DICE
+	ALWAYS
+	CANCEL
+	LET
+	RUN
+	TAKE
+	REPEAT
+	TIMES
+	FOR
 
 	// Bet types
 	PASS_LINE
@@ -64,6 +75,7 @@ const (
 	ACE_DEUCE
 	ACES
 	BOXCARS
+	COME_OUT_HARDWAYS
 	PLACE_4
 	PLACE_5
 	PLACE_6
@@ -87,7 +99,10 @@ const (
 	BIG_6
 	BIG_8
 	HOP
+	HOP_HARD_4
 	HOP_HARD_6
+	HOP_HARD_8
+	HOP_HARD_10
 	HOP_EASY_8
 	WORLD
 	C_AND_E
@@ -213,20 +228,33 @@ type BetStatement struct {
 	Amount    *AmountExpression
 	BetType   *BetTypeExpression
 	Modifiers []*ModifierExpression
+	Player    string // FOR <player>; "" bets for the player running the script
 }
 
 func (bs *BetStatement) statementNode()       {}
 func (bs *BetStatement) TokenLiteral() string { return bs.Token.Literal }
 
-// AmountExpression represents a dollar amount
+// AmountExpression represents a dollar amount, a reference to a variable
+// holding one when Name is set, or arithmetic such as $25 * 2 when Expr is set
 type AmountExpression struct {
 	Token Token
 	Value float64
+	Name  string
+	Expr  Expression
 }
 
 func (ae *AmountExpression) expressionNode()      {}
 func (ae *AmountExpression) TokenLiteral() string { return ae.Token.Literal }
 
+// StringExpression represents a quoted string literal
+type StringExpression struct {
+	Token Token
+	Value string
+}
+
+func (se *StringExpression) expressionNode()      {}
+func (se *StringExpression) TokenLiteral() string { return se.Token.Literal }
+
 // BetTypeExpression represents a bet type
 type BetTypeExpression struct {
 	Token Token
@@ -287,6 +315,26 @@ type ConditionalStatement struct {
 func (cs *ConditionalStatement) statementNode()       {}
 func (cs *ConditionalStatement) TokenLiteral() string { return cs.Token.Literal }
 
+// WhileStatement represents WHILE/DO/END loops
+type WhileStatement struct {
+	Token     Token
+	Condition Expression
+	Body      *BlockStatement
+}
+
+func (ws *WhileStatement) statementNode()       {}
+func (ws *WhileStatement) TokenLiteral() string { return ws.Token.Literal }
+
+// RepeatStatement represents REPEAT <count> TIMES ... END loops
+type RepeatStatement struct {
+	Token Token
+	Count Expression
+	Body  *BlockStatement
+}
+
+func (rs *RepeatStatement) statementNode()       {}
+func (rs *RepeatStatement) TokenLiteral() string { return rs.Token.Literal }
+
 // BlockStatement represents a block of statements
 type BlockStatement struct {
 	Token      Token
@@ -298,8 +346,13 @@ func (bs *BlockStatement) TokenLiteral() string { return bs.Token.Literal }
 
 // QueryStatement represents SHOW commands
 type QueryStatement struct {
-	Token Token
-	Type  QueryType
+	Token   Token
+	Type    QueryType
+	Filter  string             // optional filter, e.g. ONE_ROLL or MULTI_ROLL for SHOW MY BETS
+	Value   Expression         // optional argument, e.g. the total for SHOW WAYS
+	BetType *BetTypeExpression // optional bet type, e.g. for SHOW VIG
+	Dice    []int              // a total or a pair of dice for SHOW IF ROLL
+	Player  string             // FOR <player>; "" asks about the player running the script
 }
 
 func (qs *QueryStatement) statementNode()       {}
@@ -307,9 +360,11 @@ func (qs *QueryStatement) TokenLiteral() string { return qs.Token.Literal }
 
 // ManagementStatement represents SET commands
 type ManagementStatement struct {
-	Token Token
-	Type  ManagementType
-	Value Expression
+	Token      Token
+	Type       ManagementType
+	Value      Expression
+	ResetStats bool   // SET BANKROLL ... RESET STATS starts the session stats over
+	Player     string // FOR <player>; "" sets the player running the script
 }
 
 func (ms *ManagementStatement) statementNode()       {}
@@ -317,8 +372,10 @@ func (ms *ManagementStatement) TokenLiteral() string { return ms.Token.Literal }
 
 // RemoveStatement represents REMOVE BET commands
 type RemoveStatement struct {
-	Token   Token
-	BetType *BetTypeExpression
+	Token    Token
+	BetType  *BetTypeExpression
+	BetID    string // set for REMOVE BET <id>
+	TakeDown bool   // TAKE DOWN ALL: like REMOVE ALL, but contract bets stay up
 }
 
 func (rs *RemoveStatement) statementNode()       {}
@@ -328,9 +385,19 @@ func (rs *RemoveStatement) TokenLiteral() string { return rs.Token.Literal }
 type PressStatement struct {
 	Token   Token
 	BetType *BetTypeExpression
-	Amount  *AmountExpression
+	Mode    PressMode
+	Amount  *AmountExpression // the amount to add for BY, the new amount for TO
 }
 
+// PressMode is how a PRESS statement raises a bet
+type PressMode int
+
+const (
+	PressBy   PressMode = iota // PRESS PLACE_6 BY $6
+	PressTo                    // PRESS PLACE_6 TO $30
+	PressFull                  // PRESS PLACE_6 FULL (double it)
+)
+
 func (ps *PressStatement) statementNode()       {}
 func (ps *PressStatement) TokenLiteral() string { return ps.Token.Literal }
 
@@ -344,14 +411,56 @@ type TurnStatement struct {
 func (ts *TurnStatement) statementNode()       {}
 func (ts *TurnStatement) TokenLiteral() string { return ts.Token.Literal }
 
-// RollStatement represents a ROLL DICE command
+// RollStatement represents a ROLL DICE or ROLL UNTIL RESOLVED command
 type RollStatement struct {
-	Token Token
+	Token         Token
+	UntilResolved bool // keep rolling until one of the player's bets wins or loses
 }
 
 func (rs *RollStatement) statementNode()       {}
 func (rs *RollStatement) TokenLiteral() string { return rs.Token.Literal }
 
+// AlwaysStatement represents ALWAYS PLACE commands, a standing bet that is
+// put back on the layout after every roll until cancelled
+type AlwaysStatement struct {
+	Token Token
+	Bet   *BetStatement
+}
+
+func (as *AlwaysStatement) statementNode()       {}
+func (as *AlwaysStatement) TokenLiteral() string { return as.Token.Literal }
+
+// LetStatement represents LET commands, which store an amount in a variable
+type LetStatement struct {
+	Token Token
+	Name  string
+	Value *AmountExpression
+}
+
+func (ls *LetStatement) statementNode()       {}
+func (ls *LetStatement) TokenLiteral() string { return ls.Token.Literal }
+
+// RunStrategyStatement represents RUN STRATEGY commands, which hand a bet
+// type over to a built-in strategy
+type RunStrategyStatement struct {
+	Token   Token
+	Name    string
+	BetType *BetTypeExpression
+	Base    *AmountExpression
+}
+
+func (rs *RunStrategyStatement) statementNode()       {}
+func (rs *RunStrategyStatement) TokenLiteral() string { return rs.Token.Literal }
+
+// CancelStatement represents CANCEL ALWAYS commands
+type CancelStatement struct {
+	Token   Token
+	BetType *BetTypeExpression
+}
+
+func (cs *CancelStatement) statementNode()       {}
+func (cs *CancelStatement) TokenLiteral() string { return cs.Token.Literal }
+
 // Bet types
 type BetType int
 
@@ -370,6 +479,7 @@ const (
 	BetAceDeuce
 	BetAces
 	BetBoxcars
+	BetComeOutHardways
 
 	// Place bets
 	BetPlace4
@@ -405,7 +515,10 @@ const (
 
 	// Hop bets
 	BetHop
+	BetHopHard4
 	BetHopHard6
+	BetHopHard8
+	BetHopHard10
 	BetHopEasy8
 
 	// Proposition bets
@@ -474,6 +587,10 @@ const (
 	ModMax
 	ModAmount
 	ModRatio
+	ModFullOdds   // take the table maximum odds behind the line bet
+	ModDoubleOdds // take 2x odds behind the line bet
+	ModParlay     // let a one-roll bet's wins ride until it loses
+	ModKeep       // put a one-roll bet back up each time it loses
 )
 
 // Query types
@@ -481,10 +598,33 @@ type QueryType int
 
 const (
 	QueryPoint QueryType = iota
-	QueryBets
+	QueryBetTypes
 	QueryBankroll
 	QueryTableMinimums
 	QueryOddsAllowed
+	QueryMyBets
+	QueryWays
+	QueryVig
+	QueryAvgBet
+	QueryOutcomeHistogram
+	QueryShooter
+	QueryRemainingAction
+	QueryHistory
+	QueryImplied
+	QueryTableTotal
+	QueryOdds
+	QueryWhy
+	QueryVariance
+	QueryBehavior
+	QueryCost
+	QueryStreak
+	QueryIfRoll
+	QueryStats
+	QuerySevenExposure
+	QueryBreakEven
+	QueryMinBankroll
+	QueryComp
+	QueryHouse
 )
 
 // Management types
@@ -497,9 +637,12 @@ const (
 	ManageWinGoal
 	ManageLossLimit
 	ManageSessionTime
+	ManageDiceSet
 )
 
 // Error types
+
+// ParseError is a parse error at the position of the token it was found at
 type ParseError struct {
 	Message string
 	Line    int
@@ -507,7 +650,7 @@ type ParseError struct {
 }
 
 func (e *ParseError) Error() string {
-	return fmt.Sprintf("parse error at line %d, column %d: %s", e.Line, e.Column, e.Message)
+	return fmt.Sprintf("line %d, col %d: %s", e.Line, e.Column, e.Message)
 }
 
 // Helper function to convert string to float64
@@ -530,6 +673,8 @@ func (t TokenType) String() string {
 		return "DOLLAR"
 	case NUMBER:
 		return "NUMBER"
+	case STRING:
+		return "STRING"
 	case PLACE:
 		return "PLACE"
 	case ON:
@@ -544,6 +689,10 @@ func (t TokenType) String() string {
 		return "ELSE"
 	case END:
 		return "END"
+	case WHILE:
+		return "WHILE"
+	case DO:
+		return "DO"
 	case SET:
 		return "SET"
 	case SHOW:
@@ -590,6 +739,22 @@ func (t TokenType) String() string {
 		return "ROLL"
 	case DICE:
 		return "DICE"
+	case ALWAYS:
+		return "ALWAYS"
+	case CANCEL:
+		return "CANCEL"
+	case LET:
+		return "LET"
+	case RUN:
+		return "RUN"
+	case TAKE:
+		return "TAKE"
+	case REPEAT:
+		return "REPEAT"
+	case TIMES:
+		return "TIMES"
+	case FOR:
+		return "FOR"
 	case PASS_LINE:
 		return "PASS_LINE"
 	case DONT_PASS:
@@ -612,6 +777,8 @@ func (t TokenType) String() string {
 		return "ACES"
 	case BOXCARS:
 		return "BOXCARS"
+	case COME_OUT_HARDWAYS:
+		return "COME_OUT_HARDWAYS"
 	case PLACE_4:
 		return "PLACE_4"
 	case PLACE_5:
@@ -658,8 +825,14 @@ func (t TokenType) String() string {
 		return "BIG_8"
 	case HOP:
 		return "HOP"
+	case HOP_HARD_4:
+		return "HOP_HARD_4"
 	case HOP_HARD_6:
 		return "HOP_HARD_6"
+	case HOP_HARD_8:
+		return "HOP_HARD_8"
+	case HOP_HARD_10:
+		return "HOP_HARD_10"
 	case HOP_EASY_8:
 		return "HOP_EASY_8"
 	case WORLD: